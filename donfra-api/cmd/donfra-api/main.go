@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"donfra-api/internal/config"
+	"donfra-api/internal/domain/auth"
+	"donfra-api/internal/domain/db"
+	"donfra-api/internal/domain/interview"
+	"donfra-api/internal/domain/problem"
+	"donfra-api/internal/domain/room"
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/domain/user"
+	"donfra-api/internal/http/router"
+	"donfra-api/internal/pkg/mailer"
+	"donfra-api/internal/pkg/tracing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func main() {
+	cfg := config.Load()
+
+	// Initialize Jaeger tracing
+	shutdown, err := tracing.InitTracer("donfra-api", cfg.JaegerEndpoint)
+	if err != nil {
+		log.Fatalf("failed to initialize tracer: %v", err)
+	}
+	defer func() {
+		if err := shutdown(context.Background()); err != nil {
+			log.Printf("failed to shutdown tracer: %v", err)
+		}
+	}()
+
+	conn, err := db.InitFromEnv()
+	if err != nil {
+		log.Fatalf("failed to initialize database: %v", err)
+	}
+
+	// Initialize room repository (Redis or Memory)
+	var roomRepo room.Repository
+	var redisClient *redis.Client
+	if cfg.UseRedis && cfg.RedisAddr != "" {
+		redisClient = redis.NewClient(&redis.Options{
+			Addr: cfg.RedisAddr,
+		})
+		// Test Redis connection
+		if err := redisClient.Ping(context.Background()).Err(); err != nil {
+			log.Fatalf("failed to connect to Redis at %s: %v", cfg.RedisAddr, err)
+		}
+		roomRepo = room.NewRedisRepository(redisClient)
+		log.Printf("[donfra-api] using Redis repository at %s", cfg.RedisAddr)
+	} else {
+		roomRepo = room.NewMemoryRepository()
+		log.Println("[donfra-api] using in-memory repository")
+	}
+
+	roomSvc := room.NewService(roomRepo, cfg.Passcode, cfg.BaseURL)
+	authSvc := auth.NewAuthService(cfg.AdminPass, cfg.JWTSecret)
+
+	// Initialize lesson asset storage (local filesystem or S3-compatible bucket)
+	var blobStore study.BlobStore
+	if cfg.AssetStore == "s3" {
+		// The timeout covers reading the body, so it leaves room to stream a MaxAssetSize asset
+		blobStore, err = study.NewS3BlobStore(study.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		}, &http.Client{Timeout: time.Minute})
+		if err != nil {
+			log.Fatalf("failed to initialize S3 asset store: %v", err)
+		}
+		log.Printf("[donfra-api] using S3 asset store bucket %s at %s", cfg.S3Bucket, cfg.S3Endpoint)
+	} else {
+		blobStore, err = study.NewLocalBlobStore(cfg.AssetDir)
+		if err != nil {
+			log.Fatalf("failed to initialize local asset store: %v", err)
+		}
+		log.Printf("[donfra-api] using local asset store at %s", cfg.AssetDir)
+	}
+	studySvc := study.NewService(conn, blobStore, cfg.JWTSecret)
+	switch cfg.LessonCache {
+	case "redis":
+		if redisClient == nil {
+			log.Fatalf("LESSON_CACHE=redis requires USE_REDIS=true and REDIS_ADDR")
+		}
+		studySvc.SetLessonCache(study.NewRedisLessonCache(redisClient, cfg.LessonCacheTTL))
+		log.Printf("[donfra-api] using Redis lesson cache (ttl %s)", cfg.LessonCacheTTL)
+	case "memory":
+		studySvc.SetLessonCache(study.NewMemoryLessonCache(cfg.LessonCacheTTL, 1000))
+		log.Printf("[donfra-api] using in-memory lesson cache (ttl %s)", cfg.LessonCacheTTL)
+	}
+
+	// Initialize user service with PostgreSQL repository
+	userRepo := user.NewPostgresRepository(conn)
+	userSvc := user.NewService(userRepo, cfg.JWTSecret, 168) // 168 hours = 7 days
+	if cfg.SMTPAddr != "" {
+		userSvc.SetMailer(mailer.NewSMTPMailer(cfg.SMTPAddr, cfg.MailFrom, cfg.SMTPUsername, cfg.SMTPPassword), cfg.PasswordResetURL)
+		log.Printf("[donfra-api] sending emails via SMTP %s", cfg.SMTPAddr)
+	} else {
+		userSvc.SetMailer(mailer.NewLogMailer(), cfg.PasswordResetURL)
+		log.Println("[donfra-api] SMTP_ADDR not set, emails will not be sent")
+	}
+	log.Println("[donfra-api] user service initialized")
+
+	// Initialize problem bank service with PostgreSQL repository
+	problemRepo := problem.NewRepository(conn)
+	problemSvc := problem.NewService(problemRepo)
+	log.Println("[donfra-api] problem service initialized")
+
+	// Initialize interview room service with PostgreSQL repository
+	interviewRepo := interview.NewRepository(conn)
+	interviewSvc := interview.NewService(interviewRepo, problemSvc, cfg.JWTSecret, cfg.BaseURL)
+	log.Println("[donfra-api] interview room service initialized")
+
+	// Start Redis Pub/Sub subscriber for headcount updates (if using Redis)
+	var subCancel context.CancelFunc
+	if redisClient != nil {
+		subCtx, cancel := context.WithCancel(context.Background())
+		subCancel = cancel
+		subscriber := room.NewHeadcountSubscriber(redisClient, roomRepo)
+		go func() {
+			if err := subscriber.Start(subCtx); err != nil && err != context.Canceled {
+				log.Printf("[pubsub] subscriber error: %v", err)
+			}
+		}()
+	}
+
+	// Start the lesson publish scheduler (applies publish_at/unpublish_at)
+	schedCtx, schedCancel := context.WithCancel(context.Background())
+	scheduler := study.NewPublishScheduler(studySvc, study.DefaultScheduleInterval)
+	go func() {
+		if err := scheduler.Start(schedCtx); err != nil && err != context.Canceled {
+			log.Printf("[scheduler] publish scheduler error: %v", err)
+		}
+	}()
+
+	r := router.New(cfg, roomSvc, studySvc, authSvc, userSvc, interviewSvc, problemSvc)
+
+	srv := &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           r,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	// Graceful shutdown
+	go func() {
+		log.Printf("[donfra-api] listening on %s", cfg.Addr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("server error: %v", err)
+		}
+	}()
+
+	// Wait for interrupt signal
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Println("[donfra-api] shutting down gracefully...")
+
+	// Cancel Redis Pub/Sub subscriber if running
+	if subCancel != nil {
+		subCancel()
+	}
+	schedCancel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Fatalf("server forced to shutdown: %v", err)
+	}
+
+	// Close Redis connection if open
+	if redisClient != nil {
+		if err := redisClient.Close(); err != nil {
+			log.Printf("[donfra-api] error closing Redis: %v", err)
+		}
+	}
+
+	log.Println("[donfra-api] server exited")
+}
@@ -1,6 +1,10 @@
 package config
 
-import "os"
+import (
+	"os"
+	"strings"
+	"time"
+)
 
 type Config struct {
 	Addr           string
@@ -13,6 +17,30 @@ type Config struct {
 	JaegerEndpoint string
 	RedisAddr      string
 	UseRedis       bool
+
+	// Lesson asset storage: "local" (AssetDir) or "s3" (S3-compatible bucket)
+	AssetStore  string
+	AssetDir    string
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+
+	// Lesson read cache: "" (disabled), "memory" or "redis" (requires USE_REDIS)
+	LessonCache    string
+	LessonCacheTTL time.Duration
+
+	// TrustedProxies lists reverse proxies (IPs or CIDRs) whose X-Forwarded-For is
+	// honored when identifying clients, e.g. for rate limits
+	TrustedProxies []string
+
+	// Password reset emails: suppressed unless SMTPAddr is set
+	PasswordResetURL string
+	SMTPAddr         string
+	SMTPUsername     string
+	SMTPPassword     string
+	MailFrom         string
 }
 
 func getenv(k, def string) string {
@@ -22,6 +50,23 @@ func getenv(k, def string) string {
 	return def
 }
 
+func getduration(k string, def time.Duration) time.Duration {
+	if d, err := time.ParseDuration(os.Getenv(k)); err == nil && d > 0 {
+		return d
+	}
+	return def
+}
+
+func getlist(k string) []string {
+	var list []string
+	for _, v := range strings.Split(os.Getenv(k), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
 func Load() Config {
 	return Config{
 		Addr:           getenv("ADDR", ":8080"),
@@ -34,5 +79,21 @@ func Load() Config {
 		JaegerEndpoint: getenv("JAEGER_ENDPOINT", ""), // e.g., "jaeger:4318" or "localhost:4318"
 		RedisAddr:      getenv("REDIS_ADDR", ""),      // e.g., "redis:6379" or "localhost:6379"
 		UseRedis:       getenv("USE_REDIS", "false") == "true",
+		AssetStore:     getenv("ASSET_STORE", "local"),
+		AssetDir:       getenv("ASSET_DIR", "./data/assets"),
+		S3Endpoint:     getenv("S3_ENDPOINT", ""), // e.g., "http://minio:9000"
+		S3Region:       getenv("S3_REGION", "us-east-1"),
+		S3Bucket:       getenv("S3_BUCKET", ""),
+		S3AccessKey:    getenv("S3_ACCESS_KEY", ""),
+		S3SecretKey:    getenv("S3_SECRET_KEY", ""),
+		LessonCache:    getenv("LESSON_CACHE", ""),
+		LessonCacheTTL: getduration("LESSON_CACHE_TTL", 5*time.Minute),
+		TrustedProxies: getlist("TRUSTED_PROXIES"), // e.g., "172.16.0.0/12" for the proxy's Docker network
+
+		PasswordResetURL: getenv("PASSWORD_RESET_URL", "http://localhost:3000/reset-password"),
+		SMTPAddr:         getenv("SMTP_ADDR", ""), // e.g., "smtp.example.com:587"
+		SMTPUsername:     getenv("SMTP_USERNAME", ""),
+		SMTPPassword:     getenv("SMTP_PASSWORD", ""),
+		MailFrom:         getenv("MAIL_FROM", "Donfra <no-reply@donfra.local>"),
 	}
 }
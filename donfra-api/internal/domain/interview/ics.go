@@ -0,0 +1,105 @@
+package interview
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// icsTimeFormat is the iCalendar UTC date-time format (RFC 5545 section 3.3.5)
+const icsTimeFormat = "20060102T150405Z"
+
+// BuildCalendar renders a scheduled interview room as an iCalendar (.ics) file
+// containing a single VEVENT that calendar clients can import.
+func BuildCalendar(room *InterviewRoom, now time.Time) ([]byte, error) {
+	if room == nil || room.ScheduledAt == nil {
+		return nil, ErrNotScheduled
+	}
+
+	start := room.ScheduledAt.UTC()
+	end := start.Add(time.Duration(room.DurationMinutes) * time.Minute)
+
+	summary := "Donfra interview"
+	if room.CandidateName != "" {
+		summary = fmt.Sprintf("Donfra interview with %s", room.CandidateName)
+	}
+	description := fmt.Sprintf("Join the interview room: %s", room.InviteLink)
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Donfra//Interview Scheduler//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"BEGIN:VEVENT",
+		"UID:" + room.RoomID + "@donfra",
+		"DTSTAMP:" + now.UTC().Format(icsTimeFormat),
+		"DTSTART:" + start.Format(icsTimeFormat),
+		"DTEND:" + end.Format(icsTimeFormat),
+		"SUMMARY:" + escapeICSText(summary),
+		"DESCRIPTION:" + escapeICSText(description),
+	}
+	if room.InviteLink != "" {
+		lines = append(lines, "URL:"+room.InviteLink)
+	}
+	if room.CandidateEmail != "" {
+		attendee := "ATTENDEE;ROLE=REQ-PARTICIPANT;RSVP=TRUE"
+		if room.CandidateName != "" {
+			attendee += ";CN=" + quoteICSParam(room.CandidateName)
+		}
+		lines = append(lines, attendee+":mailto:"+room.CandidateEmail)
+	}
+	lines = append(lines,
+		"STATUS:CONFIRMED",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	)
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(foldICSLine(line))
+		b.WriteString("\r\n")
+	}
+	return []byte(b.String()), nil
+}
+
+// escapeICSText escapes a TEXT property value (RFC 5545 section 3.3.11)
+func escapeICSText(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return r.Replace(s)
+}
+
+// quoteICSParam quotes a parameter value, dropping characters not allowed inside quotes
+func quoteICSParam(s string) string {
+	s = strings.NewReplacer(`"`, "", "\r", "", "\n", " ").Replace(s)
+	return `"` + s + `"`
+}
+
+// foldICSLine splits content lines longer than 75 octets (RFC 5545 section 3.1)
+// without breaking multi-byte UTF-8 sequences.
+func foldICSLine(line string) string {
+	const limit = 75
+	if len(line) <= limit {
+		return line
+	}
+
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1 // the leading space counts toward the next line
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
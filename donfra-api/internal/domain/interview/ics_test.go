@@ -0,0 +1,79 @@
+package interview_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"donfra-api/internal/domain/interview"
+)
+
+func TestBuildCalendar_ScheduledRoom(t *testing.T) {
+	start := time.Date(2030, 3, 14, 15, 30, 0, 0, time.UTC)
+	room := &interview.InterviewRoom{
+		RoomID:          "abc123",
+		InviteLink:      "http://localhost:3000/interview?token=xyz",
+		ScheduledAt:     &start,
+		DurationMinutes: 45,
+		CandidateName:   "Ada, Lovelace",
+		CandidateEmail:  "ada@example.com",
+	}
+
+	ics, err := interview.BuildCalendar(room, start.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	out := string(ics)
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:abc123@donfra\r\n",
+		"DTSTART:20300314T153000Z\r\n",
+		"DTEND:20300314T161500Z\r\n",
+		"SUMMARY:Donfra interview with Ada\\, Lovelace\r\n",
+		"mailto:ada@example.com\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("expected calendar to contain %q, got:\n%s", want, unfolded)
+		}
+	}
+
+	// Every physical line must respect the 75-octet limit
+	for _, line := range strings.Split(out, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line exceeds 75 octets: %q", line)
+		}
+	}
+}
+
+func TestBuildCalendar_EscapesLineBreaks(t *testing.T) {
+	start := time.Date(2030, 3, 14, 15, 30, 0, 0, time.UTC)
+	room := &interview.InterviewRoom{
+		RoomID:          "abc123",
+		ScheduledAt:     &start,
+		DurationMinutes: 45,
+		CandidateName:   "Ada\rSTATUS:CANCELLED\nX\r\nY",
+	}
+
+	ics, err := interview.BuildCalendar(room, start)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	unfolded := strings.ReplaceAll(string(ics), "\r\n ", "")
+	if !strings.Contains(unfolded, "SUMMARY:Donfra interview with Ada\\nSTATUS:CANCELLED\\nX\\nY\r\n") {
+		t.Errorf("expected line breaks to be escaped, got:\n%s", unfolded)
+	}
+	if strings.Contains(strings.ReplaceAll(unfolded, "\r\n", ""), "\r") {
+		t.Error("expected no bare carriage return in the calendar")
+	}
+}
+
+func TestBuildCalendar_NotScheduled(t *testing.T) {
+	_, err := interview.BuildCalendar(&interview.InterviewRoom{RoomID: "adhoc"}, time.Now())
+	if !errors.Is(err, interview.ErrNotScheduled) {
+		t.Errorf("expected ErrNotScheduled, got %v", err)
+	}
+}
@@ -0,0 +1,172 @@
+package interview
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrDisplayNameRequired = errors.New("display_name is required to enter the lobby")
+	ErrAdmissionNotFound   = errors.New("lobby entry not found")
+	ErrAdmissionDecided    = errors.New("lobby entry has already been decided")
+	ErrAdmissionRejected   = errors.New("admission rejected by the room owner")
+	ErrLobbyFull           = errors.New("too many people are waiting in the lobby")
+)
+
+const (
+	maxDisplayNameLength = 80
+	// maxPendingAdmissions bounds how many joiners may wait in one room's lobby
+	maxPendingAdmissions = 20
+)
+
+// enterLobby adds a joiner to the room's pending list and issues a ticket
+// the joiner uses to poll for the owner's decision
+func (s *service) enterLobby(ctx context.Context, room *InterviewRoom, displayName string) (*JoinRoomResponse, error) {
+	displayName = strings.TrimSpace(displayName)
+	if displayName == "" {
+		return nil, ErrDisplayNameRequired
+	}
+	if utf8.RuneCountInString(displayName) > maxDisplayNameLength {
+		displayName = string([]rune(displayName)[:maxDisplayNameLength])
+	}
+
+	ticket, err := generateTicket()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate lobby ticket: %w", err)
+	}
+
+	admission := &Admission{
+		RoomID:      room.RoomID,
+		DisplayName: displayName,
+		Status:      AdmissionPending,
+		TicketHash:  hashTicket(ticket),
+	}
+	if err := s.repo.CreateAdmission(ctx, admission, maxPendingAdmissions); err != nil {
+		if errors.Is(err, ErrLobbyFull) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to enter lobby: %w", err)
+	}
+
+	// The room id is withheld until the owner admits the joiner
+	return &JoinRoomResponse{
+		Status:      AdmissionPending,
+		AdmissionID: admission.ID,
+		Message:     "Waiting for the interviewer to admit you",
+		Ticket:      ticket,
+	}, nil
+}
+
+// GetAdmissionStatus returns the lobby decision for the holder of a ticket
+func (s *service) GetAdmissionStatus(ctx context.Context, ticket string) (*JoinRoomResponse, error) {
+	if ticket == "" {
+		return nil, ErrAdmissionNotFound
+	}
+	admission, err := s.repo.GetAdmissionByTicketHash(ctx, hashTicket(ticket))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAdmissionNotFound
+		}
+		return nil, fmt.Errorf("failed to get lobby entry: %w", err)
+	}
+
+	// The room may have been closed while the joiner was waiting
+	room, err := s.GetRoomByID(ctx, admission.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	if !room.CurrentState().IsActive() {
+		return nil, ErrRoomNotActive
+	}
+
+	resp := &JoinRoomResponse{
+		Status:      admission.Status,
+		AdmissionID: admission.ID,
+	}
+	switch admission.Status {
+	case AdmissionAdmitted:
+		resp.RoomID = admission.RoomID
+		resp.Message = "Successfully joined interview room"
+		if resp.AccessToken, err = s.generateAccessToken(admission.RoomID, admission.ID); err != nil {
+			return nil, fmt.Errorf("failed to generate access token: %w", err)
+		}
+	case AdmissionRejected:
+		return nil, ErrAdmissionRejected
+	default:
+		resp.Message = "Waiting for the interviewer to admit you"
+	}
+	return resp, nil
+}
+
+// ListLobby returns the pending joiners of a room (only owner can view)
+func (s *service) ListLobby(ctx context.Context, roomID string, userID uint) ([]Admission, error) {
+	room, err := s.GetRoomByID(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.OwnerID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	admissions, err := s.repo.ListAdmissions(ctx, roomID, AdmissionPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list lobby: %w", err)
+	}
+	return admissions, nil
+}
+
+// DecideAdmission admits or rejects a pending joiner (only owner can decide)
+func (s *service) DecideAdmission(ctx context.Context, roomID string, admissionID uint, userID uint, admit bool) (*Admission, error) {
+	room, err := s.GetRoomByID(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.OwnerID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	admission, err := s.repo.GetAdmission(ctx, roomID, admissionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAdmissionNotFound
+		}
+		return nil, fmt.Errorf("failed to get lobby entry: %w", err)
+	}
+	if admission.Status != AdmissionPending {
+		return nil, ErrAdmissionDecided
+	}
+
+	decidedAt := s.now()
+	admission.DecidedAt = &decidedAt
+	admission.Status = AdmissionRejected
+	if admit {
+		admission.Status = AdmissionAdmitted
+	}
+	if err := s.repo.UpdateAdmission(ctx, admission); err != nil {
+		return nil, fmt.Errorf("failed to save lobby decision: %w", err)
+	}
+	return admission, nil
+}
+
+// generateTicket creates a random lobby ticket
+func generateTicket() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashTicket returns the SHA-256 hex digest stored in place of the ticket
+func hashTicket(ticket string) string {
+	sum := sha256.Sum256([]byte(ticket))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,171 @@
+package interview_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"donfra-api/internal/domain/interview"
+)
+
+func TestInterviewService_Lobby_AdmitFlow(t *testing.T) {
+	svc := interview.NewService(newFakeRepository(), nil, "secret", "http://localhost:3000")
+	ctx := context.Background()
+
+	created, err := svc.InitRoom(ctx, 7, true, &interview.InitRoomRequest{LobbyEnabled: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	token := inviteToken(t, created.InviteLink)
+
+	if _, err := svc.JoinRoom(ctx, &interview.JoinRoomRequest{InviteToken: token}); !errors.Is(err, interview.ErrDisplayNameRequired) {
+		t.Fatalf("expected ErrDisplayNameRequired, got %v", err)
+	}
+
+	joined, err := svc.JoinRoom(ctx, &interview.JoinRoomRequest{InviteToken: token, DisplayName: "  Ada  "})
+	if err != nil {
+		t.Fatalf("expected join to succeed, got %v", err)
+	}
+	if joined.Status != interview.AdmissionPending || joined.Ticket == "" {
+		t.Fatalf("expected pending status with a ticket, got %+v", joined)
+	}
+	if joined.RoomID != "" || joined.AccessToken != "" {
+		t.Errorf("expected no room id or access token while pending, got %+v", joined)
+	}
+
+	status, err := svc.GetAdmissionStatus(ctx, joined.Ticket)
+	if err != nil || status.Status != interview.AdmissionPending {
+		t.Fatalf("expected pending status, got %+v (err %v)", status, err)
+	}
+
+	if _, err := svc.ListLobby(ctx, created.RoomID, 8); !errors.Is(err, interview.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for non-owner, got %v", err)
+	}
+	lobby, err := svc.ListLobby(ctx, created.RoomID, 7)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(lobby) != 1 || lobby[0].DisplayName != "Ada" {
+		t.Fatalf("expected one pending entry for Ada, got %+v", lobby)
+	}
+
+	if _, err := svc.DecideAdmission(ctx, created.RoomID, joined.AdmissionID, 7, true); err != nil {
+		t.Fatalf("expected admit to succeed, got %v", err)
+	}
+	if _, err := svc.DecideAdmission(ctx, created.RoomID, joined.AdmissionID, 7, false); !errors.Is(err, interview.ErrAdmissionDecided) {
+		t.Errorf("expected ErrAdmissionDecided, got %v", err)
+	}
+
+	status, err = svc.GetAdmissionStatus(ctx, joined.Ticket)
+	if err != nil || status.Status != interview.AdmissionAdmitted {
+		t.Fatalf("expected admitted status, got %+v (err %v)", status, err)
+	}
+	if status.RoomID != created.RoomID {
+		t.Errorf("expected room id once admitted, got %q", status.RoomID)
+	}
+	if err := svc.ValidateRoomAccess(ctx, created.RoomID, status.AccessToken); err != nil {
+		t.Errorf("expected the access token to be valid, got %v", err)
+	}
+}
+
+func TestInterviewService_Lobby_Reject(t *testing.T) {
+	svc := interview.NewService(newFakeRepository(), nil, "secret", "http://localhost:3000")
+	ctx := context.Background()
+
+	created, err := svc.InitRoom(ctx, 7, true, &interview.InitRoomRequest{LobbyEnabled: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	joined, err := svc.JoinRoom(ctx, &interview.JoinRoomRequest{InviteToken: inviteToken(t, created.InviteLink), DisplayName: "Mallory"})
+	if err != nil {
+		t.Fatalf("expected join to succeed, got %v", err)
+	}
+
+	if _, err := svc.DecideAdmission(ctx, created.RoomID, joined.AdmissionID, 7, false); err != nil {
+		t.Fatalf("expected reject to succeed, got %v", err)
+	}
+	if _, err := svc.GetAdmissionStatus(ctx, joined.Ticket); !errors.Is(err, interview.ErrAdmissionRejected) {
+		t.Errorf("expected ErrAdmissionRejected, got %v", err)
+	}
+	if _, err := svc.GetAdmissionStatus(ctx, "not-a-ticket"); !errors.Is(err, interview.ErrAdmissionNotFound) {
+		t.Errorf("expected ErrAdmissionNotFound, got %v", err)
+	}
+}
+
+func TestInterviewService_Lobby_Full(t *testing.T) {
+	svc := interview.NewService(newFakeRepository(), nil, "secret", "http://localhost:3000")
+	ctx := context.Background()
+
+	created, err := svc.InitRoom(ctx, 7, true, &interview.InitRoomRequest{LobbyEnabled: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	req := &interview.JoinRoomRequest{InviteToken: inviteToken(t, created.InviteLink), DisplayName: "Guest"}
+
+	var first *interview.JoinRoomResponse
+	for i := 0; i < 20; i++ {
+		joined, err := svc.JoinRoom(ctx, req)
+		if err != nil {
+			t.Fatalf("expected join %d to succeed, got %v", i+1, err)
+		}
+		if first == nil {
+			first = joined
+		}
+	}
+	if _, err := svc.JoinRoom(ctx, req); !errors.Is(err, interview.ErrLobbyFull) {
+		t.Fatalf("expected ErrLobbyFull, got %v", err)
+	}
+
+	// Deciding a pending entry frees its place
+	if _, err := svc.DecideAdmission(ctx, created.RoomID, first.AdmissionID, 7, false); err != nil {
+		t.Fatalf("expected reject to succeed, got %v", err)
+	}
+	if _, err := svc.JoinRoom(ctx, req); err != nil {
+		t.Errorf("expected join to succeed after a decision, got %v", err)
+	}
+}
+
+func TestInterviewService_JoinRoom_WithoutLobbyAdmitsDirectly(t *testing.T) {
+	svc := interview.NewService(newFakeRepository(), nil, "secret", "http://localhost:3000")
+	ctx := context.Background()
+
+	created, err := svc.InitRoom(ctx, 7, true, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	joined, err := svc.JoinRoom(ctx, &interview.JoinRoomRequest{InviteToken: inviteToken(t, created.InviteLink)})
+	if err != nil {
+		t.Fatalf("expected join to succeed, got %v", err)
+	}
+	if joined.Status != interview.AdmissionAdmitted || joined.Ticket != "" {
+		t.Errorf("expected direct admission without ticket, got %+v", joined)
+	}
+}
+
+func TestInterviewService_ValidateRoomAccess_ChecksAdmission(t *testing.T) {
+	repo := newFakeRepository()
+	svc := interview.NewService(repo, nil, "secret", "http://localhost:3000")
+	ctx := context.Background()
+
+	created, err := svc.InitRoom(ctx, 7, true, &interview.InitRoomRequest{LobbyEnabled: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	joined, err := svc.JoinRoom(ctx, &interview.JoinRoomRequest{InviteToken: inviteToken(t, created.InviteLink), DisplayName: "Ada"})
+	if err != nil {
+		t.Fatalf("expected join to succeed, got %v", err)
+	}
+	if _, err := svc.DecideAdmission(ctx, created.RoomID, joined.AdmissionID, 7, true); err != nil {
+		t.Fatalf("expected admit to succeed, got %v", err)
+	}
+	status, err := svc.GetAdmissionStatus(ctx, joined.Ticket)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// An access token stops working once its admission is no longer admitted
+	repo.admissions[0].Status = interview.AdmissionRejected
+	if err := svc.ValidateRoomAccess(ctx, created.RoomID, status.AccessToken); !errors.Is(err, interview.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
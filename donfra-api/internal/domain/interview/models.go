@@ -8,15 +8,26 @@ import (
 
 // InterviewRoom represents a collaborative interview room with user ownership
 type InterviewRoom struct {
-	ID           uint           `gorm:"primaryKey" json:"id"`
-	RoomID       string         `gorm:"uniqueIndex;not null" json:"room_id"`
-	OwnerID      uint           `gorm:"not null;index" json:"owner_id"`
-	Headcount    int            `gorm:"default:0" json:"headcount"`
-	CodeSnapshot string         `gorm:"type:text;default:''" json:"code_snapshot"`
-	InviteLink   string         `gorm:"size:500" json:"invite_link"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	ID              uint           `gorm:"primaryKey" json:"id"`
+	RoomID          string         `gorm:"uniqueIndex;not null" json:"room_id"`
+	OwnerID         uint           `gorm:"not null;index" json:"owner_id"`
+	Headcount       int            `gorm:"default:0" json:"headcount"`
+	CodeSnapshot    string         `gorm:"type:text;default:''" json:"code_snapshot"`
+	InviteLink      string         `gorm:"size:500" json:"invite_link"`
+	ProblemID       *uint          `gorm:"index" json:"problem_id,omitempty"`
+	ScheduledAt     *time.Time     `gorm:"index" json:"scheduled_at,omitempty"` // nil for rooms created on the spot
+	DurationMinutes int            `gorm:"default:0" json:"duration_minutes,omitempty"`
+	CandidateName   string         `gorm:"size:255" json:"candidate_name,omitempty"`
+	CandidateEmail  string         `gorm:"size:255" json:"candidate_email,omitempty"`
+	State           string         `gorm:"size:20;not null;default:'waiting';index" json:"state"`
+	OpenedAt        *time.Time     `json:"opened_at,omitempty"`
+	StartedAt       *time.Time     `json:"started_at,omitempty"`
+	EndedAt         *time.Time     `json:"ended_at,omitempty"`
+	ArchivedAt      *time.Time     `json:"archived_at,omitempty"`
+	LobbyEnabled    bool           `gorm:"not null;default:false" json:"lobby_enabled"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
 
 // TableName specifies the table name for GORM
@@ -27,30 +38,103 @@ func (InterviewRoom) TableName() string {
 // InitRoomRequest is the request payload for POST /api/interview/init
 // No fields required - only admin users can create rooms via JWT authentication
 type InitRoomRequest struct {
-	// Empty - authentication is via JWT cookie
+	// ProblemID optionally attaches a question bank problem to the room
+	ProblemID *uint `json:"problem_id,omitempty"`
+	// LobbyEnabled makes joiners wait in a lobby until the owner admits them
+	LobbyEnabled bool `json:"lobby_enabled"`
 }
 
 // InitRoomResponse is the response for POST /api/interview/init
 type InitRoomResponse struct {
 	RoomID     string `json:"room_id"`
 	InviteLink string `json:"invite_link"`
+	ProblemID  *uint  `json:"problem_id,omitempty"`
 	Message    string `json:"message"`
 }
 
+// ScheduleRoomRequest is the request payload for POST /api/interview/schedule
+type ScheduleRoomRequest struct {
+	ScheduledAt     time.Time `json:"scheduled_at"`
+	DurationMinutes int       `json:"duration_minutes"`
+	CandidateName   string    `json:"candidate_name"`
+	CandidateEmail  string    `json:"candidate_email"`
+	ProblemID       *uint     `json:"problem_id,omitempty"`
+	LobbyEnabled    bool      `json:"lobby_enabled"`
+}
+
+// ScheduleRoomResponse is the response for POST /api/interview/schedule
+type ScheduleRoomResponse struct {
+	RoomID          string    `json:"room_id"`
+	InviteLink      string    `json:"invite_link"`
+	ScheduledAt     time.Time `json:"scheduled_at"`
+	DurationMinutes int       `json:"duration_minutes"`
+	ValidFrom       time.Time `json:"valid_from"`
+	ValidUntil      time.Time `json:"valid_until"`
+	CalendarURL     string    `json:"calendar_url"`
+	Message         string    `json:"message"`
+}
+
+// RoomStateResponse is the response for POST /api/interview/{room_id}/start and /end
+type RoomStateResponse struct {
+	RoomID    string     `json:"room_id"`
+	State     RoomState  `json:"state"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+	NoShow    bool       `json:"no_show"`
+	Message   string     `json:"message"`
+}
+
 // JoinRoomRequest is the request payload for POST /api/interview/join
 type JoinRoomRequest struct {
 	InviteToken string `json:"invite_token"`
+	// DisplayName identifies the joiner in the lobby (required when the room has a lobby)
+	DisplayName string `json:"display_name,omitempty"`
 }
 
 // JoinRoomResponse is the response for POST /api/interview/join
+// and GET /api/interview/join/status
 type JoinRoomResponse struct {
-	RoomID  string `json:"room_id"`
-	Message string `json:"message"`
+	RoomID      string          `json:"room_id,omitempty"` // Set once admitted
+	Status      AdmissionStatus `json:"status"`
+	AdmissionID uint            `json:"admission_id,omitempty"`
+	Message     string          `json:"message"`
+	// Ticket lets a pending joiner poll for admission; delivered via cookie only
+	Ticket string `json:"-"`
+	// AccessToken grants an admitted joiner access to the room; delivered via cookie only
+	AccessToken string `json:"-"`
+}
+
+// AdmissionStatus is the lobby decision for a joiner
+type AdmissionStatus string
+
+const (
+	AdmissionPending  AdmissionStatus = "pending"
+	AdmissionAdmitted AdmissionStatus = "admitted"
+	AdmissionRejected AdmissionStatus = "rejected"
+)
+
+// Admission is a lobby entry created when someone joins a room with the lobby enabled
+type Admission struct {
+	ID          uint            `gorm:"primaryKey" json:"id"`
+	RoomID      string          `gorm:"not null;index" json:"room_id"`
+	DisplayName string          `gorm:"size:80;not null" json:"display_name"`
+	Status      AdmissionStatus `gorm:"size:20;not null;default:'pending'" json:"status"`
+	TicketHash  string          `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	DecidedAt   *time.Time      `json:"decided_at,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Admission) TableName() string {
+	return "interview_admissions"
 }
 
 // CloseRoomRequest is the request payload for POST /api/interview/close
 type CloseRoomRequest struct {
 	RoomID string `json:"room_id"`
+	// CodeSnapshot optionally records the final code before the room is closed
+	CodeSnapshot *string `json:"code_snapshot,omitempty"`
 }
 
 // CloseRoomResponse is the response for POST /api/interview/close
@@ -58,3 +142,58 @@ type CloseRoomResponse struct {
 	RoomID  string `json:"room_id"`
 	Message string `json:"message"`
 }
+
+// InterviewRun records a single code execution inside an interview room
+type InterviewRun struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	RoomID     string    `gorm:"not null;index" json:"room_id"`
+	Language   string    `gorm:"size:20;not null;default:'python'" json:"language"`
+	Code       string    `gorm:"type:text;not null" json:"code"`
+	Stdout     string    `gorm:"type:text" json:"stdout"`
+	Stderr     string    `gorm:"type:text" json:"stderr"`
+	TimedOut   bool      `gorm:"not null;default:false" json:"timed_out"`
+	DurationMs int64     `gorm:"not null;default:0" json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (InterviewRun) TableName() string {
+	return "interview_runs"
+}
+
+// Recommendation values accepted on a scorecard
+const (
+	RecommendationStrongHire   = "strong_hire"
+	RecommendationHire         = "hire"
+	RecommendationNoHire       = "no_hire"
+	RecommendationStrongNoHire = "strong_no_hire"
+)
+
+// Scorecard holds an interviewer's evaluation of a candidate (one per author per room)
+type Scorecard struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	RoomID         string    `gorm:"not null;uniqueIndex:idx_scorecards_room_author" json:"room_id"`
+	AuthorID       uint      `gorm:"not null;uniqueIndex:idx_scorecards_room_author" json:"author_id"`
+	Rating         int       `gorm:"not null" json:"rating"` // 1 (poor) to 5 (excellent)
+	Recommendation string    `gorm:"size:20;not null" json:"recommendation"`
+	Notes          string    `gorm:"type:text" json:"notes"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Scorecard) TableName() string {
+	return "interview_scorecards"
+}
+
+// RunCodeRequest is the request payload for POST /api/interview/{room_id}/run
+type RunCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// SubmitScorecardRequest is the request payload for POST /api/interview/{room_id}/scorecard
+type SubmitScorecardRequest struct {
+	Rating         int    `json:"rating"`
+	Recommendation string `json:"recommendation"`
+	Notes          string `json:"notes"`
+}
@@ -0,0 +1,342 @@
+package interview
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	"donfra-api/internal/domain/problem"
+)
+
+// Report is the post-interview summary of a room, assembled from the room
+// and its related runs and scorecards. It remains available after the room is closed.
+type Report struct {
+	RoomID          string                 `json:"room_id"`
+	OwnerID         uint                   `json:"owner_id"`
+	CandidateName   string                 `json:"candidate_name,omitempty"`
+	CandidateEmail  string                 `json:"candidate_email,omitempty"`
+	ScheduledAt     *time.Time             `json:"scheduled_at,omitempty"`
+	DurationMinutes int                    `json:"duration_minutes,omitempty"`
+	CreatedAt       time.Time              `json:"created_at"`
+	State           RoomState              `json:"state"`
+	StartedAt       *time.Time             `json:"started_at,omitempty"`
+	EndedAt         *time.Time             `json:"ended_at,omitempty"`
+	ActualMinutes   int                    `json:"actual_minutes"`
+	NoShow          bool                   `json:"no_show"`
+	ClosedAt        *time.Time             `json:"closed_at,omitempty"`
+	Problem         *problem.ProblemPublic `json:"problem,omitempty"`
+	FinalCode       string                 `json:"final_code"`
+	Runs            []InterviewRun         `json:"runs"`
+	Timeline        []TimelineEvent        `json:"timeline"`
+	Summary         ReportSummary          `json:"summary"`
+	Scorecards      []Scorecard            `json:"scorecards"`
+	GeneratedAt     time.Time              `json:"generated_at"`
+}
+
+// TimelineEvent is a single entry of the report timeline
+type TimelineEvent struct {
+	At     time.Time `json:"at"`
+	Event  string    `json:"event"`
+	Detail string    `json:"detail,omitempty"`
+}
+
+// ReportSummary aggregates the run history of a room
+type ReportSummary struct {
+	TotalRuns    int        `json:"total_runs"`
+	FailedRuns   int        `json:"failed_runs"`
+	TimedOutRuns int        `json:"timed_out_runs"`
+	FirstRunAt   *time.Time `json:"first_run_at,omitempty"`
+	LastRunAt    *time.Time `json:"last_run_at,omitempty"`
+}
+
+// GetReport assembles the report of a room (only owner can view, including after close)
+func (s *service) GetReport(ctx context.Context, roomID string, userID uint) (*Report, error) {
+	room, err := s.getRoomIncludingClosed(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.OwnerID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	report := &Report{
+		RoomID:          room.RoomID,
+		OwnerID:         room.OwnerID,
+		CandidateName:   room.CandidateName,
+		CandidateEmail:  room.CandidateEmail,
+		ScheduledAt:     room.ScheduledAt,
+		DurationMinutes: room.DurationMinutes,
+		CreatedAt:       room.CreatedAt,
+		State:           room.CurrentState(),
+		StartedAt:       room.StartedAt,
+		EndedAt:         room.EndedAt,
+		ActualMinutes:   int(room.ActualDuration().Round(time.Minute) / time.Minute),
+		NoShow:          room.IsNoShow(),
+		FinalCode:       room.CodeSnapshot,
+		GeneratedAt:     s.now(),
+	}
+	if room.DeletedAt.Valid {
+		closedAt := room.DeletedAt.Time
+		report.ClosedAt = &closedAt
+	}
+
+	// A problem deleted from the bank after the interview is simply omitted
+	if room.ProblemID != nil {
+		p, err := s.getProblem(ctx, *room.ProblemID)
+		if err != nil && !errors.Is(err, ErrProblemNotFound) {
+			return nil, err
+		}
+		if p != nil {
+			report.Problem = p.ToPublic()
+		}
+	}
+
+	if report.Runs, err = s.repo.ListRuns(ctx, roomID); err != nil {
+		return nil, fmt.Errorf("failed to list runs: %w", err)
+	}
+	if report.Scorecards, err = s.repo.ListScorecards(ctx, roomID); err != nil {
+		return nil, fmt.Errorf("failed to list scorecards: %w", err)
+	}
+
+	report.Summary = summarizeRuns(report.Runs)
+	report.Timeline = buildTimeline(room, report.Runs, report.Scorecards)
+	return report, nil
+}
+
+// summarizeRuns counts failed and timed-out runs and records the first/last run times
+func summarizeRuns(runs []InterviewRun) ReportSummary {
+	summary := ReportSummary{TotalRuns: len(runs)}
+	for i := range runs {
+		r := &runs[i]
+		if r.TimedOut {
+			summary.TimedOutRuns++
+		} else if r.Stderr != "" {
+			summary.FailedRuns++
+		}
+		if summary.FirstRunAt == nil || r.CreatedAt.Before(*summary.FirstRunAt) {
+			summary.FirstRunAt = &r.CreatedAt
+		}
+		if summary.LastRunAt == nil || r.CreatedAt.After(*summary.LastRunAt) {
+			summary.LastRunAt = &r.CreatedAt
+		}
+	}
+	return summary
+}
+
+// buildTimeline derives a chronological list of events from the room and its related data
+func buildTimeline(room *InterviewRoom, runs []InterviewRun, scorecards []Scorecard) []TimelineEvent {
+	events := []TimelineEvent{{At: room.CreatedAt, Event: "room_created"}}
+	if room.ScheduledAt != nil {
+		events = append(events, TimelineEvent{
+			At:     *room.ScheduledAt,
+			Event:  "scheduled_start",
+			Detail: fmt.Sprintf("%d minute slot", room.DurationMinutes),
+		})
+	}
+	if room.OpenedAt != nil && room.ScheduledAt != nil {
+		events = append(events, TimelineEvent{At: *room.OpenedAt, Event: "room_opened"})
+	}
+	if room.StartedAt != nil {
+		events = append(events, TimelineEvent{At: *room.StartedAt, Event: "interview_started"})
+	}
+	if room.EndedAt != nil {
+		event := TimelineEvent{At: *room.EndedAt, Event: "interview_ended"}
+		if room.IsNoShow() {
+			event.Detail = "no-show"
+		}
+		events = append(events, event)
+	}
+	for i, r := range runs {
+		events = append(events, TimelineEvent{
+			At:     r.CreatedAt,
+			Event:  "code_run",
+			Detail: fmt.Sprintf("run #%d: %s", i+1, runOutcome(r)),
+		})
+	}
+	for _, sc := range scorecards {
+		events = append(events, TimelineEvent{
+			At:     sc.UpdatedAt,
+			Event:  "scorecard_submitted",
+			Detail: fmt.Sprintf("rating %d, %s", sc.Rating, sc.Recommendation),
+		})
+	}
+	if room.DeletedAt.Valid {
+		events = append(events, TimelineEvent{At: room.DeletedAt.Time, Event: "room_closed"})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].At.Before(events[j].At)
+	})
+	return events
+}
+
+// runOutcome describes the result of a run in a few words
+func runOutcome(r InterviewRun) string {
+	switch {
+	case r.TimedOut:
+		return "timed out"
+	case r.Stderr != "":
+		return "error"
+	default:
+		return "ok"
+	}
+}
+
+// RenderMarkdown renders the report as a Markdown document
+func (r *Report) RenderMarkdown() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Interview Report: %s\n\n", r.RoomID)
+	if r.CandidateName != "" {
+		fmt.Fprintf(&b, "- **Candidate:** %s", r.CandidateName)
+		if r.CandidateEmail != "" {
+			fmt.Fprintf(&b, " <%s>", r.CandidateEmail)
+		}
+		b.WriteString("\n")
+	}
+	if r.ScheduledAt != nil {
+		fmt.Fprintf(&b, "- **Scheduled:** %s (%d min)\n", formatReportTime(*r.ScheduledAt), r.DurationMinutes)
+	}
+	fmt.Fprintf(&b, "- **Created:** %s\n", formatReportTime(r.CreatedAt))
+	fmt.Fprintf(&b, "- **State:** %s\n", r.State)
+	if r.StartedAt != nil {
+		fmt.Fprintf(&b, "- **Started:** %s\n", formatReportTime(*r.StartedAt))
+	}
+	if r.EndedAt != nil {
+		fmt.Fprintf(&b, "- **Ended:** %s (%d min)\n", formatReportTime(*r.EndedAt), r.ActualMinutes)
+	}
+	if r.NoShow {
+		b.WriteString("- **No-show:** candidate never joined the interview\n")
+	}
+	if r.ClosedAt != nil {
+		fmt.Fprintf(&b, "- **Closed:** %s\n", formatReportTime(*r.ClosedAt))
+	}
+	fmt.Fprintf(&b, "- **Generated:** %s\n\n", formatReportTime(r.GeneratedAt))
+
+	b.WriteString("## Problem\n\n")
+	if r.Problem != nil {
+		fmt.Fprintf(&b, "### %s (%s)\n\n%s\n\n", r.Problem.Title, r.Problem.Difficulty, strings.TrimSpace(r.Problem.Statement))
+	} else {
+		b.WriteString("_No problem attached._\n\n")
+	}
+
+	b.WriteString("## Final Code\n\n")
+	writeCodeBlock(&b, "python", r.FinalCode)
+
+	b.WriteString("## Run History\n\n")
+	fmt.Fprintf(&b, "%d runs, %d failed, %d timed out.\n\n", r.Summary.TotalRuns, r.Summary.FailedRuns, r.Summary.TimedOutRuns)
+	for i, run := range r.Runs {
+		fmt.Fprintf(&b, "### Run #%d — %s (%s, %d ms)\n\n", i+1, formatReportTime(run.CreatedAt), runOutcome(run), run.DurationMs)
+		writeCodeBlock(&b, run.Language, run.Code)
+		if run.Stdout != "" {
+			b.WriteString("stdout:\n\n")
+			writeCodeBlock(&b, "", run.Stdout)
+		}
+		if run.Stderr != "" {
+			b.WriteString("stderr:\n\n")
+			writeCodeBlock(&b, "", run.Stderr)
+		}
+	}
+
+	b.WriteString("## Timeline\n\n")
+	for _, e := range r.Timeline {
+		fmt.Fprintf(&b, "- %s — %s", formatReportTime(e.At), e.Event)
+		if e.Detail != "" {
+			fmt.Fprintf(&b, " (%s)", e.Detail)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	b.WriteString("## Scorecards\n\n")
+	if len(r.Scorecards) == 0 {
+		b.WriteString("_No scorecards submitted._\n")
+	}
+	for _, sc := range r.Scorecards {
+		fmt.Fprintf(&b, "### Interviewer #%d\n\n- **Rating:** %d/5\n- **Recommendation:** %s\n\n", sc.AuthorID, sc.Rating, sc.Recommendation)
+		if sc.Notes != "" {
+			fmt.Fprintf(&b, "%s\n\n", sc.Notes)
+		}
+	}
+
+	return b.String()
+}
+
+// writeCodeBlock writes a fenced code block, lengthening the fence if the code contains backticks
+func writeCodeBlock(b *strings.Builder, lang, code string) {
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	fmt.Fprintf(b, "%s%s\n%s\n%s\n\n", fence, lang, strings.TrimRight(code, "\n"), fence)
+}
+
+func formatReportTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04 UTC")
+}
+
+var reportHTMLTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"fmtTime": formatReportTime,
+	"outcome": runOutcome,
+	"inc":     func(i int) int { return i + 1 },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Interview Report: {{.RoomID}}</title>
+<style>
+body{font-family:system-ui,sans-serif;max-width:860px;margin:2rem auto;padding:0 1rem;color:#222}
+pre{background:#f5f5f5;padding:.75rem;overflow-x:auto}
+</style>
+</head>
+<body>
+<h1>Interview Report: {{.RoomID}}</h1>
+<ul>
+{{if .CandidateName}}<li><strong>Candidate:</strong> {{.CandidateName}}{{if .CandidateEmail}} &lt;{{.CandidateEmail}}&gt;{{end}}</li>{{end}}
+{{if .ScheduledAt}}<li><strong>Scheduled:</strong> {{fmtTime .ScheduledAt}} ({{.DurationMinutes}} min)</li>{{end}}
+<li><strong>Created:</strong> {{fmtTime .CreatedAt}}</li>
+<li><strong>State:</strong> {{.State}}</li>
+{{if .StartedAt}}<li><strong>Started:</strong> {{fmtTime .StartedAt}}</li>{{end}}
+{{if .EndedAt}}<li><strong>Ended:</strong> {{fmtTime .EndedAt}} ({{.ActualMinutes}} min)</li>{{end}}
+{{if .NoShow}}<li><strong>No-show:</strong> candidate never joined the interview</li>{{end}}
+{{if .ClosedAt}}<li><strong>Closed:</strong> {{fmtTime .ClosedAt}}</li>{{end}}
+<li><strong>Generated:</strong> {{fmtTime .GeneratedAt}}</li>
+</ul>
+<h2>Problem</h2>
+{{with .Problem}}<h3>{{.Title}} ({{.Difficulty}})</h3>
+<pre>{{.Statement}}</pre>{{else}}<p><em>No problem attached.</em></p>{{end}}
+<h2>Final Code</h2>
+<pre><code>{{.FinalCode}}</code></pre>
+<h2>Run History</h2>
+<p>{{.Summary.TotalRuns}} runs, {{.Summary.FailedRuns}} failed, {{.Summary.TimedOutRuns}} timed out.</p>
+{{range $i, $run := .Runs}}<h3>Run #{{inc $i}} — {{fmtTime $run.CreatedAt}} ({{outcome $run}}, {{$run.DurationMs}} ms)</h3>
+<pre><code>{{$run.Code}}</code></pre>
+{{if $run.Stdout}}<p>stdout:</p><pre>{{$run.Stdout}}</pre>{{end}}
+{{if $run.Stderr}}<p>stderr:</p><pre>{{$run.Stderr}}</pre>{{end}}
+{{end}}
+<h2>Timeline</h2>
+<ul>
+{{range .Timeline}}<li>{{fmtTime .At}} — {{.Event}}{{if .Detail}} ({{.Detail}}){{end}}</li>
+{{end}}</ul>
+<h2>Scorecards</h2>
+{{range .Scorecards}}<h3>Interviewer #{{.AuthorID}}</h3>
+<ul><li><strong>Rating:</strong> {{.Rating}}/5</li><li><strong>Recommendation:</strong> {{.Recommendation}}</li></ul>
+{{if .Notes}}<pre>{{.Notes}}</pre>{{end}}
+{{else}}<p><em>No scorecards submitted.</em></p>{{end}}
+</body>
+</html>
+`))
+
+// RenderHTML renders the report as a standalone, escaped HTML page
+func (r *Report) RenderHTML() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := reportHTMLTemplate.Execute(&buf, r); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
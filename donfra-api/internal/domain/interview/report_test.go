@@ -0,0 +1,93 @@
+package interview_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"donfra-api/internal/domain/interview"
+)
+
+func TestInterviewService_GetReport_AfterClose(t *testing.T) {
+	repo := newFakeRepository()
+	svc := interview.NewService(repo, nil, "secret", "http://localhost:3000")
+	ctx := context.Background()
+
+	created, err := svc.InitRoom(ctx, 7, true, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Seed run history directly so the test does not depend on python3
+	_ = repo.CreateRun(ctx, &interview.InterviewRun{RoomID: created.RoomID, Language: "python", Code: "print(1)", Stdout: "1\n"})
+	_ = repo.CreateRun(ctx, &interview.InterviewRun{RoomID: created.RoomID, Language: "python", Code: "raise", Stderr: "Traceback"})
+
+	finalCode := "print('done')"
+	if err := svc.CloseRoom(ctx, created.RoomID, 7, &finalCode); err != nil {
+		t.Fatalf("expected close to succeed, got %v", err)
+	}
+	if _, err := svc.SubmitScorecard(ctx, created.RoomID, 7, &interview.SubmitScorecardRequest{
+		Rating: 4, Recommendation: interview.RecommendationHire, Notes: "Solid problem solving",
+	}); err != nil {
+		t.Fatalf("expected scorecard to be saved after close, got %v", err)
+	}
+
+	report, err := svc.GetReport(ctx, created.RoomID, 7)
+	if err != nil {
+		t.Fatalf("expected report after close, got %v", err)
+	}
+	if report.ClosedAt == nil {
+		t.Error("expected report to include close time")
+	}
+	if report.FinalCode != finalCode {
+		t.Errorf("expected final code %q, got %q", finalCode, report.FinalCode)
+	}
+	if report.Summary.TotalRuns != 2 || report.Summary.FailedRuns != 1 {
+		t.Errorf("expected 2 runs with 1 failure, got %+v", report.Summary)
+	}
+	if len(report.Scorecards) != 1 {
+		t.Errorf("expected 1 scorecard, got %d", len(report.Scorecards))
+	}
+	if first, last := report.Timeline[0], report.Timeline[len(report.Timeline)-1]; first.Event != "room_created" || last.Event != "scorecard_submitted" {
+		t.Errorf("unexpected timeline order: first=%s last=%s", first.Event, last.Event)
+	}
+
+	md := report.RenderMarkdown()
+	for _, want := range []string{"# Interview Report: " + created.RoomID, "print('done')", "Solid problem solving", "2 runs, 1 failed"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected markdown to contain %q", want)
+		}
+	}
+}
+
+func TestInterviewService_GetReport_OwnerOnly(t *testing.T) {
+	svc := interview.NewService(newFakeRepository(), nil, "secret", "http://localhost:3000")
+	ctx := context.Background()
+
+	created, err := svc.InitRoom(ctx, 7, true, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := svc.GetReport(ctx, created.RoomID, 8); !errors.Is(err, interview.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestReport_RenderHTML_EscapesContent(t *testing.T) {
+	report := &interview.Report{
+		RoomID:    "room1",
+		FinalCode: "<script>alert(1)</script>",
+		CreatedAt: time.Now(),
+	}
+
+	page, err := report.RenderHTML()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if strings.Contains(string(page), "<script>alert(1)</script>") {
+		t.Error("expected final code to be HTML-escaped")
+	}
+}
@@ -2,8 +2,11 @@ package interview
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Repository defines the interface for interview room data access
@@ -11,10 +14,22 @@ type Repository interface {
 	Create(ctx context.Context, room *InterviewRoom) error
 	GetByRoomID(ctx context.Context, roomID string) (*InterviewRoom, error)
 	GetActiveByOwnerID(ctx context.Context, ownerID uint) (*InterviewRoom, error)
+	ListScheduledByOwnerID(ctx context.Context, ownerID uint, from, to time.Time) ([]InterviewRoom, error)
 	Update(ctx context.Context, room *InterviewRoom) error
+	UpdateState(ctx context.Context, room *InterviewRoom, from string) error
 	SoftDelete(ctx context.Context, roomID string) error
 	UpdateHeadcount(ctx context.Context, roomID string, headcount int) error
 	UpdateCodeSnapshot(ctx context.Context, roomID string, code string) error
+	GetByRoomIDUnscoped(ctx context.Context, roomID string) (*InterviewRoom, error)
+	CreateRun(ctx context.Context, run *InterviewRun) error
+	ListRuns(ctx context.Context, roomID string) ([]InterviewRun, error)
+	UpsertScorecard(ctx context.Context, scorecard *Scorecard) error
+	ListScorecards(ctx context.Context, roomID string) ([]Scorecard, error)
+	CreateAdmission(ctx context.Context, admission *Admission, maxPending int) error
+	GetAdmission(ctx context.Context, roomID string, id uint) (*Admission, error)
+	GetAdmissionByTicketHash(ctx context.Context, ticketHash string) (*Admission, error)
+	ListAdmissions(ctx context.Context, roomID string, status AdmissionStatus) ([]Admission, error)
+	UpdateAdmission(ctx context.Context, admission *Admission) error
 }
 
 // repository implements Repository interface using GORM
@@ -44,11 +59,12 @@ func (r *repository) GetByRoomID(ctx context.Context, roomID string) (*Interview
 	return &room, nil
 }
 
-// GetActiveByOwnerID retrieves the active (non-deleted) room owned by a user
+// GetActiveByOwnerID retrieves the active (non-deleted) on-the-spot room owned by a user.
+// Scheduled rooms are excluded so planned interviews don't block ad-hoc ones.
 func (r *repository) GetActiveByOwnerID(ctx context.Context, ownerID uint) (*InterviewRoom, error) {
 	var room InterviewRoom
 	err := r.db.WithContext(ctx).
-		Where("owner_id = ?", ownerID).
+		Where("owner_id = ? AND scheduled_at IS NULL", ownerID).
 		Order("created_at DESC").
 		First(&room).Error
 	if err != nil {
@@ -57,11 +73,49 @@ func (r *repository) GetActiveByOwnerID(ctx context.Context, ownerID uint) (*Int
 	return &room, nil
 }
 
+// ListScheduledByOwnerID retrieves non-deleted scheduled rooms of a user
+// whose slot starts within [from, to), ordered by start time
+func (r *repository) ListScheduledByOwnerID(ctx context.Context, ownerID uint, from, to time.Time) ([]InterviewRoom, error) {
+	var rooms []InterviewRoom
+	err := r.db.WithContext(ctx).
+		Where("owner_id = ? AND scheduled_at >= ? AND scheduled_at < ?", ownerID, from, to).
+		Order("scheduled_at ASC").
+		Find(&rooms).Error
+	if err != nil {
+		return nil, err
+	}
+	return rooms, nil
+}
+
 // Update updates an existing interview room
 func (r *repository) Update(ctx context.Context, room *InterviewRoom) error {
 	return r.db.WithContext(ctx).Save(room).Error
 }
 
+// UpdateState persists only the lifecycle columns of a room, so concurrent
+// headcount and code snapshot updates are not overwritten. The row is only
+// updated while its state is still from, the state the transition was checked
+// against; otherwise ErrInvalidTransition is returned.
+func (r *repository) UpdateState(ctx context.Context, room *InterviewRoom, from string) error {
+	res := r.db.WithContext(ctx).
+		Model(&InterviewRoom{}).
+		Where("room_id = ? AND state = ?", room.RoomID, from).
+		Updates(map[string]any{
+			"state":       room.State,
+			"opened_at":   room.OpenedAt,
+			"started_at":  room.StartedAt,
+			"ended_at":    room.EndedAt,
+			"archived_at": room.ArchivedAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w: room state changed concurrently", ErrInvalidTransition)
+	}
+	return nil
+}
+
 // SoftDelete soft-deletes a room by room_id
 func (r *repository) SoftDelete(ctx context.Context, roomID string) error {
 	return r.db.WithContext(ctx).
@@ -84,3 +138,126 @@ func (r *repository) UpdateCodeSnapshot(ctx context.Context, roomID string, code
 		Where("room_id = ?", roomID).
 		Update("code_snapshot", code).Error
 }
+
+// GetByRoomIDUnscoped retrieves a room by room_id including soft-deleted rooms
+func (r *repository) GetByRoomIDUnscoped(ctx context.Context, roomID string) (*InterviewRoom, error) {
+	var room InterviewRoom
+	err := r.db.WithContext(ctx).
+		Unscoped().
+		Where("room_id = ?", roomID).
+		First(&room).Error
+	if err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
+
+// CreateRun records a code execution for a room
+func (r *repository) CreateRun(ctx context.Context, run *InterviewRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+// ListRuns retrieves all code executions of a room in chronological order
+func (r *repository) ListRuns(ctx context.Context, roomID string) ([]InterviewRun, error) {
+	var runs []InterviewRun
+	err := r.db.WithContext(ctx).
+		Where("room_id = ?", roomID).
+		Order("created_at ASC").
+		Find(&runs).Error
+	if err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// UpsertScorecard creates or replaces the scorecard of an author for a room
+func (r *repository) UpsertScorecard(ctx context.Context, scorecard *Scorecard) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "room_id"}, {Name: "author_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"rating", "recommendation", "notes", "updated_at"}),
+		}).
+		Create(scorecard).Error
+}
+
+// ListScorecards retrieves all scorecards of a room
+func (r *repository) ListScorecards(ctx context.Context, roomID string) ([]Scorecard, error) {
+	var scorecards []Scorecard
+	err := r.db.WithContext(ctx).
+		Where("room_id = ?", roomID).
+		Order("created_at ASC").
+		Find(&scorecards).Error
+	if err != nil {
+		return nil, err
+	}
+	return scorecards, nil
+}
+
+// CreateAdmission adds a joiner to a room's lobby, or returns ErrLobbyFull when
+// maxPending joiners are already waiting there. The room row stays locked until
+// the insert so concurrent joins cannot both take the last place.
+func (r *repository) CreateAdmission(ctx context.Context, admission *Admission, maxPending int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var room InterviewRoom
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("room_id = ?", admission.RoomID).
+			First(&room).Error; err != nil {
+			return err
+		}
+
+		var pending int64
+		if err := tx.Model(&Admission{}).
+			Where("room_id = ? AND status = ?", admission.RoomID, AdmissionPending).
+			Count(&pending).Error; err != nil {
+			return err
+		}
+		if pending >= int64(maxPending) {
+			return ErrLobbyFull
+		}
+		return tx.Create(admission).Error
+	})
+}
+
+// GetAdmission retrieves a lobby entry of a room by ID
+func (r *repository) GetAdmission(ctx context.Context, roomID string, id uint) (*Admission, error) {
+	var admission Admission
+	err := r.db.WithContext(ctx).
+		Where("room_id = ? AND id = ?", roomID, id).
+		First(&admission).Error
+	if err != nil {
+		return nil, err
+	}
+	return &admission, nil
+}
+
+// GetAdmissionByTicketHash retrieves a lobby entry by the hash of its ticket
+func (r *repository) GetAdmissionByTicketHash(ctx context.Context, ticketHash string) (*Admission, error) {
+	var admission Admission
+	err := r.db.WithContext(ctx).
+		Where("ticket_hash = ?", ticketHash).
+		First(&admission).Error
+	if err != nil {
+		return nil, err
+	}
+	return &admission, nil
+}
+
+// ListAdmissions retrieves lobby entries of a room, optionally filtered by status
+func (r *repository) ListAdmissions(ctx context.Context, roomID string, status AdmissionStatus) ([]Admission, error) {
+	q := r.db.WithContext(ctx).Where("room_id = ?", roomID)
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+
+	var admissions []Admission
+	if err := q.Order("created_at ASC").Find(&admissions).Error; err != nil {
+		return nil, err
+	}
+	return admissions, nil
+}
+
+// UpdateAdmission saves a lobby decision
+func (r *repository) UpdateAdmission(ctx context.Context, admission *Admission) error {
+	return r.db.WithContext(ctx).Save(admission).Error
+}
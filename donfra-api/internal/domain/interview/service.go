@@ -6,10 +6,15 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"net/mail"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"gorm.io/gorm"
+
+	"donfra-api/internal/domain/problem"
+	"donfra-api/internal/domain/run"
 )
 
 var (
@@ -18,40 +23,99 @@ var (
 	ErrInvalidToken      = errors.New("invalid invite token")
 	ErrAdminRequired     = errors.New("only admin users can create rooms")
 	ErrRoomAlreadyExists = errors.New("user already has an active room")
+	ErrProblemNotFound   = errors.New("problem not found")
+	ErrNoProblemAttached = errors.New("no problem attached to room")
+	ErrInvalidSchedule   = errors.New("invalid schedule")
+	ErrScheduleConflict  = errors.New("time slot overlaps another scheduled interview")
+	ErrInviteNotYetValid = errors.New("invite token is not valid yet")
+	ErrNotScheduled      = errors.New("room is not a scheduled interview")
+	ErrEmptyCode         = errors.New("code cannot be empty")
+	ErrCodeTooLong       = errors.New("code is too long")
+	ErrInvalidScorecard  = errors.New("invalid scorecard")
+	ErrInvalidTransition = errors.New("invalid room state transition")
+	ErrRoomNotActive     = errors.New("room is no longer active")
+)
+
+const (
+	defaultInterviewMinutes = 60
+	minInterviewMinutes     = 15
+	maxInterviewMinutes     = 8 * 60
+
+	// Invite tokens of scheduled rooms are only valid within a window around the slot
+	inviteEarlyJoinWindow = 15 * time.Minute
+	inviteLateJoinWindow  = 30 * time.Minute
+
+	// Rooms created on the spot keep the original 24h invite validity
+	adHocInviteValidity = 24 * time.Hour
+
+	// Participants get a signed access token on join, valid for one day
+	roomAccessValidity = 24 * time.Hour
+
+	// Code runs inside a room are bounded like the legacy /room/run endpoint
+	runTimeout        = 5 * time.Second
+	maxRunOutputBytes = 64 << 10
 )
 
+// MaxRunCodeLength is the maximum size of code run inside a room in bytes
+const MaxRunCodeLength = 64 << 10
+
+// ProblemProvider looks up question bank problems that can be attached to rooms
+type ProblemProvider interface {
+	GetProblem(ctx context.Context, id uint) (*problem.Problem, error)
+}
+
 // Service defines the interface for interview room business logic
 type Service interface {
-	InitRoom(ctx context.Context, userID uint, isAdmin bool) (*InitRoomResponse, error)
-	JoinRoom(ctx context.Context, inviteToken string) (*JoinRoomResponse, error)
-	CloseRoom(ctx context.Context, roomID string, userID uint) error
+	InitRoom(ctx context.Context, userID uint, isAdmin bool, req *InitRoomRequest) (*InitRoomResponse, error)
+	JoinRoom(ctx context.Context, req *JoinRoomRequest) (*JoinRoomResponse, error)
+	CloseRoom(ctx context.Context, roomID string, userID uint, finalCode *string) error
 	GetRoomByID(ctx context.Context, roomID string) (*InterviewRoom, error)
+	ValidateRoomAccess(ctx context.Context, roomID, token string) error
 	UpdateHeadcount(ctx context.Context, roomID string, headcount int) error
+	GetRoomProblem(ctx context.Context, roomID string) (*problem.ProblemPublic, error)
+	ScheduleRoom(ctx context.Context, userID uint, isAdmin bool, req *ScheduleRoomRequest) (*ScheduleRoomResponse, error)
+	ListScheduledRooms(ctx context.Context, userID uint) ([]InterviewRoom, error)
+	GetRoomCalendar(ctx context.Context, roomID string, userID uint) ([]byte, error)
+	RunCode(ctx context.Context, roomID string, code string) (*InterviewRun, error)
+	SubmitScorecard(ctx context.Context, roomID string, userID uint, req *SubmitScorecardRequest) (*Scorecard, error)
+	GetReport(ctx context.Context, roomID string, userID uint) (*Report, error)
+	StartInterview(ctx context.Context, roomID string, userID uint) (*RoomStateResponse, error)
+	EndInterview(ctx context.Context, roomID string, userID uint) (*RoomStateResponse, error)
+	GetAdmissionStatus(ctx context.Context, ticket string) (*JoinRoomResponse, error)
+	ListLobby(ctx context.Context, roomID string, userID uint) ([]Admission, error)
+	DecideAdmission(ctx context.Context, roomID string, admissionID uint, userID uint, admit bool) (*Admission, error)
 }
 
 // service implements Service interface
 type service struct {
 	repo      Repository
+	problems  ProblemProvider
 	jwtSecret []byte
 	baseURL   string
+	now       func() time.Time
 }
 
 // NewService creates a new interview room service
-func NewService(repo Repository, jwtSecret, baseURL string) Service {
+func NewService(repo Repository, problems ProblemProvider, jwtSecret, baseURL string) Service {
 	return &service{
 		repo:      repo,
+		problems:  problems,
 		jwtSecret: []byte(jwtSecret),
 		baseURL:   baseURL,
+		now:       time.Now,
 	}
 }
 
 // InitRoom creates a new interview room
 // Only admin users can create rooms
-func (s *service) InitRoom(ctx context.Context, userID uint, isAdmin bool) (*InitRoomResponse, error) {
+func (s *service) InitRoom(ctx context.Context, userID uint, isAdmin bool, req *InitRoomRequest) (*InitRoomResponse, error) {
 	// Only admin users can create rooms
 	if !isAdmin {
 		return nil, ErrAdminRequired
 	}
+	if req == nil {
+		req = &InitRoomRequest{}
+	}
 
 	// Check if user already has an active room
 	existingRoom, err := s.repo.GetActiveByOwnerID(ctx, userID)
@@ -62,6 +126,13 @@ func (s *service) InitRoom(ctx context.Context, userID uint, isAdmin bool) (*Ini
 		return nil, fmt.Errorf("failed to check existing room: %w", err)
 	}
 
+	// Verify the attached problem exists before creating the room
+	if req.ProblemID != nil {
+		if _, err := s.getProblem(ctx, *req.ProblemID); err != nil {
+			return nil, err
+		}
+	}
+
 	// Generate unique room ID
 	roomID, err := generateRoomID()
 	if err != nil {
@@ -69,7 +140,7 @@ func (s *service) InitRoom(ctx context.Context, userID uint, isAdmin bool) (*Ini
 	}
 
 	// Generate invite token (JWT) containing room_id
-	token, err := s.generateInviteToken(roomID)
+	token, err := s.generateInviteToken(roomID, time.Time{}, s.now().Add(adHocInviteValidity))
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate invite token: %w", err)
 	}
@@ -77,13 +148,18 @@ func (s *service) InitRoom(ctx context.Context, userID uint, isAdmin bool) (*Ini
 	// Construct invite link
 	inviteLink := fmt.Sprintf("%s/interview?token=%s", s.baseURL, token)
 
-	// Create room in database
+	// Create room in database (open immediately, waiting for the candidate)
+	openedAt := s.now()
 	room := &InterviewRoom{
 		RoomID:       roomID,
 		OwnerID:      userID,
 		Headcount:    3, // default headcount 3, one for interviewer and two for candidates
 		CodeSnapshot: "",
 		InviteLink:   inviteLink,
+		ProblemID:    req.ProblemID,
+		State:        string(StateWaiting),
+		OpenedAt:     &openedAt,
+		LobbyEnabled: req.LobbyEnabled,
 	}
 
 	if err := s.repo.Create(ctx, room); err != nil {
@@ -93,14 +169,16 @@ func (s *service) InitRoom(ctx context.Context, userID uint, isAdmin bool) (*Ini
 	return &InitRoomResponse{
 		RoomID:     roomID,
 		InviteLink: inviteLink,
+		ProblemID:  req.ProblemID,
 		Message:    "Interview room created successfully",
 	}, nil
 }
 
-// JoinRoom validates invite token and allows user to join the room
-func (s *service) JoinRoom(ctx context.Context, inviteToken string) (*JoinRoomResponse, error) {
+// JoinRoom validates invite token and allows user to join the room.
+// Rooms with the lobby enabled put the joiner in the pending list instead.
+func (s *service) JoinRoom(ctx context.Context, req *JoinRoomRequest) (*JoinRoomResponse, error) {
 	// Validate invite token and extract room_id
-	roomID, err := s.validateInviteToken(inviteToken)
+	roomID, err := s.validateInviteToken(req.InviteToken)
 	if err != nil {
 		return nil, err
 	}
@@ -114,14 +192,37 @@ func (s *service) JoinRoom(ctx context.Context, inviteToken string) (*JoinRoomRe
 		return nil, fmt.Errorf("failed to get room: %w", err)
 	}
 
+	if !room.CurrentState().IsActive() {
+		return nil, ErrRoomNotActive
+	}
+
+	// The first join of a scheduled room opens it
+	if room.CurrentState() == StateScheduled {
+		if room, err = s.openRoom(ctx, room); err != nil {
+			return nil, err
+		}
+	}
+
+	if room.LobbyEnabled {
+		return s.enterLobby(ctx, room, req.DisplayName)
+	}
+
+	accessToken, err := s.generateAccessToken(room.RoomID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
 	return &JoinRoomResponse{
-		RoomID:  room.RoomID,
-		Message: "Successfully joined interview room",
+		RoomID:      room.RoomID,
+		Status:      AdmissionAdmitted,
+		Message:     "Successfully joined interview room",
+		AccessToken: accessToken,
 	}, nil
 }
 
-// CloseRoom soft-deletes a room (only owner can close)
-func (s *service) CloseRoom(ctx context.Context, roomID string, userID uint) error {
+// CloseRoom soft-deletes a room (only owner can close).
+// If finalCode is provided it is stored as the room's code snapshot first.
+func (s *service) CloseRoom(ctx context.Context, roomID string, userID uint, finalCode *string) error {
 	// Get room to verify ownership
 	room, err := s.repo.GetByRoomID(ctx, roomID)
 	if err != nil {
@@ -136,6 +237,22 @@ func (s *service) CloseRoom(ctx context.Context, roomID string, userID uint) err
 		return ErrUnauthorized
 	}
 
+	from := room.State
+	if err := room.Transition(StateArchived, s.now()); err != nil {
+		return err
+	}
+	if finalCode != nil {
+		if err := s.repo.UpdateCodeSnapshot(ctx, roomID, *finalCode); err != nil {
+			return fmt.Errorf("failed to save code snapshot: %w", err)
+		}
+	}
+	if err := s.repo.UpdateState(ctx, room, from); err != nil {
+		if errors.Is(err, ErrInvalidTransition) {
+			return err
+		}
+		return fmt.Errorf("failed to archive room: %w", err)
+	}
+
 	// Soft delete the room
 	if err := s.repo.SoftDelete(ctx, roomID); err != nil {
 		return fmt.Errorf("failed to close room: %w", err)
@@ -161,6 +278,357 @@ func (s *service) UpdateHeadcount(ctx context.Context, roomID string, headcount
 	return s.repo.UpdateHeadcount(ctx, roomID, headcount)
 }
 
+// ScheduleRoom creates an interview room for a future time slot.
+// The invite token only becomes valid shortly before the slot and expires after it ends.
+func (s *service) ScheduleRoom(ctx context.Context, userID uint, isAdmin bool, req *ScheduleRoomRequest) (*ScheduleRoomResponse, error) {
+	if !isAdmin {
+		return nil, ErrAdminRequired
+	}
+
+	start, duration, err := s.validateSchedule(req)
+	if err != nil {
+		return nil, err
+	}
+	end := start.Add(duration)
+
+	if req.ProblemID != nil {
+		if _, err := s.getProblem(ctx, *req.ProblemID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Reject slots overlapping another scheduled interview of the same owner
+	existing, err := s.repo.ListScheduledByOwnerID(ctx, userID, start.Add(-maxInterviewMinutes*time.Minute), end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check schedule: %w", err)
+	}
+	for _, other := range existing {
+		otherStart := *other.ScheduledAt
+		otherEnd := otherStart.Add(time.Duration(other.DurationMinutes) * time.Minute)
+		if otherStart.Before(end) && otherEnd.After(start) {
+			return nil, ErrScheduleConflict
+		}
+	}
+
+	roomID, err := generateRoomID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate room ID: %w", err)
+	}
+
+	validFrom := start.Add(-inviteEarlyJoinWindow)
+	validUntil := end.Add(inviteLateJoinWindow)
+	token, err := s.generateInviteToken(roomID, validFrom, validUntil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invite token: %w", err)
+	}
+	inviteLink := fmt.Sprintf("%s/interview?token=%s", s.baseURL, token)
+
+	room := &InterviewRoom{
+		RoomID:          roomID,
+		OwnerID:         userID,
+		Headcount:       3,
+		CodeSnapshot:    "",
+		InviteLink:      inviteLink,
+		ProblemID:       req.ProblemID,
+		ScheduledAt:     &start,
+		DurationMinutes: int(duration / time.Minute),
+		CandidateName:   strings.TrimSpace(req.CandidateName),
+		CandidateEmail:  strings.TrimSpace(req.CandidateEmail),
+		State:           string(StateScheduled),
+		LobbyEnabled:    req.LobbyEnabled,
+	}
+
+	if err := s.repo.Create(ctx, room); err != nil {
+		return nil, fmt.Errorf("failed to create room: %w", err)
+	}
+
+	return &ScheduleRoomResponse{
+		RoomID:          roomID,
+		InviteLink:      inviteLink,
+		ScheduledAt:     start,
+		DurationMinutes: room.DurationMinutes,
+		ValidFrom:       validFrom,
+		ValidUntil:      validUntil,
+		CalendarURL:     fmt.Sprintf("/api/v1/interview/%s/calendar.ics", roomID),
+		Message:         "Interview scheduled successfully",
+	}, nil
+}
+
+// ListScheduledRooms returns the user's scheduled interviews that have not ended yet
+func (s *service) ListScheduledRooms(ctx context.Context, userID uint) ([]InterviewRoom, error) {
+	now := s.now()
+	rooms, err := s.repo.ListScheduledByOwnerID(ctx, userID, now.Add(-maxInterviewMinutes*time.Minute), now.AddDate(1, 0, 0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled rooms: %w", err)
+	}
+
+	upcoming := make([]InterviewRoom, 0, len(rooms))
+	for _, room := range rooms {
+		end := room.ScheduledAt.Add(time.Duration(room.DurationMinutes) * time.Minute)
+		if end.After(now) {
+			upcoming = append(upcoming, room)
+		}
+	}
+	return upcoming, nil
+}
+
+// GetRoomCalendar renders the iCalendar (.ics) file of a scheduled room (only owner can export)
+func (s *service) GetRoomCalendar(ctx context.Context, roomID string, userID uint) ([]byte, error) {
+	room, err := s.GetRoomByID(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.OwnerID != userID {
+		return nil, ErrUnauthorized
+	}
+	return BuildCalendar(room, s.now())
+}
+
+// validateSchedule checks the requested slot and returns its start (UTC) and duration.
+// CandidateEmail is normalized to the bare address ("Ada <ada@example.com>" becomes
+// "ada@example.com") so it can be used as a mailto: URI.
+func (s *service) validateSchedule(req *ScheduleRoomRequest) (time.Time, time.Duration, error) {
+	if req == nil || req.ScheduledAt.IsZero() {
+		return time.Time{}, 0, fmt.Errorf("%w: scheduled_at is required", ErrInvalidSchedule)
+	}
+	start := req.ScheduledAt.UTC().Truncate(time.Minute)
+	if !start.After(s.now()) {
+		return time.Time{}, 0, fmt.Errorf("%w: scheduled_at must be in the future", ErrInvalidSchedule)
+	}
+
+	minutes := req.DurationMinutes
+	if minutes == 0 {
+		minutes = defaultInterviewMinutes
+	}
+	if minutes < minInterviewMinutes || minutes > maxInterviewMinutes {
+		return time.Time{}, 0, fmt.Errorf("%w: duration_minutes must be between %d and %d", ErrInvalidSchedule, minInterviewMinutes, maxInterviewMinutes)
+	}
+
+	if strings.TrimSpace(req.CandidateName) == "" {
+		return time.Time{}, 0, fmt.Errorf("%w: candidate_name is required", ErrInvalidSchedule)
+	}
+	if email := strings.TrimSpace(req.CandidateEmail); email != "" {
+		addr, err := mail.ParseAddress(email)
+		if err != nil {
+			return time.Time{}, 0, fmt.Errorf("%w: candidate_email is invalid", ErrInvalidSchedule)
+		}
+		req.CandidateEmail = addr.Address
+	}
+
+	return start, time.Duration(minutes) * time.Minute, nil
+}
+
+// RunCode executes code inside an active room, records the run and
+// keeps the latest executed code as the room's snapshot
+func (s *service) RunCode(ctx context.Context, roomID string, code string) (*InterviewRun, error) {
+	if strings.TrimSpace(code) == "" {
+		return nil, ErrEmptyCode
+	}
+	if len(code) > MaxRunCodeLength {
+		return nil, ErrCodeTooLong
+	}
+	room, err := s.GetRoomByID(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if !room.CurrentState().IsActive() {
+		return nil, ErrRoomNotActive
+	}
+
+	// Room runs share the judge's slots so they cannot starve exercise submissions
+	release, err := run.AcquireJudgeSlot()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	runCtx, cancel := context.WithTimeout(ctx, runTimeout)
+	defer cancel()
+
+	started := time.Now()
+	result := run.RunPythonWithInput(runCtx, code, "", maxRunOutputBytes)
+	record := &InterviewRun{
+		RoomID:     roomID,
+		Language:   "python",
+		Code:       code,
+		Stdout:     storableOutput(result.Stdout),
+		Stderr:     storableOutput(result.Stderr),
+		TimedOut:   errors.Is(runCtx.Err(), context.DeadlineExceeded),
+		DurationMs: time.Since(started).Milliseconds(),
+	}
+	if record.TimedOut {
+		record.Stderr = "Execution timed out"
+	}
+
+	if err := s.repo.CreateRun(ctx, record); err != nil {
+		return nil, fmt.Errorf("failed to record run: %w", err)
+	}
+	if err := s.repo.UpdateCodeSnapshot(ctx, roomID, code); err != nil {
+		return nil, fmt.Errorf("failed to update code snapshot: %w", err)
+	}
+	return record, nil
+}
+
+// StartInterview moves a waiting (or scheduled) room to in_progress (only owner can start)
+func (s *service) StartInterview(ctx context.Context, roomID string, userID uint) (*RoomStateResponse, error) {
+	return s.transitionRoom(ctx, roomID, userID, func(room *InterviewRoom, now time.Time) error {
+		// Starting a scheduled room before anyone joined opens it implicitly
+		if room.CurrentState() == StateScheduled {
+			if err := room.Transition(StateWaiting, now); err != nil {
+				return err
+			}
+		}
+		return room.Transition(StateInProgress, now)
+	}, "Interview started")
+}
+
+// EndInterview moves a room to ended (only owner can end).
+// Ending a room that was never started records a no-show.
+func (s *service) EndInterview(ctx context.Context, roomID string, userID uint) (*RoomStateResponse, error) {
+	return s.transitionRoom(ctx, roomID, userID, func(room *InterviewRoom, now time.Time) error {
+		return room.Transition(StateEnded, now)
+	}, "Interview ended")
+}
+
+// transitionRoom loads an owned room, applies a state change and persists it
+func (s *service) transitionRoom(ctx context.Context, roomID string, userID uint, apply func(*InterviewRoom, time.Time) error, message string) (*RoomStateResponse, error) {
+	room, err := s.GetRoomByID(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.OwnerID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	from := room.State
+	if err := apply(room, s.now()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateState(ctx, room, from); err != nil {
+		if errors.Is(err, ErrInvalidTransition) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update room state: %w", err)
+	}
+
+	return &RoomStateResponse{
+		RoomID:    room.RoomID,
+		State:     room.CurrentState(),
+		StartedAt: room.StartedAt,
+		EndedAt:   room.EndedAt,
+		NoShow:    room.IsNoShow(),
+		Message:   message,
+	}, nil
+}
+
+// openRoom moves a scheduled room to waiting on its first join. A concurrent join
+// may have opened it already, which is fine as long as the room is still active.
+func (s *service) openRoom(ctx context.Context, room *InterviewRoom) (*InterviewRoom, error) {
+	from := room.State
+	if err := room.Transition(StateWaiting, s.now()); err != nil {
+		return nil, err
+	}
+	err := s.repo.UpdateState(ctx, room, from)
+	if err == nil {
+		return room, nil
+	}
+	if !errors.Is(err, ErrInvalidTransition) {
+		return nil, fmt.Errorf("failed to open room: %w", err)
+	}
+
+	room, err = s.GetRoomByID(ctx, room.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	if !room.CurrentState().IsActive() {
+		return nil, ErrRoomNotActive
+	}
+	return room, nil
+}
+
+// SubmitScorecard creates or replaces the owner's scorecard for a room.
+// Scorecards can still be submitted after the room is closed.
+func (s *service) SubmitScorecard(ctx context.Context, roomID string, userID uint, req *SubmitScorecardRequest) (*Scorecard, error) {
+	room, err := s.getRoomIncludingClosed(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.OwnerID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	if req.Rating < 1 || req.Rating > 5 {
+		return nil, fmt.Errorf("%w: rating must be between 1 and 5", ErrInvalidScorecard)
+	}
+	switch req.Recommendation {
+	case RecommendationStrongHire, RecommendationHire, RecommendationNoHire, RecommendationStrongNoHire:
+	default:
+		return nil, fmt.Errorf("%w: recommendation must be one of strong_hire, hire, no_hire, strong_no_hire", ErrInvalidScorecard)
+	}
+
+	scorecard := &Scorecard{
+		RoomID:         roomID,
+		AuthorID:       userID,
+		Rating:         req.Rating,
+		Recommendation: req.Recommendation,
+		Notes:          strings.TrimSpace(req.Notes),
+	}
+	if err := s.repo.UpsertScorecard(ctx, scorecard); err != nil {
+		return nil, fmt.Errorf("failed to save scorecard: %w", err)
+	}
+	return scorecard, nil
+}
+
+// getRoomIncludingClosed retrieves a room by room_id including soft-deleted rooms
+func (s *service) getRoomIncludingClosed(ctx context.Context, roomID string) (*InterviewRoom, error) {
+	room, err := s.repo.GetByRoomIDUnscoped(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoomNotFound
+		}
+		return nil, fmt.Errorf("failed to get room: %w", err)
+	}
+	return room, nil
+}
+
+// storableOutput prepares run output, already capped on a character boundary by the
+// runner, for a text column: Postgres rejects NUL bytes and invalid UTF-8.
+func storableOutput(out string) string {
+	return strings.ToValidUTF8(strings.ReplaceAll(out, "\x00", ""), "")
+}
+
+// GetRoomProblem returns the candidate-facing problem attached to a room
+func (s *service) GetRoomProblem(ctx context.Context, roomID string) (*problem.ProblemPublic, error) {
+	room, err := s.GetRoomByID(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.ProblemID == nil {
+		return nil, ErrNoProblemAttached
+	}
+
+	p, err := s.getProblem(ctx, *room.ProblemID)
+	if err != nil {
+		return nil, err
+	}
+	return p.ToPublic(), nil
+}
+
+// getProblem looks up a problem through the provider and maps not-found errors
+func (s *service) getProblem(ctx context.Context, id uint) (*problem.Problem, error) {
+	if s.problems == nil {
+		return nil, ErrProblemNotFound
+	}
+	p, err := s.problems.GetProblem(ctx, id)
+	if err != nil {
+		if errors.Is(err, problem.ErrProblemNotFound) {
+			return nil, ErrProblemNotFound
+		}
+		return nil, fmt.Errorf("failed to get problem: %w", err)
+	}
+	return p, nil
+}
+
 // generateRoomID generates a random room ID
 func generateRoomID() (string, error) {
 	bytes := make([]byte, 16)
@@ -176,16 +644,20 @@ type InviteTokenClaims struct {
 	jwt.RegisteredClaims
 }
 
-// generateInviteToken creates a JWT token for room invitation
-func (s *service) generateInviteToken(roomID string) (string, error) {
+// generateInviteToken creates a JWT token for room invitation.
+// A zero notBefore makes the token valid immediately.
+func (s *service) generateInviteToken(roomID string, notBefore, expiresAt time.Time) (string, error) {
 	claims := InviteTokenClaims{
 		RoomID: roomID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   "interview_room",
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			Issuer:    "donfra-api",
 		},
 	}
+	if !notBefore.IsZero() {
+		claims.NotBefore = jwt.NewNumericDate(notBefore)
+	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(s.jwtSecret)
 }
@@ -194,8 +666,11 @@ func (s *service) generateInviteToken(roomID string) (string, error) {
 func (s *service) validateInviteToken(tokenString string) (string, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &InviteTokenClaims{}, func(t *jwt.Token) (interface{}, error) {
 		return s.jwtSecret, nil
-	})
+	}, jwt.WithTimeFunc(s.now))
 	if err != nil {
+		if errors.Is(err, jwt.ErrTokenNotValidYet) {
+			return "", ErrInviteNotYetValid
+		}
 		return "", ErrInvalidToken
 	}
 
@@ -214,3 +689,60 @@ func (s *service) validateInviteToken(tokenString string) (string, error) {
 
 	return claims.RoomID, nil
 }
+
+// RoomAccessClaims represents the JWT claims of the room_access cookie issued to
+// participants once they have joined a room. AdmissionID is set for joiners
+// admitted through the lobby.
+type RoomAccessClaims struct {
+	RoomID      string `json:"room_id"`
+	AdmissionID uint   `json:"admission_id,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// generateAccessToken creates a signed participant access token for a room.
+// admissionID is 0 for rooms without a lobby.
+func (s *service) generateAccessToken(roomID string, admissionID uint) (string, error) {
+	claims := RoomAccessClaims{
+		RoomID:      roomID,
+		AdmissionID: admissionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "interview_access",
+			ExpiresAt: jwt.NewNumericDate(s.now().Add(roomAccessValidity)),
+			Issuer:    "donfra-api",
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(s.jwtSecret)
+}
+
+// ValidateRoomAccess checks that token is a participant access token for roomID and,
+// for lobby joiners, that their admission is still on record as admitted.
+// Returns ErrUnauthorized otherwise.
+func (s *service) ValidateRoomAccess(ctx context.Context, roomID, tokenString string) error {
+	token, err := jwt.ParseWithClaims(tokenString, &RoomAccessClaims{}, func(t *jwt.Token) (interface{}, error) {
+		return s.jwtSecret, nil
+	}, jwt.WithTimeFunc(s.now), jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return ErrUnauthorized
+	}
+
+	claims, ok := token.Claims.(*RoomAccessClaims)
+	if !ok || !token.Valid || claims.Subject != "interview_access" || claims.RoomID != roomID {
+		return ErrUnauthorized
+	}
+	if claims.AdmissionID == 0 {
+		return nil
+	}
+
+	admission, err := s.repo.GetAdmission(ctx, roomID, claims.AdmissionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUnauthorized
+		}
+		return fmt.Errorf("failed to get lobby entry: %w", err)
+	}
+	if admission.Status != AdmissionAdmitted {
+		return ErrUnauthorized
+	}
+	return nil
+}
@@ -0,0 +1,354 @@
+package interview_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"donfra-api/internal/domain/interview"
+)
+
+// fakeRepository is an in-memory interview.Repository for tests
+type fakeRepository struct {
+	rooms      map[string]*interview.InterviewRoom
+	runs       []interview.InterviewRun
+	scorecards []interview.Scorecard
+	admissions []*interview.Admission
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{rooms: map[string]*interview.InterviewRoom{}}
+}
+
+func (f *fakeRepository) Create(ctx context.Context, room *interview.InterviewRoom) error {
+	room.ID = uint(len(f.rooms) + 1)
+	room.CreatedAt = time.Now()
+	f.rooms[room.RoomID] = room
+	return nil
+}
+
+func (f *fakeRepository) GetByRoomID(ctx context.Context, roomID string) (*interview.InterviewRoom, error) {
+	room, ok := f.rooms[roomID]
+	if !ok || room.DeletedAt.Valid {
+		return nil, gorm.ErrRecordNotFound
+	}
+	// Callers get their own copy, like rows loaded from the database
+	loaded := *room
+	return &loaded, nil
+}
+
+func (f *fakeRepository) GetByRoomIDUnscoped(ctx context.Context, roomID string) (*interview.InterviewRoom, error) {
+	room, ok := f.rooms[roomID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	loaded := *room
+	return &loaded, nil
+}
+
+func (f *fakeRepository) GetActiveByOwnerID(ctx context.Context, ownerID uint) (*interview.InterviewRoom, error) {
+	for _, room := range f.rooms {
+		if room.OwnerID == ownerID && room.ScheduledAt == nil && !room.DeletedAt.Valid {
+			return room, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeRepository) ListScheduledByOwnerID(ctx context.Context, ownerID uint, from, to time.Time) ([]interview.InterviewRoom, error) {
+	var rooms []interview.InterviewRoom
+	for _, room := range f.rooms {
+		if room.OwnerID == ownerID && room.ScheduledAt != nil && !room.DeletedAt.Valid &&
+			!room.ScheduledAt.Before(from) && room.ScheduledAt.Before(to) {
+			rooms = append(rooms, *room)
+		}
+	}
+	return rooms, nil
+}
+
+func (f *fakeRepository) Update(ctx context.Context, room *interview.InterviewRoom) error {
+	f.rooms[room.RoomID] = room
+	return nil
+}
+
+func (f *fakeRepository) UpdateState(ctx context.Context, room *interview.InterviewRoom, from string) error {
+	if stored, ok := f.rooms[room.RoomID]; ok {
+		if stored.State != from {
+			return interview.ErrInvalidTransition
+		}
+		stored.State = room.State
+		stored.OpenedAt = room.OpenedAt
+		stored.StartedAt = room.StartedAt
+		stored.EndedAt = room.EndedAt
+		stored.ArchivedAt = room.ArchivedAt
+	}
+	return nil
+}
+
+func (f *fakeRepository) SoftDelete(ctx context.Context, roomID string) error {
+	if room, ok := f.rooms[roomID]; ok {
+		room.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	}
+	return nil
+}
+
+func (f *fakeRepository) UpdateHeadcount(ctx context.Context, roomID string, headcount int) error {
+	if room, ok := f.rooms[roomID]; ok {
+		room.Headcount = headcount
+	}
+	return nil
+}
+
+func (f *fakeRepository) UpdateCodeSnapshot(ctx context.Context, roomID string, code string) error {
+	if room, ok := f.rooms[roomID]; ok {
+		room.CodeSnapshot = code
+	}
+	return nil
+}
+
+func (f *fakeRepository) CreateRun(ctx context.Context, run *interview.InterviewRun) error {
+	run.ID = uint(len(f.runs) + 1)
+	run.CreatedAt = time.Now()
+	f.runs = append(f.runs, *run)
+	return nil
+}
+
+func (f *fakeRepository) ListRuns(ctx context.Context, roomID string) ([]interview.InterviewRun, error) {
+	var runs []interview.InterviewRun
+	for _, r := range f.runs {
+		if r.RoomID == roomID {
+			runs = append(runs, r)
+		}
+	}
+	return runs, nil
+}
+
+func (f *fakeRepository) UpsertScorecard(ctx context.Context, scorecard *interview.Scorecard) error {
+	scorecard.UpdatedAt = time.Now()
+	for i, sc := range f.scorecards {
+		if sc.RoomID == scorecard.RoomID && sc.AuthorID == scorecard.AuthorID {
+			f.scorecards[i] = *scorecard
+			return nil
+		}
+	}
+	scorecard.CreatedAt = scorecard.UpdatedAt
+	f.scorecards = append(f.scorecards, *scorecard)
+	return nil
+}
+
+func (f *fakeRepository) ListScorecards(ctx context.Context, roomID string) ([]interview.Scorecard, error) {
+	var scorecards []interview.Scorecard
+	for _, sc := range f.scorecards {
+		if sc.RoomID == roomID {
+			scorecards = append(scorecards, sc)
+		}
+	}
+	return scorecards, nil
+}
+
+func (f *fakeRepository) CreateAdmission(ctx context.Context, admission *interview.Admission, maxPending int) error {
+	pending := 0
+	for _, a := range f.admissions {
+		if a.RoomID == admission.RoomID && a.Status == interview.AdmissionPending {
+			pending++
+		}
+	}
+	if pending >= maxPending {
+		return interview.ErrLobbyFull
+	}
+	admission.ID = uint(len(f.admissions) + 1)
+	admission.CreatedAt = time.Now()
+	f.admissions = append(f.admissions, admission)
+	return nil
+}
+
+func (f *fakeRepository) GetAdmission(ctx context.Context, roomID string, id uint) (*interview.Admission, error) {
+	for _, a := range f.admissions {
+		if a.RoomID == roomID && a.ID == id {
+			return a, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeRepository) GetAdmissionByTicketHash(ctx context.Context, ticketHash string) (*interview.Admission, error) {
+	for _, a := range f.admissions {
+		if a.TicketHash == ticketHash {
+			return a, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeRepository) ListAdmissions(ctx context.Context, roomID string, status interview.AdmissionStatus) ([]interview.Admission, error) {
+	var admissions []interview.Admission
+	for _, a := range f.admissions {
+		if a.RoomID == roomID && (status == "" || a.Status == status) {
+			admissions = append(admissions, *a)
+		}
+	}
+	return admissions, nil
+}
+
+func (f *fakeRepository) UpdateAdmission(ctx context.Context, admission *interview.Admission) error {
+	return nil
+}
+
+// inviteToken extracts the token query parameter from an invite link
+func inviteToken(t *testing.T, link string) string {
+	t.Helper()
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("invalid invite link %q: %v", link, err)
+	}
+	return u.Query().Get("token")
+}
+
+func TestInterviewService_ScheduleRoom_TokenNotValidBeforeWindow(t *testing.T) {
+	svc := interview.NewService(newFakeRepository(), nil, "secret", "http://localhost:3000")
+	ctx := context.Background()
+
+	resp, err := svc.ScheduleRoom(ctx, 1, true, &interview.ScheduleRoomRequest{
+		ScheduledAt:   time.Now().Add(2 * time.Hour),
+		CandidateName: "Grace Hopper",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if resp.DurationMinutes != 60 {
+		t.Errorf("expected default duration 60, got %d", resp.DurationMinutes)
+	}
+
+	_, err = svc.JoinRoom(ctx, &interview.JoinRoomRequest{InviteToken: inviteToken(t, resp.InviteLink)})
+	if !errors.Is(err, interview.ErrInviteNotYetValid) {
+		t.Errorf("expected ErrInviteNotYetValid, got %v", err)
+	}
+}
+
+func TestInterviewService_ScheduleRoom_TokenValidInsideWindow(t *testing.T) {
+	svc := interview.NewService(newFakeRepository(), nil, "secret", "http://localhost:3000")
+	ctx := context.Background()
+
+	// Starting in 5 minutes is inside the early-join window
+	resp, err := svc.ScheduleRoom(ctx, 1, true, &interview.ScheduleRoomRequest{
+		ScheduledAt:   time.Now().Add(5 * time.Minute),
+		CandidateName: "Grace Hopper",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	joined, err := svc.JoinRoom(ctx, &interview.JoinRoomRequest{InviteToken: inviteToken(t, resp.InviteLink)})
+	if err != nil {
+		t.Fatalf("expected join to succeed, got %v", err)
+	}
+	if joined.RoomID != resp.RoomID {
+		t.Errorf("expected room %s, got %s", resp.RoomID, joined.RoomID)
+	}
+}
+
+func TestInterviewService_ScheduleRoom_Validation(t *testing.T) {
+	svc := interview.NewService(newFakeRepository(), nil, "secret", "http://localhost:3000")
+	ctx := context.Background()
+
+	cases := []struct {
+		name string
+		req  interview.ScheduleRoomRequest
+	}{
+		{"past slot", interview.ScheduleRoomRequest{ScheduledAt: time.Now().Add(-time.Hour), CandidateName: "A"}},
+		{"missing candidate", interview.ScheduleRoomRequest{ScheduledAt: time.Now().Add(time.Hour)}},
+		{"too long", interview.ScheduleRoomRequest{ScheduledAt: time.Now().Add(time.Hour), CandidateName: "A", DurationMinutes: 1000}},
+		{"bad email", interview.ScheduleRoomRequest{ScheduledAt: time.Now().Add(time.Hour), CandidateName: "A", CandidateEmail: "nope"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := svc.ScheduleRoom(ctx, 1, true, &tc.req)
+			if !errors.Is(err, interview.ErrInvalidSchedule) {
+				t.Errorf("expected ErrInvalidSchedule, got %v", err)
+			}
+		})
+	}
+}
+
+func TestInterviewService_ScheduleRoom_Conflict(t *testing.T) {
+	svc := interview.NewService(newFakeRepository(), nil, "secret", "http://localhost:3000")
+	ctx := context.Background()
+	start := time.Now().Add(24 * time.Hour)
+
+	if _, err := svc.ScheduleRoom(ctx, 1, true, &interview.ScheduleRoomRequest{
+		ScheduledAt: start, DurationMinutes: 60, CandidateName: "A",
+	}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	_, err := svc.ScheduleRoom(ctx, 1, true, &interview.ScheduleRoomRequest{
+		ScheduledAt: start.Add(30 * time.Minute), CandidateName: "B",
+	})
+	if !errors.Is(err, interview.ErrScheduleConflict) {
+		t.Errorf("expected ErrScheduleConflict, got %v", err)
+	}
+
+	// Scheduled rooms must not block creating an on-the-spot room
+	if _, err := svc.InitRoom(ctx, 1, true, nil); err != nil {
+		t.Errorf("expected InitRoom to succeed alongside scheduled rooms, got %v", err)
+	}
+}
+
+func TestInterviewService_ValidateRoomAccess(t *testing.T) {
+	svc := interview.NewService(newFakeRepository(), nil, "secret", "http://localhost:3000")
+	ctx := context.Background()
+
+	room, err := svc.InitRoom(ctx, 1, true, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	joined, err := svc.JoinRoom(ctx, &interview.JoinRoomRequest{InviteToken: inviteToken(t, room.InviteLink)})
+	if err != nil {
+		t.Fatalf("expected join to succeed, got %v", err)
+	}
+	if joined.AccessToken == "" || joined.AccessToken == room.RoomID {
+		t.Fatalf("expected a signed access token, got %q", joined.AccessToken)
+	}
+
+	if err := svc.ValidateRoomAccess(ctx, room.RoomID, joined.AccessToken); err != nil {
+		t.Errorf("expected the access token to be valid, got %v", err)
+	}
+	for name, token := range map[string]string{
+		"room id as token": room.RoomID,
+		"invite token":     inviteToken(t, room.InviteLink),
+		"tampered token":   joined.AccessToken + "x",
+	} {
+		if err := svc.ValidateRoomAccess(ctx, room.RoomID, token); !errors.Is(err, interview.ErrUnauthorized) {
+			t.Errorf("%s: expected ErrUnauthorized, got %v", name, err)
+		}
+	}
+	if err := svc.ValidateRoomAccess(ctx, "other-room", joined.AccessToken); !errors.Is(err, interview.ErrUnauthorized) {
+		t.Errorf("expected the token to be bound to its room, got %v", err)
+	}
+}
+
+func TestInterviewService_ScheduleRoom_NormalizesCandidateEmail(t *testing.T) {
+	svc := interview.NewService(newFakeRepository(), nil, "secret", "http://localhost:3000")
+	ctx := context.Background()
+
+	resp, err := svc.ScheduleRoom(ctx, 1, true, &interview.ScheduleRoomRequest{
+		ScheduledAt:    time.Now().Add(2 * time.Hour),
+		CandidateName:  "Ada Lovelace",
+		CandidateEmail: " Ada Lovelace <ada@example.com> ",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	room, err := svc.GetRoomByID(ctx, resp.RoomID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if room.CandidateEmail != "ada@example.com" {
+		t.Errorf("expected the bare address to be stored, got %q", room.CandidateEmail)
+	}
+}
@@ -0,0 +1,92 @@
+package interview
+
+import (
+	"fmt"
+	"time"
+)
+
+// RoomState is the lifecycle state of an interview room
+type RoomState string
+
+const (
+	// StateScheduled: planned for a future slot, nobody has joined yet
+	StateScheduled RoomState = "scheduled"
+	// StateWaiting: room is open and waiting for the candidate
+	StateWaiting RoomState = "waiting"
+	// StateInProgress: the interviewer started the interview
+	StateInProgress RoomState = "in_progress"
+	// StateEnded: the interview is over (or the candidate never showed up)
+	StateEnded RoomState = "ended"
+	// StateArchived: the room was closed by its owner (soft-deleted)
+	StateArchived RoomState = "archived"
+)
+
+// roomTransitions lists the states reachable from each state
+var roomTransitions = map[RoomState][]RoomState{
+	StateScheduled:  {StateWaiting, StateEnded, StateArchived},
+	StateWaiting:    {StateInProgress, StateEnded, StateArchived},
+	StateInProgress: {StateEnded, StateArchived},
+	StateEnded:      {StateArchived},
+	StateArchived:   {},
+}
+
+// CanTransitionTo reports whether a room in state s may move to next
+func (s RoomState) CanTransitionTo(next RoomState) bool {
+	for _, allowed := range roomTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActive reports whether participants may still join and run code
+func (s RoomState) IsActive() bool {
+	return s == StateScheduled || s == StateWaiting || s == StateInProgress
+}
+
+// Transition moves the room to the next state and stamps the transition time.
+// Archiving an interview that is still in progress also ends it.
+func (r *InterviewRoom) Transition(next RoomState, at time.Time) error {
+	current := r.CurrentState()
+	if !current.CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, current, next)
+	}
+
+	switch next {
+	case StateWaiting:
+		r.OpenedAt = &at
+	case StateInProgress:
+		r.StartedAt = &at
+	case StateEnded:
+		r.EndedAt = &at
+	case StateArchived:
+		if current == StateInProgress {
+			r.EndedAt = &at
+		}
+		r.ArchivedAt = &at
+	}
+	r.State = string(next)
+	return nil
+}
+
+// CurrentState returns the room state, treating legacy rows without a state as waiting
+func (r *InterviewRoom) CurrentState() RoomState {
+	if r.State == "" {
+		return StateWaiting
+	}
+	return RoomState(r.State)
+}
+
+// IsNoShow reports whether the interview was explicitly ended without ever being started
+func (r *InterviewRoom) IsNoShow() bool {
+	return r.EndedAt != nil && r.StartedAt == nil
+}
+
+// ActualDuration returns how long the interview ran between start and end
+func (r *InterviewRoom) ActualDuration() time.Duration {
+	if r.StartedAt == nil || r.EndedAt == nil {
+		return 0
+	}
+	return r.EndedAt.Sub(*r.StartedAt)
+}
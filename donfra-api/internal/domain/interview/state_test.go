@@ -0,0 +1,173 @@
+package interview_test
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"donfra-api/internal/domain/interview"
+)
+
+func TestRoomState_Transitions(t *testing.T) {
+	cases := []struct {
+		from, to interview.RoomState
+		allowed  bool
+	}{
+		{interview.StateScheduled, interview.StateWaiting, true},
+		{interview.StateScheduled, interview.StateInProgress, false},
+		{interview.StateWaiting, interview.StateInProgress, true},
+		{interview.StateWaiting, interview.StateEnded, true},
+		{interview.StateInProgress, interview.StateEnded, true},
+		{interview.StateInProgress, interview.StateWaiting, false},
+		{interview.StateEnded, interview.StateInProgress, false},
+		{interview.StateEnded, interview.StateArchived, true},
+		{interview.StateArchived, interview.StateWaiting, false},
+	}
+	for _, tc := range cases {
+		if got := tc.from.CanTransitionTo(tc.to); got != tc.allowed {
+			t.Errorf("%s -> %s: expected allowed=%v, got %v", tc.from, tc.to, tc.allowed, got)
+		}
+	}
+}
+
+func TestInterviewRoom_Transition_StampsTimes(t *testing.T) {
+	room := &interview.InterviewRoom{State: string(interview.StateWaiting)}
+	start := time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	if err := room.Transition(interview.StateInProgress, start); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := room.Transition(interview.StateEnded, start.Add(42*time.Minute)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if room.ActualDuration() != 42*time.Minute {
+		t.Errorf("expected 42m duration, got %v", room.ActualDuration())
+	}
+	if room.IsNoShow() {
+		t.Error("started interview must not be a no-show")
+	}
+
+	err := room.Transition(interview.StateInProgress, start)
+	if !errors.Is(err, interview.ErrInvalidTransition) {
+		t.Errorf("expected ErrInvalidTransition, got %v", err)
+	}
+}
+
+func TestInterviewService_EndWithoutStart_IsNoShow(t *testing.T) {
+	svc := interview.NewService(newFakeRepository(), nil, "secret", "http://localhost:3000")
+	ctx := context.Background()
+
+	created, err := svc.InitRoom(ctx, 1, true, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := svc.EndInterview(ctx, created.RoomID, 2); !errors.Is(err, interview.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for non-owner, got %v", err)
+	}
+
+	resp, err := svc.EndInterview(ctx, created.RoomID, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if resp.State != interview.StateEnded || !resp.NoShow {
+		t.Errorf("expected ended no-show, got state=%s no_show=%v", resp.State, resp.NoShow)
+	}
+
+	if _, err := svc.StartInterview(ctx, created.RoomID, 1); !errors.Is(err, interview.ErrInvalidTransition) {
+		t.Errorf("expected ErrInvalidTransition when starting an ended room, got %v", err)
+	}
+	if _, err := svc.RunCode(ctx, created.RoomID, "print(1)"); !errors.Is(err, interview.ErrRoomNotActive) {
+		t.Errorf("expected ErrRoomNotActive when running code in an ended room, got %v", err)
+	}
+}
+
+func TestInterviewService_StartScheduledRoom(t *testing.T) {
+	svc := interview.NewService(newFakeRepository(), nil, "secret", "http://localhost:3000")
+	ctx := context.Background()
+
+	scheduled, err := svc.ScheduleRoom(ctx, 1, true, &interview.ScheduleRoomRequest{
+		ScheduledAt:   time.Now().Add(time.Hour),
+		CandidateName: "Linus",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	resp, err := svc.StartInterview(ctx, scheduled.RoomID, 1)
+	if err != nil {
+		t.Fatalf("expected scheduled room to start, got %v", err)
+	}
+	if resp.State != interview.StateInProgress || resp.StartedAt == nil {
+		t.Errorf("expected in_progress with start time, got %+v", resp)
+	}
+}
+
+func TestInterviewService_RunCode_StorableOutput(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not available")
+	}
+	svc := interview.NewService(newFakeRepository(), nil, "secret", "http://localhost:3000")
+	ctx := context.Background()
+
+	created, err := svc.InitRoom(ctx, 1, true, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := svc.RunCode(ctx, created.RoomID, strings.Repeat("#", interview.MaxRunCodeLength+1)); !errors.Is(err, interview.ErrCodeTooLong) {
+		t.Errorf("expected ErrCodeTooLong, got %v", err)
+	}
+
+	// NUL bytes and a multi-byte character cut by the output cap would be rejected by Postgres
+	result, err := svc.RunCode(ctx, created.RoomID, "import sys\nsys.stdout.write('\\x00' + 'x' + '\\u00e9' * 40000)\n")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !utf8.ValidString(result.Stdout) || strings.ContainsRune(result.Stdout, 0) {
+		t.Errorf("expected valid UTF-8 without NUL bytes, got %q", result.Stdout[:16])
+	}
+	if !strings.HasSuffix(result.Stdout, "(truncated)") {
+		t.Errorf("expected output to be capped, got %d bytes", len(result.Stdout))
+	}
+}
+
+// racingRepository commits a state change of another request right after each room is read
+type racingRepository struct {
+	*fakeRepository
+	concurrent interview.RoomState
+}
+
+func (r *racingRepository) GetByRoomID(ctx context.Context, roomID string) (*interview.InterviewRoom, error) {
+	room, err := r.fakeRepository.GetByRoomID(ctx, roomID)
+	if err == nil && r.concurrent != "" {
+		r.rooms[roomID].State = string(r.concurrent)
+		r.concurrent = ""
+	}
+	return room, err
+}
+
+func TestInterviewService_ConcurrentTransition(t *testing.T) {
+	repo := &racingRepository{fakeRepository: newFakeRepository()}
+	svc := interview.NewService(repo, nil, "secret", "http://localhost:3000")
+	ctx := context.Background()
+
+	created, err := svc.InitRoom(ctx, 1, true, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// The room is ended while the start request still sees it waiting
+	repo.concurrent = interview.StateEnded
+	if _, err := svc.StartInterview(ctx, created.RoomID, 1); !errors.Is(err, interview.ErrInvalidTransition) {
+		t.Errorf("expected ErrInvalidTransition, got %v", err)
+	}
+	if state := repo.rooms[created.RoomID].CurrentState(); state != interview.StateEnded {
+		t.Errorf("expected the concurrent end to be kept, got %s", state)
+	}
+}
@@ -0,0 +1,87 @@
+package problem
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Difficulty levels accepted for a problem
+const (
+	DifficultyEasy   = "easy"
+	DifficultyMedium = "medium"
+	DifficultyHard   = "hard"
+)
+
+// TestCase is a single hidden test case used to judge a submission
+type TestCase struct {
+	Input          string `json:"input"`
+	ExpectedOutput string `json:"expected_output"`
+}
+
+// Problem represents an interview question in the question bank
+type Problem struct {
+	ID          uint                                  `gorm:"primaryKey" json:"id"`
+	Title       string                                `gorm:"not null" json:"title"`
+	Statement   string                                `gorm:"type:text;not null" json:"statement"`
+	Difficulty  string                                `gorm:"size:20;not null;default:'medium'" json:"difficulty"`
+	Tags        datatypes.JSONSlice[string]           `gorm:"type:jsonb;not null" json:"tags"`
+	StarterCode datatypes.JSONType[map[string]string] `gorm:"type:jsonb;not null" json:"starter_code"`
+	TestCases   datatypes.JSONSlice[TestCase]         `gorm:"type:jsonb;not null" json:"test_cases"`
+	CreatedAt   time.Time                             `json:"created_at"`
+	UpdatedAt   time.Time                             `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Problem) TableName() string {
+	return "problems"
+}
+
+// ProblemPublic is the candidate-facing view of a problem (hidden test cases removed)
+type ProblemPublic struct {
+	ID          uint              `json:"id"`
+	Title       string            `json:"title"`
+	Statement   string            `json:"statement"`
+	Difficulty  string            `json:"difficulty"`
+	Tags        []string          `json:"tags"`
+	StarterCode map[string]string `json:"starter_code"`
+}
+
+// ToPublic converts a Problem to ProblemPublic (safe to serve inside a room)
+func (p *Problem) ToPublic() *ProblemPublic {
+	return &ProblemPublic{
+		ID:          p.ID,
+		Title:       p.Title,
+		Statement:   p.Statement,
+		Difficulty:  p.Difficulty,
+		Tags:        p.Tags,
+		StarterCode: p.StarterCode.Data(),
+	}
+}
+
+// CreateProblemRequest is the request payload for POST /api/problems
+type CreateProblemRequest struct {
+	Title       string            `json:"title"`
+	Statement   string            `json:"statement"`
+	Difficulty  string            `json:"difficulty"`
+	Tags        []string          `json:"tags"`
+	StarterCode map[string]string `json:"starter_code"`
+	TestCases   []TestCase        `json:"test_cases"`
+}
+
+// UpdateProblemRequest is the request payload for PATCH /api/problems/{id}
+// Nil fields are left unchanged
+type UpdateProblemRequest struct {
+	Title       *string            `json:"title"`
+	Statement   *string            `json:"statement"`
+	Difficulty  *string            `json:"difficulty"`
+	Tags        *[]string          `json:"tags"`
+	StarterCode *map[string]string `json:"starter_code"`
+	TestCases   *[]TestCase        `json:"test_cases"`
+}
+
+// ListProblemsFilter narrows down the problems returned by List
+type ListProblemsFilter struct {
+	Difficulty string
+	Tag        string
+}
@@ -0,0 +1,79 @@
+package problem
+
+import (
+	"context"
+	"encoding/json"
+
+	"gorm.io/gorm"
+)
+
+// Repository defines the interface for problem data access
+type Repository interface {
+	Create(ctx context.Context, p *Problem) error
+	GetByID(ctx context.Context, id uint) (*Problem, error)
+	List(ctx context.Context, filter ListProblemsFilter) ([]Problem, error)
+	Update(ctx context.Context, p *Problem) error
+	Delete(ctx context.Context, id uint) error
+}
+
+// repository implements Repository interface using GORM
+type repository struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a new problem repository
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// Create inserts a new problem
+func (r *repository) Create(ctx context.Context, p *Problem) error {
+	return r.db.WithContext(ctx).Create(p).Error
+}
+
+// GetByID retrieves a problem by its primary key
+func (r *repository) GetByID(ctx context.Context, id uint) (*Problem, error) {
+	var p Problem
+	if err := r.db.WithContext(ctx).First(&p, id).Error; err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// List returns problems matching the filter, newest first
+func (r *repository) List(ctx context.Context, filter ListProblemsFilter) ([]Problem, error) {
+	q := r.db.WithContext(ctx).Model(&Problem{})
+	if filter.Difficulty != "" {
+		q = q.Where("difficulty = ?", filter.Difficulty)
+	}
+	if filter.Tag != "" {
+		tag, err := json.Marshal([]string{filter.Tag})
+		if err != nil {
+			return nil, err
+		}
+		q = q.Where("tags @> ?", string(tag))
+	}
+
+	var problems []Problem
+	if err := q.Order("created_at DESC").Find(&problems).Error; err != nil {
+		return nil, err
+	}
+	return problems, nil
+}
+
+// Update saves all fields of an existing problem
+func (r *repository) Update(ctx context.Context, p *Problem) error {
+	return r.db.WithContext(ctx).Save(p).Error
+}
+
+// Delete removes a problem by ID
+func (r *repository) Delete(ctx context.Context, id uint) error {
+	res := r.db.WithContext(ctx).Delete(&Problem{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package problem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrProblemNotFound   = errors.New("problem not found")
+	ErrTitleRequired     = errors.New("title is required")
+	ErrStatementRequired = errors.New("statement is required")
+	ErrInvalidDifficulty = errors.New("difficulty must be one of easy, medium, hard")
+)
+
+// Service defines the interface for question bank business logic
+type Service interface {
+	CreateProblem(ctx context.Context, req *CreateProblemRequest) (*Problem, error)
+	GetProblem(ctx context.Context, id uint) (*Problem, error)
+	ListProblems(ctx context.Context, filter ListProblemsFilter) ([]Problem, error)
+	UpdateProblem(ctx context.Context, id uint, req *UpdateProblemRequest) (*Problem, error)
+	DeleteProblem(ctx context.Context, id uint) error
+}
+
+// service implements Service interface
+type service struct {
+	repo Repository
+}
+
+// NewService creates a new problem service
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+// CreateProblem validates and stores a new problem
+func (s *service) CreateProblem(ctx context.Context, req *CreateProblemRequest) (*Problem, error) {
+	p := &Problem{
+		Title:       strings.TrimSpace(req.Title),
+		Statement:   req.Statement,
+		Difficulty:  normalizeDifficulty(req.Difficulty),
+		Tags:        datatypes.NewJSONSlice(normalizeTags(req.Tags)),
+		StarterCode: datatypes.NewJSONType(nonNilMap(req.StarterCode)),
+		TestCases:   datatypes.NewJSONSlice(nonNilTestCases(req.TestCases)),
+	}
+	if err := validate(p); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, p); err != nil {
+		return nil, fmt.Errorf("failed to create problem: %w", err)
+	}
+	return p, nil
+}
+
+// GetProblem retrieves a problem by ID
+func (s *service) GetProblem(ctx context.Context, id uint) (*Problem, error) {
+	p, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProblemNotFound
+		}
+		return nil, fmt.Errorf("failed to get problem: %w", err)
+	}
+	return p, nil
+}
+
+// ListProblems returns problems matching the filter
+func (s *service) ListProblems(ctx context.Context, filter ListProblemsFilter) ([]Problem, error) {
+	filter.Difficulty = strings.ToLower(strings.TrimSpace(filter.Difficulty))
+	filter.Tag = strings.ToLower(strings.TrimSpace(filter.Tag))
+	return s.repo.List(ctx, filter)
+}
+
+// UpdateProblem applies the non-nil fields of req to an existing problem
+func (s *service) UpdateProblem(ctx context.Context, id uint, req *UpdateProblemRequest) (*Problem, error) {
+	p, err := s.GetProblem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		p.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.Statement != nil {
+		p.Statement = *req.Statement
+	}
+	if req.Difficulty != nil {
+		p.Difficulty = normalizeDifficulty(*req.Difficulty)
+	}
+	if req.Tags != nil {
+		p.Tags = datatypes.NewJSONSlice(normalizeTags(*req.Tags))
+	}
+	if req.StarterCode != nil {
+		p.StarterCode = datatypes.NewJSONType(nonNilMap(*req.StarterCode))
+	}
+	if req.TestCases != nil {
+		p.TestCases = datatypes.NewJSONSlice(nonNilTestCases(*req.TestCases))
+	}
+	if err := validate(p); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, p); err != nil {
+		return nil, fmt.Errorf("failed to update problem: %w", err)
+	}
+	return p, nil
+}
+
+// DeleteProblem removes a problem from the question bank
+func (s *service) DeleteProblem(ctx context.Context, id uint) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrProblemNotFound
+		}
+		return fmt.Errorf("failed to delete problem: %w", err)
+	}
+	return nil
+}
+
+// validate checks required fields and the difficulty level
+func validate(p *Problem) error {
+	if p.Title == "" {
+		return ErrTitleRequired
+	}
+	if strings.TrimSpace(p.Statement) == "" {
+		return ErrStatementRequired
+	}
+	switch p.Difficulty {
+	case DifficultyEasy, DifficultyMedium, DifficultyHard:
+		return nil
+	default:
+		return ErrInvalidDifficulty
+	}
+}
+
+// normalizeDifficulty lowercases the difficulty and defaults it to medium
+func normalizeDifficulty(d string) string {
+	d = strings.ToLower(strings.TrimSpace(d))
+	if d == "" {
+		return DifficultyMedium
+	}
+	return d
+}
+
+// normalizeTags lowercases, trims and de-duplicates tags
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	out := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	return out
+}
+
+func nonNilMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
+
+func nonNilTestCases(tc []TestCase) []TestCase {
+	if tc == nil {
+		return []TestCase{}
+	}
+	return tc
+}
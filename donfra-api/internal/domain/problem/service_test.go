@@ -0,0 +1,149 @@
+package problem_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"donfra-api/internal/domain/problem"
+)
+
+// fakeRepository is an in-memory problem.Repository for tests
+type fakeRepository struct {
+	problems map[uint]*problem.Problem
+	nextID   uint
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{problems: map[uint]*problem.Problem{}, nextID: 1}
+}
+
+func (f *fakeRepository) Create(ctx context.Context, p *problem.Problem) error {
+	p.ID = f.nextID
+	f.nextID++
+	f.problems[p.ID] = p
+	return nil
+}
+
+func (f *fakeRepository) GetByID(ctx context.Context, id uint) (*problem.Problem, error) {
+	p, ok := f.problems[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return p, nil
+}
+
+func (f *fakeRepository) List(ctx context.Context, filter problem.ListProblemsFilter) ([]problem.Problem, error) {
+	var out []problem.Problem
+	for _, p := range f.problems {
+		out = append(out, *p)
+	}
+	return out, nil
+}
+
+func (f *fakeRepository) Update(ctx context.Context, p *problem.Problem) error {
+	f.problems[p.ID] = p
+	return nil
+}
+
+func (f *fakeRepository) Delete(ctx context.Context, id uint) error {
+	if _, ok := f.problems[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	delete(f.problems, id)
+	return nil
+}
+
+func TestProblemService_Create_NormalizesFields(t *testing.T) {
+	svc := problem.NewService(newFakeRepository())
+
+	p, err := svc.CreateProblem(context.Background(), &problem.CreateProblemRequest{
+		Title:     "  Two Sum ",
+		Statement: "Find two numbers that add up to target.",
+		Tags:      []string{"Array", "hash-map", "array", " "},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if p.Title != "Two Sum" {
+		t.Errorf("expected trimmed title, got %q", p.Title)
+	}
+	if p.Difficulty != problem.DifficultyMedium {
+		t.Errorf("expected default difficulty medium, got %q", p.Difficulty)
+	}
+	if len(p.Tags) != 2 || p.Tags[0] != "array" || p.Tags[1] != "hash-map" {
+		t.Errorf("expected normalized tags [array hash-map], got %v", p.Tags)
+	}
+}
+
+func TestProblemService_Create_Validation(t *testing.T) {
+	svc := problem.NewService(newFakeRepository())
+	ctx := context.Background()
+
+	cases := []struct {
+		name string
+		req  problem.CreateProblemRequest
+		want error
+	}{
+		{"missing title", problem.CreateProblemRequest{Statement: "s"}, problem.ErrTitleRequired},
+		{"missing statement", problem.CreateProblemRequest{Title: "t"}, problem.ErrStatementRequired},
+		{"bad difficulty", problem.CreateProblemRequest{Title: "t", Statement: "s", Difficulty: "insane"}, problem.ErrInvalidDifficulty},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := svc.CreateProblem(ctx, &tc.req)
+			if !errors.Is(err, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestProblemService_UpdateAndDelete_NotFound(t *testing.T) {
+	svc := problem.NewService(newFakeRepository())
+	ctx := context.Background()
+
+	title := "New title"
+	if _, err := svc.UpdateProblem(ctx, 42, &problem.UpdateProblemRequest{Title: &title}); !errors.Is(err, problem.ErrProblemNotFound) {
+		t.Errorf("expected ErrProblemNotFound on update, got %v", err)
+	}
+	if err := svc.DeleteProblem(ctx, 42); !errors.Is(err, problem.ErrProblemNotFound) {
+		t.Errorf("expected ErrProblemNotFound on delete, got %v", err)
+	}
+}
+
+func TestProblem_ToPublic_HidesTestCases(t *testing.T) {
+	svc := problem.NewService(newFakeRepository())
+
+	p, err := svc.CreateProblem(context.Background(), &problem.CreateProblemRequest{
+		Title:       "FizzBuzz",
+		Statement:   "Print fizz buzz.",
+		Difficulty:  "Easy",
+		StarterCode: map[string]string{"python": "def fizzbuzz(n):\n    pass\n"},
+		TestCases:   []problem.TestCase{{Input: "3", ExpectedOutput: "Fizz"}},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	pub := p.ToPublic()
+	if pub.StarterCode["python"] == "" {
+		t.Error("expected starter code to be served")
+	}
+	if pub.Difficulty != problem.DifficultyEasy {
+		t.Errorf("expected difficulty easy, got %q", pub.Difficulty)
+	}
+
+	body, err := json.Marshal(pub)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if strings.Contains(string(body), "test_cases") || strings.Contains(string(body), "Fizz\"") {
+		t.Errorf("expected test cases to be hidden, got %s", body)
+	}
+}
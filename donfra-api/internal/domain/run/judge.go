@@ -0,0 +1,163 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// maxJudgeOutput caps the stdout/stderr kept per test case.
+	maxJudgeOutput = 8 << 10
+	// truncatedMarker ends output that was cut short.
+	truncatedMarker = "\n... (truncated)"
+	// MaxConcurrentJudges bounds how many programs are judged or run at the same time.
+	MaxConcurrentJudges = 4
+)
+
+// ErrJudgeBusy is returned when MaxConcurrentJudges programs are already being judged or run.
+var ErrJudgeBusy = errors.New("too many submissions are being judged, try again shortly")
+
+// judgeSlots holds one token per running Judge call or AcquireJudgeSlot holder
+var judgeSlots = make(chan struct{}, MaxConcurrentJudges)
+
+// AcquireJudgeSlot takes one of the MaxConcurrentJudges slots for running a user program
+// outside Judge, returning ErrJudgeBusy when all are taken. Call release when done.
+func AcquireJudgeSlot() (release func(), err error) {
+	select {
+	case judgeSlots <- struct{}{}:
+		return func() { <-judgeSlots }, nil
+	default:
+		return nil, ErrJudgeBusy
+	}
+}
+
+// TestCase is a stdin/stdout check: the program reads Input and must print ExpectedOutput.
+type TestCase struct {
+	Input          string
+	ExpectedOutput string
+}
+
+// CaseResult is the outcome of running the program against one test case.
+type CaseResult struct {
+	Passed     bool
+	TimedOut   bool
+	Stdout     string
+	Stderr     string
+	DurationMs int64
+}
+
+// JudgeResult is the outcome of judging a program against all its test cases.
+type JudgeResult struct {
+	Passed int
+	Total  int
+	Cases  []CaseResult
+}
+
+// AllPassed reports whether every test case passed.
+func (r JudgeResult) AllPassed() bool {
+	return r.Total > 0 && r.Passed == r.Total
+}
+
+// RunPythonWithInput executes Python code with input on stdin. The program is written to
+// a fresh temporary directory, which is also its working and TMPDIR directory, so files
+// left behind by one run are not visible to the next. The directory is removed afterwards.
+// The program runs in its own process group, which is killed as a whole when ctx is done,
+// and at most maxOutput bytes of stdout and of stderr are kept.
+func RunPythonWithInput(ctx context.Context, code, input string, maxOutput int) ExecutionResult {
+	dir, err := os.MkdirTemp("", "donfra-judge-*")
+	if err != nil {
+		return ExecutionResult{Error: err}
+	}
+	defer os.RemoveAll(dir)
+	script := filepath.Join(dir, "main.py")
+	if err := os.WriteFile(script, []byte(code), 0o600); err != nil {
+		return ExecutionResult{Error: err}
+	}
+
+	cmd := exec.CommandContext(ctx, "python3", "-I", "-u", script)
+	cmd.Dir = dir
+	cmd.Env = programEnv(dir)
+	isolateProcess(cmd)
+	outBuf := &limitedBuffer{limit: maxOutput}
+	errBuf := &limitedBuffer{limit: maxOutput}
+	cmd.Stdout, cmd.Stderr = outBuf, errBuf
+	cmd.Stdin = strings.NewReader(input)
+	err = cmd.Run()
+	killProcessGroup(cmd)
+	return ExecutionResult{
+		Stdout: outBuf.String(),
+		Stderr: errBuf.String(),
+		Error:  err,
+	}
+}
+
+// Judge runs code against each test case in turn, giving every case its own timeout and
+// the whole run a total budget; cases left when the budget is spent count as timed out.
+// It returns ErrJudgeBusy without running anything when MaxConcurrentJudges runs are
+// already in progress.
+func Judge(ctx context.Context, code string, tests []TestCase, perCase, total time.Duration) (JudgeResult, error) {
+	release, err := AcquireJudgeSlot()
+	if err != nil {
+		return JudgeResult{}, err
+	}
+	defer release()
+
+	ctx, cancelAll := context.WithTimeout(ctx, total)
+	defer cancelAll()
+
+	result := JudgeResult{Total: len(tests), Cases: make([]CaseResult, 0, len(tests))}
+	for _, tc := range tests {
+		if ctx.Err() != nil {
+			result.Cases = append(result.Cases, CaseResult{TimedOut: true, Stderr: "Execution timed out"})
+			continue
+		}
+		caseCtx, cancel := context.WithTimeout(ctx, perCase)
+		started := time.Now()
+		// Room for the expected output plus what is shown, so long outputs are still judged
+		out := RunPythonWithInput(caseCtx, code, tc.Input, len(tc.ExpectedOutput)+maxJudgeOutput)
+		timedOut := errors.Is(caseCtx.Err(), context.DeadlineExceeded)
+		cancel()
+
+		cr := CaseResult{
+			TimedOut:   timedOut,
+			Stdout:     truncate(out.Stdout),
+			Stderr:     truncate(out.Stderr),
+			DurationMs: time.Since(started).Milliseconds(),
+		}
+		if timedOut {
+			cr.Stderr = "Execution timed out"
+		}
+		cr.Passed = out.Error == nil && !timedOut && OutputMatches(out.Stdout, tc.ExpectedOutput)
+		if cr.Passed {
+			result.Passed++
+		}
+		result.Cases = append(result.Cases, cr)
+	}
+	return result, nil
+}
+
+// OutputMatches compares program output with the expected output, ignoring line-ending
+// style, trailing whitespace on each line and trailing blank lines.
+func OutputMatches(got, want string) bool {
+	return normalizeOutput(got) == normalizeOutput(want)
+}
+
+func normalizeOutput(s string) string {
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+func truncate(s string) string {
+	if len(s) <= maxJudgeOutput {
+		return s
+	}
+	return s[:runeBoundary(s, maxJudgeOutput)] + truncatedMarker
+}
@@ -0,0 +1,221 @@
+package run_test
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"donfra-api/internal/domain/run"
+)
+
+func TestOutputMatches(t *testing.T) {
+	cases := []struct {
+		got, want string
+		match     bool
+	}{
+		{"3\n", "3", true},
+		{"a  \r\nb\n\n", "a\nb", true},
+		{"3\n4\n", "3 4", false},
+		{"", "", true},
+		{"  3", "3", false},
+	}
+	for _, tc := range cases {
+		if got := run.OutputMatches(tc.got, tc.want); got != tc.match {
+			t.Errorf("OutputMatches(%q, %q) = %v, want %v", tc.got, tc.want, got, tc.match)
+		}
+	}
+}
+
+func TestJudge(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not available")
+	}
+
+	code := "a, b = map(int, input().split())\nprint(a + b)\n"
+	tests := []run.TestCase{
+		{Input: "1 2\n", ExpectedOutput: "3\n"},
+		{Input: "10 -4\n", ExpectedOutput: "6"},
+		{Input: "2 2\n", ExpectedOutput: "5"},
+	}
+
+	result, err := run.Judge(context.Background(), code, tests, 5*time.Second, 20*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Total != 3 || result.Passed != 2 || result.AllPassed() {
+		t.Fatalf("expected 2 of 3 passed, got %+v", result)
+	}
+	if !result.Cases[0].Passed || result.Cases[2].Passed {
+		t.Errorf("unexpected case results %+v", result.Cases)
+	}
+
+	slow, _ := run.Judge(context.Background(), "while True:\n    pass\n", tests[:1], 200*time.Millisecond, time.Second)
+	if !slow.Cases[0].TimedOut || slow.Cases[0].Passed {
+		t.Errorf("expected a timeout, got %+v", slow.Cases[0])
+	}
+}
+
+func TestJudge_TotalBudget(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not available")
+	}
+
+	tests := make([]run.TestCase, 10)
+	started := time.Now()
+	result, err := run.Judge(context.Background(), "while True:\n    pass\n", tests, time.Second, 300*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(started); elapsed > 2*time.Second {
+		t.Errorf("expected the total budget to stop judging, took %s", elapsed)
+	}
+	if len(result.Cases) != len(tests) {
+		t.Fatalf("expected %d case results, got %d", len(tests), len(result.Cases))
+	}
+	for i, c := range result.Cases {
+		if !c.TimedOut || c.Passed {
+			t.Errorf("case %d: expected a timeout, got %+v", i, c)
+		}
+	}
+}
+
+func TestJudge_KillsForkedChildren(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not available")
+	}
+
+	// The child inherits the output pipes and outlives its parent
+	code := "import os, time\nif os.fork() == 0:\n    time.sleep(30)\nelse:\n    time.sleep(30)\n"
+	started := time.Now()
+	result, err := run.Judge(context.Background(), code, make([]run.TestCase, 1), 500*time.Millisecond, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(started); elapsed > 3*time.Second {
+		t.Errorf("expected the deadline to stop forked children, took %s", elapsed)
+	}
+	if !result.Cases[0].TimedOut {
+		t.Errorf("expected a timeout, got %+v", result.Cases[0])
+	}
+}
+
+func TestJudge_CapsOutput(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not available")
+	}
+
+	code := "import sys\nwhile True:\n    sys.stdout.write('x' * 65536)\n"
+	result, err := run.Judge(context.Background(), code, make([]run.TestCase, 1), 500*time.Millisecond, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := result.Cases[0]
+	if !c.TimedOut || c.Passed {
+		t.Errorf("expected a timeout, got passed=%v timedOut=%v", c.Passed, c.TimedOut)
+	}
+	if len(c.Stdout) > 9<<10 || !strings.HasSuffix(c.Stdout, "(truncated)") {
+		t.Errorf("expected output capped and marked, got %d bytes", len(c.Stdout))
+	}
+
+	// Output longer than the shown limit is still judged in full
+	long := strings.Repeat("y", 20<<10)
+	result, err = run.Judge(context.Background(), "print('"+long+"')\n", []run.TestCase{{ExpectedOutput: long}}, 5*time.Second, 20*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.AllPassed() {
+		t.Errorf("expected long output to match, got %d bytes", len(result.Cases[0].Stdout))
+	}
+}
+
+func TestJudge_CasesDoNotShareFiles(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not available")
+	}
+
+	// Each case writes its input to a file and prints what an earlier case left behind
+	code := "import os, tempfile\n" +
+		"p = os.path.join(tempfile.gettempdir(), 'seen.txt')\n" +
+		"print(open(p).read() if os.path.exists(p) else 'none')\n" +
+		"open(p, 'w').write(input())\n" +
+		"open('cwd.txt', 'w').write('x')\n"
+	tests := []run.TestCase{
+		{Input: "secret\n", ExpectedOutput: "none"},
+		{Input: "other\n", ExpectedOutput: "none"},
+	}
+	result, err := run.Judge(context.Background(), code, tests, 5*time.Second, 20*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.AllPassed() {
+		t.Errorf("expected no files to leak between cases, got %+v", result.Cases)
+	}
+}
+
+func TestJudge_HidesServerEnvironment(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not available")
+	}
+	t.Setenv("JWT_SECRET", "judge-test-secret")
+
+	code := "import os\nprint(os.environ.get('JWT_SECRET', 'none'))\n"
+	result, err := run.Judge(context.Background(), code, []run.TestCase{{ExpectedOutput: "none"}}, 5*time.Second, 20*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.AllPassed() || strings.Contains(result.Cases[0].Stdout, "judge-test-secret") {
+		t.Errorf("expected the secret to be hidden, got %+v", result.Cases[0])
+	}
+
+	if out := run.RunPython(context.Background(), code); strings.Contains(out.Stdout, "judge-test-secret") {
+		t.Errorf("expected the secret to be hidden from RunPython, got %q", out.Stdout)
+	}
+}
+
+func TestJudge_Busy(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not available")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for i := 0; i < run.MaxConcurrentJudges; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			run.Judge(ctx, "while True:\n    pass\n", make([]run.TestCase, 1), 10*time.Second, 10*time.Second)
+		}()
+	}
+	defer wg.Wait()
+	defer cancel()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := run.Judge(context.Background(), "", nil, time.Second, time.Second); errors.Is(err, run.ErrJudgeBusy) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("expected ErrJudgeBusy while all judge slots are taken")
+}
+
+func TestRunPython_CapsOutputOnRuneBoundary(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not available")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	out := run.RunPython(ctx, "import sys\nwhile True:\n    sys.stdout.write('x' + 'é' * 40000)\n")
+	if len(out.Stdout) > 65<<10 || !strings.HasSuffix(out.Stdout, "(truncated)") {
+		t.Errorf("expected output capped and marked, got %d bytes", len(out.Stdout))
+	}
+	if !utf8.ValidString(out.Stdout) {
+		t.Error("expected output cut on a character boundary")
+	}
+}
@@ -3,18 +3,65 @@ package run
 import (
 	"bytes"
 	"context"
+	"os"
 	"os/exec"
+	"syscall"
+	"time"
+	"unicode/utf8"
 )
 
+const (
+	// maxRunOutput caps what RunPython keeps of each of stdout and stderr.
+	maxRunOutput = 64 << 10
+	// waitDelay bounds how long a run waits for its output pipes to close after the
+	// program exits or is killed.
+	waitDelay = time.Second
+)
+
+// programEnv is the whole environment of a user program. It is built from scratch so
+// server secrets (JWT_SECRET, database and SMTP credentials, ...) never reach it.
+func programEnv(dir string) []string {
+	return []string{
+		"PATH=" + os.Getenv("PATH"),
+		"TMPDIR=" + dir,
+		"HOME=" + dir,
+		"LANG=C.UTF-8",
+		"PYTHONIOENCODING=utf-8",
+	}
+}
+
+// isolateProcess starts cmd in its own process group and kills the whole group when
+// cmd's context is done. Children the program forks would otherwise outlive it and keep
+// the output pipes open, so Run would wait for them past the deadline.
+func isolateProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = waitDelay
+}
+
+// killProcessGroup kills children still running after the program exited; they are
+// not needed any more.
+func killProcessGroup(cmd *exec.Cmd) {
+	if cmd.Process != nil {
+		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
+
 // RunPython executes Python code in an isolated environment and returns the execution result.
 // It uses Python 3 with isolated mode (-I) and unbuffered output (-u).
 // The context can be used to set execution timeouts.
 func RunPython(ctx context.Context, code string) ExecutionResult {
 	cmd := exec.CommandContext(ctx, "python3", "-I", "-u", "-")
-	var outBuf, errBuf bytes.Buffer
-	cmd.Stdout, cmd.Stderr = &outBuf, &errBuf
+	cmd.Env = programEnv(os.TempDir())
+	isolateProcess(cmd)
+	outBuf := &limitedBuffer{limit: maxRunOutput}
+	errBuf := &limitedBuffer{limit: maxRunOutput}
+	cmd.Stdout, cmd.Stderr = outBuf, errBuf
 	cmd.Stdin = bytes.NewBufferString(code)
 	err := cmd.Run()
+	killProcessGroup(cmd)
 	return ExecutionResult{
 		Stdout: outBuf.String(),
 		Stderr: errBuf.String(),
@@ -31,3 +78,58 @@ func Execute(ctx context.Context, req ExecutionRequest) ExecutionResponse {
 		Stderr: result.Stderr,
 	}
 }
+
+// limitedBuffer keeps the first limit bytes written to it and discards the rest, so
+// a program printing in a loop cannot grow server memory. Writes never fail.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.buf.Len(); len(p) > room {
+		b.truncated = true
+		if room > 0 {
+			b.buf.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+// String returns the kept output, marked when the rest was discarded. A character the
+// limit cut in half is dropped.
+func (b *limitedBuffer) String() string {
+	if b.truncated {
+		return trimPartialRune(b.buf.String()) + truncatedMarker
+	}
+	return b.buf.String()
+}
+
+// trimPartialRune drops an incomplete UTF-8 character at the end of s
+func trimPartialRune(s string) string {
+	for i := len(s) - 1; i >= 0 && i >= len(s)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(s[i]) {
+			if !utf8.FullRuneInString(s[i:]) {
+				return s[:i]
+			}
+			break
+		}
+	}
+	return s
+}
+
+// runeBoundary returns the largest index at most n where s can be cut without splitting
+// a UTF-8 character
+func runeBoundary(s string, n int) int {
+	if n >= len(s) {
+		return len(s)
+	}
+	for i := n; i > 0 && n-i < utf8.UTFMax; i-- {
+		if utf8.RuneStart(s[i]) {
+			return i
+		}
+	}
+	return n
+}
@@ -0,0 +1,243 @@
+package study
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"donfra-api/internal/pkg/tracing"
+)
+
+var (
+	ErrAssetNotFound         = errors.New("asset not found")
+	ErrAssetEmpty            = errors.New("file is empty")
+	ErrAssetTooLarge         = errors.New("file is too large")
+	ErrUnsupportedAssetType  = errors.New("unsupported file type: allowed are PNG, JPEG, GIF, WebP and PDF")
+	ErrAssetInUse            = errors.New("asset is referenced by a lesson")
+	ErrAssetStoreUnavailable = errors.New("asset storage is not configured")
+)
+
+// MaxAssetSize is the maximum size of an uploaded asset.
+const MaxAssetSize = 10 << 20
+
+// assetExtensions maps the accepted (sniffed) content types to file extensions.
+// SVG is deliberately excluded: it can carry scripts and assets are served same-origin.
+var assetExtensions = map[string]string{
+	"image/png":       ".png",
+	"image/jpeg":      ".jpg",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+// UploadAsset validates and stores a file under its SHA-256 content hash. Uploading the
+// same bytes again returns the existing asset with created=false.
+// Caller must ensure admin authorization (e.g., via middleware).
+func (s *Service) UploadAsset(ctx context.Context, filename string, content []byte, uploadedBy *uint) (*Asset, bool, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.UploadAsset",
+		tracing.AttrDBOperation.String("INSERT"),
+		tracing.AttrDBTable.String("lesson_assets"),
+	)
+	defer span.End()
+
+	if s.blobs == nil {
+		return nil, false, ErrAssetStoreUnavailable
+	}
+	if len(content) == 0 {
+		return nil, false, ErrAssetEmpty
+	}
+	if len(content) > MaxAssetSize {
+		return nil, false, ErrAssetTooLarge
+	}
+	contentType, ext, err := DetectAssetType(content)
+	if err != nil {
+		return nil, false, err
+	}
+
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+
+	var existing Asset
+	err = s.db.WithContext(ctx).Where("hash = ?", hash).First(&existing).Error
+	if err == nil {
+		return withAssetURL(&existing), false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		tracing.RecordError(span, err)
+		return nil, false, err
+	}
+
+	asset := &Asset{
+		Hash:        hash,
+		Key:         hash + ext,
+		Filename:    sanitizeFilename(filename),
+		ContentType: contentType,
+		Size:        int64(len(content)),
+		UploadedBy:  uploadedBy,
+	}
+	// Store the bytes before the row so a listed asset always has content
+	if err := s.blobs.Put(ctx, asset.Key, bytes.NewReader(content), asset.Size, contentType); err != nil {
+		tracing.RecordError(span, err)
+		return nil, false, err
+	}
+
+	res := s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(asset)
+	if res.Error != nil {
+		tracing.RecordError(span, res.Error)
+		return nil, false, res.Error
+	}
+	if res.RowsAffected == 0 {
+		// A concurrent upload of the same file won the race
+		if err := s.db.WithContext(ctx).Where("hash = ?", hash).First(&existing).Error; err != nil {
+			return nil, false, err
+		}
+		return withAssetURL(&existing), false, nil
+	}
+	return withAssetURL(asset), true, nil
+}
+
+// ListAssets returns all assets, newest first.
+func (s *Service) ListAssets(ctx context.Context) ([]Asset, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.ListAssets",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("lesson_assets"),
+	)
+	defer span.End()
+
+	var assets []Asset
+	if err := s.db.WithContext(ctx).Order("created_at DESC, id DESC").Find(&assets).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	for i := range assets {
+		withAssetURL(&assets[i])
+	}
+	return assets, nil
+}
+
+// OpenAsset returns the asset stored under key ({hash}{ext}) and a reader for its contents.
+// The caller closes the reader.
+func (s *Service) OpenAsset(ctx context.Context, key string) (*Asset, io.ReadCloser, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.OpenAsset",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("lesson_assets"),
+	)
+	defer span.End()
+
+	if s.blobs == nil {
+		return nil, nil, ErrAssetStoreUnavailable
+	}
+
+	var asset Asset
+	if err := s.db.WithContext(ctx).Where("key = ?", key).First(&asset).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrAssetNotFound
+		}
+		tracing.RecordError(span, err)
+		return nil, nil, err
+	}
+
+	content, err := s.blobs.Get(ctx, asset.Key)
+	if errors.Is(err, ErrBlobNotFound) {
+		return nil, nil, ErrAssetNotFound
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, nil, err
+	}
+	return withAssetURL(&asset), content, nil
+}
+
+// DeleteAsset removes an asset by key or hash. Assets referenced from a lesson's Markdown or
+// drawing are kept unless force is set.
+// Caller must ensure admin authorization (e.g., via middleware).
+func (s *Service) DeleteAsset(ctx context.Context, key string, force bool) error {
+	ctx, span := tracing.StartSpan(ctx, "study.DeleteAsset",
+		tracing.AttrDBOperation.String("DELETE"),
+		tracing.AttrDBTable.String("lesson_assets"),
+	)
+	defer span.End()
+
+	if s.blobs == nil {
+		return ErrAssetStoreUnavailable
+	}
+
+	hash := strings.TrimSuffix(key, filepath.Ext(key))
+	var asset Asset
+	if err := s.db.WithContext(ctx).Where("hash = ?", hash).First(&asset).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAssetNotFound
+		}
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	if !force {
+		var refs int64
+		pattern := "%" + asset.Hash + "%"
+		if err := s.db.WithContext(ctx).Model(&Lesson{}).
+			Where("markdown LIKE ? OR excalidraw::text LIKE ?", pattern, pattern).
+			Count(&refs).Error; err != nil {
+			tracing.RecordError(span, err)
+			return err
+		}
+		if refs > 0 {
+			return ErrAssetInUse
+		}
+	}
+
+	if err := s.db.WithContext(ctx).Delete(&asset).Error; err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	if err := s.blobs.Delete(ctx, asset.Key); err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	return nil
+}
+
+// DetectAssetType sniffs the content type from the file's bytes (the client-supplied
+// type is not trusted) and returns it with the extension used in the asset key.
+func DetectAssetType(content []byte) (string, string, error) {
+	contentType := http.DetectContentType(content)
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+	ext, ok := assetExtensions[contentType]
+	if !ok {
+		return "", "", ErrUnsupportedAssetType
+	}
+	return contentType, ext, nil
+}
+
+// AssetURL returns the content-addressed URL of an asset key.
+func AssetURL(key string) string {
+	return AssetURLPrefix + key
+}
+
+func withAssetURL(a *Asset) *Asset {
+	a.URL = AssetURL(a.Key)
+	return a
+}
+
+// sanitizeFilename keeps the base name of an uploaded file for display
+func sanitizeFilename(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, `\`, "/"))
+	if name == "." || name == "/" {
+		return ""
+	}
+	if r := []rune(name); len(r) > 255 {
+		name = string(r[:255])
+	}
+	return name
+}
@@ -0,0 +1,154 @@
+package study_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"donfra-api/internal/domain/study"
+)
+
+// fakeS3 is a minimal in-memory stand-in for an S3-compatible object store
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{objects: map[string][]byte{}, types: map[string]string{}}
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") ||
+		!strings.Contains(auth, "/us-east-1/s3/aws4_request") ||
+		!strings.Contains(auth, "Signature=") ||
+		r.Header.Get("X-Amz-Date") == "" {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = body
+		f.types[r.URL.Path] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(body)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// exerciseBlobStore runs the BlobStore contract against a store
+func exerciseBlobStore(t *testing.T, store study.BlobStore) {
+	t.Helper()
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "abcdef.png", strings.NewReader("pixels"), 6, "image/png"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	rc, err := store.Get(ctx, "abcdef.png")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(got) != "pixels" {
+		t.Errorf("expected stored content, got %q", got)
+	}
+
+	if err := store.Delete(ctx, "abcdef.png"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := store.Delete(ctx, "abcdef.png"); err != nil {
+		t.Errorf("deleting a missing blob should succeed, got %v", err)
+	}
+	if _, err := store.Get(ctx, "abcdef.png"); !errors.Is(err, study.ErrBlobNotFound) {
+		t.Errorf("expected ErrBlobNotFound after delete, got %v", err)
+	}
+}
+
+func TestLocalBlobStore(t *testing.T) {
+	store, err := study.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalBlobStore: %v", err)
+	}
+	exerciseBlobStore(t, store)
+
+	if err := store.Put(context.Background(), "../escape", strings.NewReader("x"), 1, "text/plain"); err == nil {
+		t.Error("expected keys with path separators to be rejected")
+	}
+}
+
+func TestS3BlobStore(t *testing.T) {
+	fake := newFakeS3()
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	store, err := study.NewS3BlobStore(study.S3Config{
+		Endpoint:  srv.URL,
+		Bucket:    "lessons",
+		AccessKey: "AKID",
+		SecretKey: "secret",
+		Prefix:    "assets/",
+	}, srv.Client())
+	if err != nil {
+		t.Fatalf("NewS3BlobStore: %v", err)
+	}
+
+	if err := store.Put(context.Background(), "abcdef.png", strings.NewReader("pixels"), 6, "image/png"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if fake.types["/lessons/assets/abcdef.png"] != "image/png" {
+		t.Errorf("expected object stored path-style with content type, got %v", fake.types)
+	}
+	exerciseBlobStore(t, store)
+}
+
+func TestS3BlobStore_Errors(t *testing.T) {
+	srv := httptest.NewServer(newFakeS3())
+	defer srv.Close()
+
+	store, _ := study.NewS3BlobStore(study.S3Config{Endpoint: srv.URL, Bucket: "lessons", AccessKey: "WRONG"}, srv.Client())
+	err := store.Put(context.Background(), "abcdef.png", strings.NewReader("pixels"), 6, "image/png")
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("expected a 403 error, got %v", err)
+	}
+
+	if _, err := study.NewS3BlobStore(study.S3Config{Endpoint: "minio:9000", Bucket: "b"}, nil); err == nil {
+		t.Error("expected an endpoint without scheme to be rejected")
+	}
+}
+
+func TestDetectAssetType(t *testing.T) {
+	png := "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+	contentType, ext, err := study.DetectAssetType([]byte(png))
+	if err != nil || contentType != "image/png" || ext != ".png" {
+		t.Errorf("expected image/png, got %q %q %v", contentType, ext, err)
+	}
+
+	for _, content := range []string{
+		"<svg xmlns=\"http://www.w3.org/2000/svg\"><script>alert(1)</script></svg>",
+		"<html><body>hi</body></html>",
+		"plain text",
+	} {
+		if _, _, err := study.DetectAssetType([]byte(content)); !errors.Is(err, study.ErrUnsupportedAssetType) {
+			t.Errorf("expected %q to be rejected, got %v", content, err)
+		}
+	}
+}
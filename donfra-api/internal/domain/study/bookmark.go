@@ -0,0 +1,94 @@
+package study
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"donfra-api/internal/pkg/tracing"
+)
+
+// ListBookmarks returns the user's bookmarked lessons, newest first.
+// Bookmarks of lessons that were unpublished since are hidden unless includeUnpublished.
+func (s *Service) ListBookmarks(ctx context.Context, userID uint, includeUnpublished bool) ([]BookmarkEntry, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.ListBookmarks",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("lesson_bookmarks"),
+	)
+	defer span.End()
+
+	db := s.db.WithContext(ctx).
+		Table("lesson_bookmarks b").
+		Select("l.slug, l.title, b.created_at").
+		Joins("JOIN lessons l ON l.id = b.lesson_id").
+		Where("b.user_id = ?", userID)
+	if !includeUnpublished {
+		db = wherePublished(db, "l", time.Now(), true)
+	}
+
+	entries := []BookmarkEntry{}
+	if err := db.Order("b.created_at DESC").Scan(&entries).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return entries, nil
+}
+
+// AddBookmark bookmarks a lesson for the user. Bookmarking twice is a no-op.
+func (s *Service) AddBookmark(ctx context.Context, userID uint, slug string, includeUnpublished bool) error {
+	ctx, span := tracing.StartSpan(ctx, "study.AddBookmark",
+		tracing.AttrDBOperation.String("INSERT"),
+		tracing.AttrDBTable.String("lesson_bookmarks"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	lesson, err := s.getVisibleLesson(ctx, slug, includeUnpublished)
+	if err != nil {
+		return err
+	}
+
+	bookmark := &Bookmark{UserID: userID, LessonID: lesson.ID}
+	if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(bookmark).Error; err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	return nil
+}
+
+// RemoveBookmark removes the user's bookmark of a lesson.
+func (s *Service) RemoveBookmark(ctx context.Context, userID uint, slug string) error {
+	ctx, span := tracing.StartSpan(ctx, "study.RemoveBookmark",
+		tracing.AttrDBOperation.String("DELETE"),
+		tracing.AttrDBTable.String("lesson_bookmarks"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	res := s.db.WithContext(ctx).
+		Where("user_id = ? AND lesson_id IN (?)", userID,
+			s.db.Model(&Lesson{}).Select("id").Where("slug = ?", slug)).
+		Delete(&Bookmark{})
+	if res.Error != nil {
+		tracing.RecordError(span, res.Error)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// getVisibleLesson loads a lesson, treating unpublished lessons as missing unless includeUnpublished.
+func (s *Service) getVisibleLesson(ctx context.Context, slug string, includeUnpublished bool) (*Lesson, error) {
+	lesson, err := s.GetLessonBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if !includeUnpublished && !lesson.IsPublishedAt(time.Now()) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return lesson, nil
+}
@@ -0,0 +1,401 @@
+package study
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"gorm.io/datatypes"
+)
+
+// BundleFormat is the archive format of a lesson bundle.
+type BundleFormat string
+
+const (
+	BundleZip   BundleFormat = "zip"
+	BundleTarGz BundleFormat = "tar"
+)
+
+const (
+	// MaxBundleSize is the maximum size of an uploaded (compressed) bundle.
+	MaxBundleSize = 32 << 20
+	// maxBundleEntrySize caps each decompressed file to guard against archive bombs.
+	maxBundleEntrySize = 8 << 20
+	// maxBundleTotalSize caps the sum of all decompressed files.
+	maxBundleTotalSize = 64 << 20
+	// maxBundleEntries caps the number of entries read from an archive.
+	maxBundleEntries = 2000
+
+	bundleDir        = "lessons"
+	markdownExt      = ".md"
+	excalidrawExt    = ".excalidraw"
+	frontMatterFence = "---"
+)
+
+var (
+	ErrInvalidBundle       = errors.New("invalid lesson bundle")
+	ErrInvalidBundleFormat = errors.New("format must be zip or tar")
+)
+
+// BundleLesson is a lesson as stored in an import/export bundle: a Markdown file with
+// YAML front matter, optionally paired with an .excalidraw file of the same name.
+type BundleLesson struct {
+	Slug        string
+	Title       string
+	Markdown    string
+	Excalidraw  datatypes.JSON
+	IsPublished bool
+	Category    string
+	Tags        []string
+	// UpdatedAt is the lesson's last update when it was exported. On import, a lesson
+	// changed in the database after this time is reported as a conflict.
+	UpdatedAt *time.Time
+}
+
+// bundleFrontMatter is the YAML header of a lesson Markdown file
+type bundleFrontMatter struct {
+	Slug      string     `yaml:"slug"`
+	Title     string     `yaml:"title"`
+	Published bool       `yaml:"published"`
+	Category  string     `yaml:"category,omitempty"`
+	Tags      []string   `yaml:"tags,omitempty"`
+	UpdatedAt *time.Time `yaml:"updated_at,omitempty"`
+}
+
+// ParseBundleFormat validates a ?format= value, defaulting to zip.
+func ParseBundleFormat(s string) (BundleFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "zip":
+		return BundleZip, nil
+	case "tar", "tar.gz", "tgz":
+		return BundleTarGz, nil
+	default:
+		return "", ErrInvalidBundleFormat
+	}
+}
+
+// FileExtension returns the file name extension for the bundle format.
+func (f BundleFormat) FileExtension() string {
+	if f == BundleTarGz {
+		return ".tar.gz"
+	}
+	return ".zip"
+}
+
+// ContentType returns the MIME type for the bundle format.
+func (f BundleFormat) ContentType() string {
+	if f == BundleTarGz {
+		return "application/gzip"
+	}
+	return "application/zip"
+}
+
+// ToBundleLesson converts a lesson to its bundle representation.
+func (l *Lesson) ToBundleLesson() BundleLesson {
+	updatedAt := l.UpdatedAt
+	return BundleLesson{
+		Slug:        l.Slug,
+		Title:       l.Title,
+		Markdown:    l.Markdown,
+		Excalidraw:  l.Excalidraw,
+		IsPublished: l.IsPublished,
+		Category:    l.Category,
+		Tags:        l.Tags,
+		UpdatedAt:   &updatedAt,
+	}
+}
+
+// ToLesson converts a bundle entry to a new lesson owned by ownerID. An entry
+// without a drawing gets an empty scene.
+func (e BundleLesson) ToLesson(ownerID *uint) *Lesson {
+	excalidraw := e.Excalidraw
+	if len(excalidraw) == 0 {
+		excalidraw = datatypes.JSON(emptyExcalidrawScene)
+	}
+	return &Lesson{
+		Slug:        e.Slug,
+		Title:       e.Title,
+		Markdown:    e.Markdown,
+		Excalidraw:  excalidraw,
+		IsPublished: e.IsPublished,
+		Category:    e.Category,
+		Tags:        NormalizeTags(e.Tags),
+		OwnerID:     ownerID,
+	}
+}
+
+// WriteBundle writes lessons as lessons/{slug}.md and lessons/{slug}.excalidraw files.
+func WriteBundle(w io.Writer, format BundleFormat, lessons []BundleLesson) error {
+	files := make(map[string][]byte, len(lessons)*2)
+	names := make([]string, 0, len(lessons)*2)
+	for _, l := range lessons {
+		md, err := encodeLessonMarkdown(l)
+		if err != nil {
+			return fmt.Errorf("failed to encode lesson %q: %w", l.Slug, err)
+		}
+		name := path.Join(bundleDir, l.Slug+markdownExt)
+		files[name] = md
+		names = append(names, name)
+
+		if len(l.Excalidraw) > 0 && string(l.Excalidraw) != "null" {
+			var pretty bytes.Buffer
+			if err := json.Indent(&pretty, l.Excalidraw, "", "  "); err != nil {
+				return fmt.Errorf("failed to encode drawing of %q: %w", l.Slug, err)
+			}
+			name := path.Join(bundleDir, l.Slug+excalidrawExt)
+			files[name] = append(pretty.Bytes(), '\n')
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	if format == BundleTarGz {
+		return writeTarGz(w, names, files)
+	}
+	return writeZip(w, names, files)
+}
+
+func writeZip(w io.Writer, names []string, files map[string][]byte) error {
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		f, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(files[name]); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func writeTarGz(w io.Writer, names []string, files map[string][]byte) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	now := time.Now()
+	for _, name := range names {
+		hdr := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(files[name])), ModTime: now}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// encodeLessonMarkdown renders the front matter followed by the lesson body
+func encodeLessonMarkdown(l BundleLesson) ([]byte, error) {
+	header, err := yaml.Marshal(bundleFrontMatter{
+		Slug:      l.Slug,
+		Title:     l.Title,
+		Published: l.IsPublished,
+		Category:  l.Category,
+		Tags:      l.Tags,
+		UpdatedAt: l.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	b.WriteString(frontMatterFence + "\n")
+	b.Write(header)
+	b.WriteString(frontMatterFence + "\n")
+	b.WriteString(l.Markdown)
+	if !strings.HasSuffix(l.Markdown, "\n") {
+		b.WriteByte('\n')
+	}
+	return b.Bytes(), nil
+}
+
+// ReadBundle parses a zip or tar.gz bundle (detected from its content). Files other than
+// .md and .excalidraw are ignored; drawings are paired with the Markdown file of the same name.
+func ReadBundle(data []byte) ([]BundleLesson, error) {
+	var files map[string][]byte
+	var err error
+	switch {
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		files, err = readZip(data)
+	case bytes.HasPrefix(data, []byte{0x1f, 0x8b}):
+		files, err = readTarGz(data)
+	default:
+		return nil, fmt.Errorf("%w: expected a zip or tar.gz archive", ErrInvalidBundle)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	drawings := map[string][]byte{}
+	var markdownNames []string
+	for name, content := range files {
+		switch path.Ext(name) {
+		case markdownExt:
+			markdownNames = append(markdownNames, name)
+		case excalidrawExt:
+			drawings[strings.TrimSuffix(name, excalidrawExt)] = content
+		}
+	}
+	sort.Strings(markdownNames)
+
+	lessons := make([]BundleLesson, 0, len(markdownNames))
+	seen := map[string]string{}
+	for _, name := range markdownNames {
+		stem := strings.TrimSuffix(name, markdownExt)
+		lesson, err := decodeLessonMarkdown(files[name], path.Base(stem))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidBundle, name, err)
+		}
+		if prev, ok := seen[lesson.Slug]; ok {
+			return nil, fmt.Errorf("%w: slug %q is used by both %s and %s", ErrInvalidBundle, lesson.Slug, prev, name)
+		}
+		seen[lesson.Slug] = name
+
+		if drawing, ok := drawings[stem]; ok {
+			scene, err := NormalizeExcalidraw(drawing)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s%s: %v", ErrInvalidBundle, stem, excalidrawExt, err)
+			}
+			lesson.Excalidraw = scene
+		}
+		lessons = append(lessons, lesson)
+	}
+	if len(lessons) == 0 {
+		return nil, fmt.Errorf("%w: no lesson Markdown files found", ErrInvalidBundle)
+	}
+	return lessons, nil
+}
+
+// decodeLessonMarkdown splits the YAML front matter from the body. The slug defaults
+// to the file name when the front matter does not set it.
+func decodeLessonMarkdown(content []byte, fileSlug string) (BundleLesson, error) {
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+	if !strings.HasPrefix(text, frontMatterFence+"\n") {
+		return BundleLesson{}, errors.New("missing YAML front matter")
+	}
+	rest := text[len(frontMatterFence)+1:]
+	end := strings.Index(rest, "\n"+frontMatterFence+"\n")
+	if end < 0 {
+		return BundleLesson{}, errors.New("unterminated YAML front matter")
+	}
+
+	var fm bundleFrontMatter
+	if err := yaml.Unmarshal([]byte(rest[:end+1]), &fm); err != nil {
+		return BundleLesson{}, fmt.Errorf("invalid front matter: %v", err)
+	}
+
+	slug := strings.TrimSpace(fm.Slug)
+	if slug == "" {
+		slug = fileSlug
+	}
+	if err := ValidateSlug(slug); err != nil {
+		return BundleLesson{}, fmt.Errorf("%w: %q", err, slug)
+	}
+	title := strings.TrimSpace(fm.Title)
+	if title == "" {
+		return BundleLesson{}, errors.New("title is required")
+	}
+
+	return BundleLesson{
+		Slug:        slug,
+		Title:       title,
+		Markdown:    rest[end+len(frontMatterFence)+2:],
+		IsPublished: fm.Published,
+		Category:    strings.TrimSpace(fm.Category),
+		Tags:        NormalizeTags(fm.Tags),
+		UpdatedAt:   fm.UpdatedAt,
+	}, nil
+}
+
+func readZip(data []byte) (map[string][]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+	if len(zr.File) > maxBundleEntries {
+		return nil, fmt.Errorf("%w: more than %d entries", ErrInvalidBundle, maxBundleEntries)
+	}
+	files := map[string][]byte{}
+	var total int64
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidBundle, f.Name, err)
+		}
+		content, err := readBundleEntry(rc, f.Name, &total)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		files[path.Clean(f.Name)] = content
+	}
+	return files, nil
+}
+
+func readTarGz(data []byte) (map[string][]byte, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+	defer gr.Close()
+
+	files := map[string][]byte{}
+	var total int64
+	tr := tar.NewReader(gr)
+	for entries := 0; ; entries++ {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+		}
+		if entries >= maxBundleEntries {
+			return nil, fmt.Errorf("%w: more than %d entries", ErrInvalidBundle, maxBundleEntries)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := readBundleEntry(tr, hdr.Name, &total)
+		if err != nil {
+			return nil, err
+		}
+		files[path.Clean(hdr.Name)] = content
+	}
+	return files, nil
+}
+
+// readBundleEntry reads a single archive entry, rejecting oversized files. total holds
+// the bytes decompressed so far and is checked against maxBundleTotalSize.
+func readBundleEntry(r io.Reader, name string, total *int64) ([]byte, error) {
+	limit := min(int64(maxBundleEntrySize), maxBundleTotalSize-*total)
+	content, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidBundle, name, err)
+	}
+	if len(content) > maxBundleEntrySize {
+		return nil, fmt.Errorf("%w: %s is larger than %d bytes", ErrInvalidBundle, name, maxBundleEntrySize)
+	}
+	if int64(len(content)) > limit {
+		return nil, fmt.Errorf("%w: contents are larger than %d bytes", ErrInvalidBundle, maxBundleTotalSize)
+	}
+	*total += int64(len(content))
+	return content, nil
+}
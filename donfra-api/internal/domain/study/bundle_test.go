@@ -0,0 +1,149 @@
+package study_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/datatypes"
+
+	"donfra-api/internal/domain/study"
+)
+
+func TestBundleRoundTrip(t *testing.T) {
+	updated := time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)
+	lessons := []study.BundleLesson{
+		{
+			Slug:        "intro",
+			Title:       "Intro: getting started",
+			Markdown:    "# Welcome\n\n---\n\nBody after a rule.\n",
+			Excalidraw:  datatypes.JSON(`{"type":"excalidraw","version":2,"elements":[],"appState":{},"files":{}}`),
+			IsPublished: true,
+			Category:    "basics",
+			Tags:        []string{"go", "setup"},
+			UpdatedAt:   &updated,
+		},
+		{Slug: "draft", Title: "Draft", Markdown: "wip"},
+	}
+
+	for _, format := range []study.BundleFormat{study.BundleZip, study.BundleTarGz} {
+		var buf bytes.Buffer
+		if err := study.WriteBundle(&buf, format, lessons); err != nil {
+			t.Fatalf("%s: WriteBundle: %v", format, err)
+		}
+		got, err := study.ReadBundle(buf.Bytes())
+		if err != nil {
+			t.Fatalf("%s: ReadBundle: %v", format, err)
+		}
+		if len(got) != 2 || got[0].Slug != "draft" || got[1].Slug != "intro" {
+			t.Fatalf("%s: unexpected lessons %+v", format, got)
+		}
+
+		intro := got[1]
+		if intro.Title != lessons[0].Title || intro.Markdown != lessons[0].Markdown ||
+			!intro.IsPublished || intro.Category != "basics" || len(intro.Tags) != 2 {
+			t.Errorf("%s: intro did not round-trip: %+v", format, intro)
+		}
+		if intro.UpdatedAt == nil || !intro.UpdatedAt.Equal(updated) {
+			t.Errorf("%s: expected updated_at %v, got %v", format, updated, intro.UpdatedAt)
+		}
+		if string(intro.Excalidraw) != string(lessons[0].Excalidraw) {
+			t.Errorf("%s: unexpected drawing %s", format, intro.Excalidraw)
+		}
+		if got[0].Markdown != "wip\n" || len(got[0].Excalidraw) != 0 {
+			t.Errorf("%s: unexpected draft %+v", format, got[0])
+		}
+	}
+}
+
+func TestReadBundle_Invalid(t *testing.T) {
+	zipOf := func(files map[string]string) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for name, content := range files {
+			f, _ := zw.Create(name)
+			f.Write([]byte(content))
+		}
+		zw.Close()
+		return buf.Bytes()
+	}
+
+	cases := map[string][]byte{
+		"not an archive":   []byte("hello"),
+		"no lessons":       zipOf(map[string]string{"README.txt": "hi"}),
+		"no front matter":  zipOf(map[string]string{"lessons/a.md": "# A"}),
+		"missing title":    zipOf(map[string]string{"lessons/a.md": "---\nslug: a\n---\nbody"}),
+		"duplicate slug":   zipOf(map[string]string{"a.md": "---\ntitle: A\n---\n", "b.md": "---\nslug: a\ntitle: B\n---\n"}),
+		"invalid drawing":  zipOf(map[string]string{"a.md": "---\ntitle: A\n---\n", "a.excalidraw": "{nope"}),
+		"bad front matter": zipOf(map[string]string{"a.md": "---\ntitle: [\n---\n"}),
+		"invalid slug":     zipOf(map[string]string{"a.md": "---\nslug: Intro_Graphs\ntitle: A\n---\n"}),
+		"invalid file":     zipOf(map[string]string{"Intro.md": "---\ntitle: A\n---\n"}),
+	}
+	for name, data := range cases {
+		if _, err := study.ReadBundle(data); !errors.Is(err, study.ErrInvalidBundle) {
+			t.Errorf("%s: expected ErrInvalidBundle, got %v", name, err)
+		}
+	}
+}
+
+func TestReadBundle_Limits(t *testing.T) {
+	// Many entries, each within the per-file limit
+	manyFiles := map[string]string{}
+	for i := 0; i <= 2000; i++ {
+		manyFiles[fmt.Sprintf("lessons/%d.txt", i)] = ""
+	}
+	// Entries that are each under 8 MB but together exceed the total limit
+	largeFiles := map[string]string{}
+	for i := 0; i < 9; i++ {
+		largeFiles[fmt.Sprintf("lessons/%d.txt", i)] = strings.Repeat("a", 8<<20)
+	}
+
+	for name, files := range map[string]map[string]string{"entries": manyFiles, "total size": largeFiles} {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for fname, content := range files {
+			f, _ := zw.Create(fname)
+			f.Write([]byte(content))
+		}
+		zw.Close()
+		if _, err := study.ReadBundle(buf.Bytes()); !errors.Is(err, study.ErrInvalidBundle) {
+			t.Errorf("%s: expected ErrInvalidBundle, got %v", name, err)
+		}
+	}
+}
+
+func TestBundleLessonToLesson_NoDrawing(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	f, _ := zw.Create("lessons/intro.md")
+	f.Write([]byte("---\ntitle: Intro\ntags: [Go]\n---\nbody\n"))
+	zw.Close()
+
+	entries, err := study.ReadBundle(buf.Bytes())
+	if err != nil {
+		t.Fatalf("ReadBundle: %v", err)
+	}
+	owner := uint(7)
+	lesson := entries[0].ToLesson(&owner)
+	if lesson.Slug != "intro" || lesson.OwnerID == nil || *lesson.OwnerID != owner || len(lesson.Tags) != 1 || lesson.Tags[0] != "go" {
+		t.Errorf("unexpected lesson %+v", lesson)
+	}
+
+	// The stored scene must be a complete, loadable Excalidraw document
+	normalized, err := study.NormalizeExcalidraw(lesson.Excalidraw)
+	if err != nil {
+		t.Fatalf("stored scene is invalid: %v", err)
+	}
+	if string(normalized) != string(lesson.Excalidraw) {
+		t.Errorf("stored scene is not normalized: %s", lesson.Excalidraw)
+	}
+	for _, key := range []string{`"type":"excalidraw"`, `"elements":[]`, `"appState":{}`, `"files":{}`} {
+		if !strings.Contains(string(lesson.Excalidraw), key) {
+			t.Errorf("scene %s is missing %s", lesson.Excalidraw, key)
+		}
+	}
+}
@@ -0,0 +1,351 @@
+package study
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+
+	"donfra-api/internal/pkg/tracing"
+)
+
+var (
+	ErrCommentNotFound         = errors.New("comment not found")
+	ErrCommentEmpty            = errors.New("comment body is required")
+	ErrCommentTooLong          = errors.New("comment is too long")
+	ErrCommentForbidden        = errors.New("only the author can change this comment")
+	ErrCommentRemoved          = errors.New("comment has been removed or hidden")
+	ErrThreadLocked            = errors.New("thread is locked")
+	ErrCommentRateLimited      = errors.New("too many comments, please slow down")
+	ErrInvalidModerationAction = errors.New("action must be hide, unhide, lock or unlock")
+)
+
+const (
+	// MaxCommentLength is the maximum number of characters in a comment.
+	MaxCommentLength = 5000
+	// CommentRateLimit is the number of comments a user may post per CommentRateWindow.
+	CommentRateLimit = 5
+	// CommentRateWindow is the sliding window for CommentRateLimit.
+	CommentRateWindow = time.Minute
+)
+
+// commentRateLockClass is the first key of the per-author Postgres advisory lock
+// taken while checking CommentRateLimit; the second key is the author's ID.
+// It is "cm" in ASCII.
+const commentRateLockClass = 0x636d
+
+// ListComments returns the threaded comments of a lesson, oldest thread first.
+// Hidden comments keep their place in the thread but their body is only shown to moderators;
+// removed comments without replies are dropped. Gated lessons need viewer to pass CheckLessonAccess.
+func (s *Service) ListComments(ctx context.Context, slug string, viewer LessonViewer, moderator bool) ([]*CommentNode, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.ListComments",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("lesson_comments"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	lesson, err := s.getAccessibleLesson(ctx, slug, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	var comments []Comment
+	if err := commentsQuery(s.db.WithContext(ctx)).
+		Where("lesson_comments.lesson_id = ?", lesson.ID).
+		Order("lesson_comments.created_at ASC, lesson_comments.id ASC").
+		Find(&comments).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	return BuildCommentTree(comments, moderator), nil
+}
+
+// CreateComment posts a comment or a reply on a lesson the user can access.
+func (s *Service) CreateComment(ctx context.Context, slug string, userID uint, req *CreateCommentRequest, viewer LessonViewer) (*Comment, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.CreateComment",
+		tracing.AttrDBOperation.String("INSERT"),
+		tracing.AttrDBTable.String("lesson_comments"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	body, err := validateCommentBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	lesson, err := s.getAccessibleLesson(ctx, slug, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &Comment{LessonID: lesson.ID, AuthorID: userID, Body: body}
+	if req.ParentID != nil {
+		parent, err := s.getComment(ctx, lesson.ID, *req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.RemovedAt != nil {
+			return nil, ErrCommentRemoved
+		}
+		root := parent
+		if parent.RootID != nil {
+			if root, err = s.getComment(ctx, lesson.ID, *parent.RootID); err != nil {
+				return nil, err
+			}
+		}
+		if root.IsLocked {
+			return nil, ErrThreadLocked
+		}
+		comment.ParentID = &parent.ID
+		comment.RootID = &root.ID
+	}
+
+	// Counting and inserting under a per-author lock keeps parallel posts from all
+	// passing the check. Removed comments still count: DeleteComment keeps recent
+	// ones as placeholders.
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", commentRateLockClass, int32(userID)).Error; err != nil {
+			return err
+		}
+		var recent int64
+		if err := tx.Model(&Comment{}).
+			Where("author_id = ? AND created_at > ?", userID, time.Now().Add(-CommentRateWindow)).
+			Count(&recent).Error; err != nil {
+			return err
+		}
+		if recent >= CommentRateLimit {
+			return ErrCommentRateLimited
+		}
+		return tx.Create(comment).Error
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return s.getComment(ctx, lesson.ID, comment.ID)
+}
+
+// UpdateComment edits the body of a comment (author only). Locked threads cannot be edited.
+func (s *Service) UpdateComment(ctx context.Context, slug string, commentID, userID uint, req *UpdateCommentRequest) (*Comment, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.UpdateComment",
+		tracing.AttrDBOperation.String("UPDATE"),
+		tracing.AttrDBTable.String("lesson_comments"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	body, err := validateCommentBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.getCommentBySlug(ctx, slug, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.AuthorID != userID {
+		return nil, ErrCommentForbidden
+	}
+	if comment.RemovedAt != nil || comment.IsHidden {
+		return nil, ErrCommentRemoved
+	}
+	locked, err := s.isThreadLocked(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+	if locked {
+		return nil, ErrThreadLocked
+	}
+
+	now := time.Now()
+	comment.Body = body
+	comment.EditedAt = &now
+	if err := s.db.WithContext(ctx).Model(comment).Updates(map[string]any{
+		"body":      comment.Body,
+		"edited_at": comment.EditedAt,
+	}).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return comment, nil
+}
+
+// DeleteComment removes a comment (author or moderator). Comments with replies are
+// blanked and kept as placeholders so the thread stays intact. Comments posted within
+// CommentRateWindow are blanked too, so deleting them does not reset the rate limit.
+func (s *Service) DeleteComment(ctx context.Context, slug string, commentID, userID uint, moderator bool) error {
+	ctx, span := tracing.StartSpan(ctx, "study.DeleteComment",
+		tracing.AttrDBOperation.String("DELETE"),
+		tracing.AttrDBTable.String("lesson_comments"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	comment, err := s.getCommentBySlug(ctx, slug, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.AuthorID != userID && !moderator {
+		return ErrCommentForbidden
+	}
+
+	var replies int64
+	if err := s.db.WithContext(ctx).Model(&Comment{}).Where("parent_id = ?", comment.ID).Count(&replies).Error; err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	now := time.Now()
+	if replies == 0 && now.Sub(comment.CreatedAt) >= CommentRateWindow {
+		err = s.db.WithContext(ctx).Delete(&Comment{}, comment.ID).Error
+	} else {
+		err = s.db.WithContext(ctx).Model(comment).Updates(map[string]any{
+			"body":       "",
+			"removed_at": now,
+		}).Error
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	return nil
+}
+
+// ModerateComment hides/unhides a comment or locks/unlocks its thread.
+// Lock actions on a reply apply to the root comment of its thread.
+// Caller must ensure the user is a moderator (admin or mentor).
+func (s *Service) ModerateComment(ctx context.Context, slug string, commentID uint, action ModerationAction) (*Comment, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.ModerateComment",
+		tracing.AttrDBOperation.String("UPDATE"),
+		tracing.AttrDBTable.String("lesson_comments"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	comment, err := s.getCommentBySlug(ctx, slug, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	target := comment
+	var column string
+	var value bool
+	switch action {
+	case ModerationHide, ModerationUnhide:
+		column, value = "is_hidden", action == ModerationHide
+	case ModerationLock, ModerationUnlock:
+		column, value = "is_locked", action == ModerationLock
+		if comment.RootID != nil {
+			if target, err = s.getComment(ctx, comment.LessonID, *comment.RootID); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, ErrInvalidModerationAction
+	}
+
+	if err := s.db.WithContext(ctx).Model(&Comment{}).Where("id = ?", target.ID).Update(column, value).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return s.getComment(ctx, target.LessonID, target.ID)
+}
+
+// BuildCommentTree nests comments (ordered oldest first) under their parents.
+// Bodies of hidden comments are blanked unless moderator; removed leaf comments are dropped.
+func BuildCommentTree(comments []Comment, moderator bool) []*CommentNode {
+	nodes := make(map[uint]*CommentNode, len(comments))
+	for i := range comments {
+		c := comments[i]
+		if c.IsHidden && !moderator {
+			c.Body = ""
+		}
+		nodes[c.ID] = &CommentNode{Comment: c, Replies: []*CommentNode{}}
+	}
+
+	roots := []*CommentNode{}
+	for i := range comments {
+		node := nodes[comments[i].ID]
+		if node.ParentID == nil {
+			roots = append(roots, node)
+			continue
+		}
+		if parent, ok := nodes[*node.ParentID]; ok {
+			parent.Replies = append(parent.Replies, node)
+		}
+	}
+	return pruneRemoved(roots)
+}
+
+// pruneRemoved drops removed comments whose replies have all been removed too
+func pruneRemoved(nodes []*CommentNode) []*CommentNode {
+	kept := nodes[:0]
+	for _, n := range nodes {
+		n.Replies = pruneRemoved(n.Replies)
+		if n.RemovedAt != nil && len(n.Replies) == 0 {
+			continue
+		}
+		kept = append(kept, n)
+	}
+	return kept
+}
+
+// isThreadLocked reports whether the thread containing the comment is locked
+func (s *Service) isThreadLocked(ctx context.Context, comment *Comment) (bool, error) {
+	if comment.RootID == nil {
+		return comment.IsLocked, nil
+	}
+	root, err := s.getComment(ctx, comment.LessonID, *comment.RootID)
+	if err != nil {
+		return false, err
+	}
+	return root.IsLocked, nil
+}
+
+// getComment loads a comment of a lesson together with its author name
+func (s *Service) getComment(ctx context.Context, lessonID, commentID uint) (*Comment, error) {
+	var comment Comment
+	err := commentsQuery(s.db.WithContext(ctx)).
+		Where("lesson_comments.id = ? AND lesson_comments.lesson_id = ?", commentID, lessonID).
+		First(&comment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCommentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// getCommentBySlug loads a comment that belongs to the lesson with the given slug
+func (s *Service) getCommentBySlug(ctx context.Context, slug string, commentID uint) (*Comment, error) {
+	lesson, err := s.GetLessonBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	return s.getComment(ctx, lesson.ID, commentID)
+}
+
+// commentsQuery selects comments together with their author's username
+func commentsQuery(db *gorm.DB) *gorm.DB {
+	return db.Model(&Comment{}).
+		Select("lesson_comments.*, COALESCE(users.username, '') AS author_name").
+		Joins("LEFT JOIN users ON users.id = lesson_comments.author_id")
+}
+
+func validateCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", ErrCommentEmpty
+	}
+	if utf8.RuneCountInString(body) > MaxCommentLength {
+		return "", ErrCommentTooLong
+	}
+	return body, nil
+}
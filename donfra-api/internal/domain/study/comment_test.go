@@ -0,0 +1,40 @@
+package study_test
+
+import (
+	"testing"
+	"time"
+
+	"donfra-api/internal/domain/study"
+)
+
+func uintPtr(v uint) *uint { return &v }
+
+func TestBuildCommentTree(t *testing.T) {
+	removed := time.Now()
+	comments := []study.Comment{
+		{ID: 1, Body: "root"},
+		{ID: 2, ParentID: uintPtr(1), RootID: uintPtr(1), Body: "reply", IsHidden: true},
+		{ID: 3, ParentID: uintPtr(2), RootID: uintPtr(1), Body: "nested"},
+		{ID: 4, Body: "gone", RemovedAt: &removed},
+		{ID: 5, Body: "", RemovedAt: &removed},
+		{ID: 6, ParentID: uintPtr(5), RootID: uintPtr(5), Body: "orphaned reply"},
+	}
+
+	tree := study.BuildCommentTree(comments, false)
+
+	if len(tree) != 2 || tree[0].ID != 1 || tree[1].ID != 5 {
+		t.Fatalf("expected roots 1 and 5 (removed leaf 4 dropped), got %+v", tree)
+	}
+	reply := tree[0].Replies[0]
+	if reply.ID != 2 || reply.Body != "" {
+		t.Errorf("hidden reply should keep its place with a blank body, got %+v", reply.Comment)
+	}
+	if len(reply.Replies) != 1 || reply.Replies[0].ID != 3 {
+		t.Errorf("expected nested reply 3, got %+v", reply.Replies)
+	}
+
+	modTree := study.BuildCommentTree(comments, true)
+	if modTree[0].Replies[0].Body != "reply" {
+		t.Errorf("moderators should see hidden bodies, got %q", modTree[0].Replies[0].Body)
+	}
+}
@@ -0,0 +1,210 @@
+package study
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DiffOp is the kind of change for a single line in a diff.
+type DiffOp string
+
+const (
+	DiffEqual  DiffOp = "equal"
+	DiffInsert DiffOp = "insert"
+	DiffDelete DiffOp = "delete"
+)
+
+// DiffLine is one line of a line-based diff.
+type DiffLine struct {
+	Op   DiffOp `json:"op"`
+	Text string `json:"text"`
+}
+
+// maxDiffCells bounds the LCS table (rows x columns) so large revisions cannot
+// exhaust memory; 4M int32 cells are 16 MB.
+const maxDiffCells = 4 << 20
+
+// DiffLines computes a line diff between a and b using the longest common subsequence.
+// Common prefix and suffix are trimmed first so typical edits stay cheap. If the
+// remaining changed blocks are too large to compare line by line (see maxDiffCells),
+// they are reported as deleted and re-inserted as a whole.
+func DiffLines(a, b string) []DiffLine {
+	x := splitLines(a)
+	y := splitLines(b)
+
+	prefix := 0
+	for prefix < len(x) && prefix < len(y) && x[prefix] == y[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(x)-prefix && suffix < len(y)-prefix &&
+		x[len(x)-1-suffix] == y[len(y)-1-suffix] {
+		suffix++
+	}
+
+	lines := make([]DiffLine, 0, len(x)+len(y))
+	for _, l := range x[:prefix] {
+		lines = append(lines, DiffLine{Op: DiffEqual, Text: l})
+	}
+
+	mx := x[prefix : len(x)-suffix]
+	my := y[prefix : len(y)-suffix]
+	if (len(mx)+1)*(len(my)+1) > maxDiffCells {
+		for _, l := range mx {
+			lines = append(lines, DiffLine{Op: DiffDelete, Text: l})
+		}
+		for _, l := range my {
+			lines = append(lines, DiffLine{Op: DiffInsert, Text: l})
+		}
+	} else {
+		lines = appendLCSDiff(lines, mx, my)
+	}
+
+	for _, l := range x[len(x)-suffix:] {
+		lines = append(lines, DiffLine{Op: DiffEqual, Text: l})
+	}
+	return lines
+}
+
+// appendLCSDiff appends the LCS line diff of mx and my to lines
+func appendLCSDiff(lines []DiffLine, mx, my []string) []DiffLine {
+	// lcs[i][j] is the LCS length of mx[i:] and my[j:]
+	lcs := make([][]int32, len(mx)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(my)+1)
+	}
+	for i := len(mx) - 1; i >= 0; i-- {
+		for j := len(my) - 1; j >= 0; j-- {
+			if mx[i] == my[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(mx) && j < len(my) {
+		switch {
+		case mx[i] == my[j]:
+			lines = append(lines, DiffLine{Op: DiffEqual, Text: mx[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, DiffLine{Op: DiffDelete, Text: mx[i]})
+			i++
+		default:
+			lines = append(lines, DiffLine{Op: DiffInsert, Text: my[j]})
+			j++
+		}
+	}
+	for ; i < len(mx); i++ {
+		lines = append(lines, DiffLine{Op: DiffDelete, Text: mx[i]})
+	}
+	for ; j < len(my); j++ {
+		lines = append(lines, DiffLine{Op: DiffInsert, Text: my[j]})
+	}
+	return lines
+}
+
+// UnifiedDiff renders diff lines in unified format with the given number of context lines.
+func UnifiedDiff(fromName, toName string, lines []DiffLine, context int) string {
+	changed := false
+	for _, l := range lines {
+		if l.Op != DiffEqual {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", fromName, toName)
+
+	// Line numbers (1-based) in the old and new text for each diff line
+	oldNo := make([]int, len(lines))
+	newNo := make([]int, len(lines))
+	o, n := 1, 1
+	for k, l := range lines {
+		oldNo[k], newNo[k] = o, n
+		if l.Op != DiffInsert {
+			o++
+		}
+		if l.Op != DiffDelete {
+			n++
+		}
+	}
+
+	for k := 0; k < len(lines); {
+		if lines[k].Op == DiffEqual {
+			k++
+			continue
+		}
+
+		// Grow the hunk while changes are within 2*context lines of each other
+		start := max(k-context, 0)
+		end := k
+		for end < len(lines) {
+			if lines[end].Op != DiffEqual {
+				end++
+				continue
+			}
+			run := end
+			for run < len(lines) && lines[run].Op == DiffEqual {
+				run++
+			}
+			if run == len(lines) || run-end > 2*context {
+				end = min(end+context, len(lines))
+				break
+			}
+			end = run
+		}
+
+		oldCount, newCount := 0, 0
+		for _, l := range lines[start:end] {
+			if l.Op != DiffInsert {
+				oldCount++
+			}
+			if l.Op != DiffDelete {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(oldNo[start], oldCount), hunkRange(newNo[start], newCount))
+		for _, l := range lines[start:end] {
+			switch l.Op {
+			case DiffInsert:
+				b.WriteString("+")
+			case DiffDelete:
+				b.WriteString("-")
+			default:
+				b.WriteString(" ")
+			}
+			b.WriteString(l.Text)
+			b.WriteString("\n")
+		}
+		k = end
+	}
+	return b.String()
+}
+
+// hunkRange formats a unified diff range; empty ranges point at the line before
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// splitLines splits text into lines, normalizing CRLF and ignoring a trailing newline
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,100 @@
+package study_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"donfra-api/internal/domain/study"
+)
+
+func TestDiffLines(t *testing.T) {
+	lines := study.DiffLines("a\nb\nc\n", "a\nB\nc\nd\n")
+
+	var ops []string
+	for _, l := range lines {
+		ops = append(ops, string(l.Op[0])+l.Text)
+	}
+	got := strings.Join(ops, ",")
+	want := "ea,db,iB,ec,id"
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestDiffLines_Identical(t *testing.T) {
+	for _, l := range study.DiffLines("same\ntext", "same\ntext\n") {
+		if l.Op != study.DiffEqual {
+			t.Fatalf("expected only equal lines, got %+v", l)
+		}
+	}
+	if out := study.UnifiedDiff("a", "b", study.DiffLines("x", "x"), 3); out != "" {
+		t.Errorf("expected empty unified diff, got %q", out)
+	}
+}
+
+func TestDiffLines_LargeInputs(t *testing.T) {
+	var before, after []string
+	for i := 0; i < 5000; i++ {
+		before = append(before, fmt.Sprintf("old %d", i))
+		after = append(after, fmt.Sprintf("new %d", i))
+	}
+	a := "title\n" + strings.Join(before, "\n") + "\nend"
+	b := "title\n" + strings.Join(after, "\n") + "\nend"
+
+	lines := study.DiffLines(a, b)
+	if len(lines) != 2+len(before)+len(after) {
+		t.Fatalf("expected %d lines, got %d", 2+len(before)+len(after), len(lines))
+	}
+	if lines[0].Op != study.DiffEqual || lines[1].Op != study.DiffDelete ||
+		lines[len(lines)-2].Op != study.DiffInsert || lines[len(lines)-1].Op != study.DiffEqual {
+		t.Errorf("expected the changed block to be replaced as a whole, got %+v ... %+v", lines[:2], lines[len(lines)-2:])
+	}
+}
+
+func TestUnifiedDiff_Hunks(t *testing.T) {
+	var before, after []string
+	for i := 1; i <= 20; i++ {
+		line := string(rune('a' + i - 1))
+		before = append(before, line)
+		switch i {
+		case 2:
+			after = append(after, "B")
+		case 18:
+			// dropped
+		default:
+			after = append(after, line)
+		}
+	}
+
+	out := study.UnifiedDiff("intro@1", "intro@2",
+		study.DiffLines(strings.Join(before, "\n"), strings.Join(after, "\n")), 3)
+
+	for _, want := range []string{
+		"--- intro@1\n+++ intro@2\n",
+		"@@ -1,5 +1,5 @@\n a\n-b\n+B\n c\n d\n e\n",
+		"@@ -15,6 +15,5 @@\n o\n p\n q\n-r\n s\n t\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected unified diff to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestRestoreFromRevision_KeepsPublishState(t *testing.T) {
+	lesson := &study.Lesson{Title: "Graphs v2", Markdown: "new", IsPublished: true}
+	revision := &study.LessonRevision{Title: "Graphs", Markdown: "old", IsPublished: false}
+
+	updates := study.RestoreFromRevision(lesson, revision)
+	if lesson.Title != "Graphs" || lesson.Markdown != "old" {
+		t.Errorf("expected the revision content to be restored, got %+v", lesson)
+	}
+	if !lesson.IsPublished {
+		t.Error("restoring an unpublished revision must keep a published lesson published")
+	}
+	for _, column := range []string{"is_published", "publish_at", "unpublish_at"} {
+		if _, ok := updates[column]; ok {
+			t.Errorf("expected %s not to be updated, got %v", column, updates)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package study
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/datatypes"
+)
+
+// ErrInvalidExcalidraw is returned when a lesson's Excalidraw scene is malformed or too large.
+var ErrInvalidExcalidraw = errors.New("invalid excalidraw scene")
+
+const (
+	// MaxExcalidrawSize is the maximum size of a scene in bytes, embedded files included.
+	MaxExcalidrawSize = 8 << 20
+	// MaxExcalidrawElements is the maximum number of elements of a scene.
+	MaxExcalidrawElements = 10000
+	// ExcalidrawVersion is the scene schema version written for scenes that omit it.
+	ExcalidrawVersion = 2
+)
+
+// emptyExcalidrawScene is stored for lessons created without a drawing.
+const emptyExcalidrawScene = `{"type":"excalidraw","version":2,"source":"donfra","elements":[],"appState":{},"files":{}}`
+
+// excalidrawElementTypes are the element types the Excalidraw editor can load.
+var excalidrawElementTypes = map[string]bool{
+	"rectangle": true, "diamond": true, "ellipse": true, "arrow": true, "line": true,
+	"freedraw": true, "text": true, "image": true, "frame": true, "magicframe": true,
+	"embeddable": true, "iframe": true, "selection": true,
+}
+
+// volatileAppState are editor session keys (selection, scroll, open menus, collaborators)
+// that are meaningless once saved and would make every save look like a change.
+var volatileAppState = map[string]bool{
+	"collaborators": true, "selectedElementIds": true, "selectedGroupIds": true,
+	"hoveredElementIds": true, "previousSelectedElementIds": true, "selectedLinearElement": true,
+	"editingElement": true, "editingGroupId": true, "editingLinearElement": true,
+	"editingTextElement": true, "editingFrame": true, "newElement": true,
+	"resizingElement": true, "selectionElement": true, "draggingElement": true,
+	"multiElement": true, "startBoundElement": true, "suggestedBindings": true,
+	"cursorButton": true, "scrollX": true, "scrollY": true, "zoom": true,
+	"scrolledOutside": true, "openMenu": true, "openPopup": true, "openSidebar": true,
+	"openDialog": true, "activeTool": true, "activeEmbeddable": true, "isLoading": true,
+	"isResizing": true, "isRotating": true, "errorMessage": true, "toast": true,
+	"contextMenu": true, "pasteDialog": true, "showHyperlinkPopup": true,
+	"width": true, "height": true, "offsetLeft": true, "offsetTop": true,
+	"fileHandle": true, "frameToHighlight": true, "elementsToHighlight": true,
+}
+
+// excalidrawScene is the normalized scene written to the database
+type excalidrawScene struct {
+	Type     string                     `json:"type"`
+	Version  int                        `json:"version"`
+	Source   string                     `json:"source,omitempty"`
+	Elements []json.RawMessage          `json:"elements"`
+	AppState map[string]json.RawMessage `json:"appState"`
+	Files    map[string]json.RawMessage `json:"files"`
+}
+
+// NormalizeExcalidraw validates an Excalidraw scene and returns its normalized form:
+// deleted elements, volatile appState keys and files no image references are removed.
+// An empty or null payload yields an empty scene, and a scene sent as a JSON string
+// is decoded first. Errors wrap ErrInvalidExcalidraw and say what is wrong.
+func NormalizeExcalidraw(raw []byte) (datatypes.JSON, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) > MaxExcalidrawSize {
+		return nil, fmt.Errorf("%w: scene exceeds %d bytes", ErrInvalidExcalidraw, MaxExcalidrawSize)
+	}
+	if len(raw) > 0 && raw[0] == '"' {
+		var encoded string
+		if err := json.Unmarshal(raw, &encoded); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidExcalidraw, err)
+		}
+		raw = bytes.TrimSpace([]byte(encoded))
+	}
+	if len(raw) == 0 || string(raw) == "null" {
+		return datatypes.JSON(emptyExcalidrawScene), nil
+	}
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("%w: scene must be a JSON object", ErrInvalidExcalidraw)
+	}
+
+	scene := excalidrawScene{Type: "excalidraw", Version: ExcalidrawVersion}
+	if v, ok := doc["type"]; ok {
+		if json.Unmarshal(v, &scene.Type) != nil || scene.Type != "excalidraw" {
+			return nil, fmt.Errorf(`%w: type must be "excalidraw"`, ErrInvalidExcalidraw)
+		}
+	}
+	if v, ok := doc["version"]; ok {
+		if json.Unmarshal(v, &scene.Version) != nil || scene.Version < 1 {
+			return nil, fmt.Errorf("%w: version must be a positive integer", ErrInvalidExcalidraw)
+		}
+	}
+	if v, ok := doc["source"]; ok && string(v) != "null" {
+		if json.Unmarshal(v, &scene.Source) != nil {
+			return nil, fmt.Errorf("%w: source must be a string", ErrInvalidExcalidraw)
+		}
+	}
+
+	elements, fileIDs, err := normalizeElements(doc["elements"])
+	if err != nil {
+		return nil, err
+	}
+	scene.Elements = elements
+
+	if scene.AppState, err = decodeObject(doc["appState"], "appState"); err != nil {
+		return nil, err
+	}
+	for key := range scene.AppState {
+		if volatileAppState[key] {
+			delete(scene.AppState, key)
+		}
+	}
+
+	files, err := decodeObject(doc["files"], "files")
+	if err != nil {
+		return nil, err
+	}
+	scene.Files = make(map[string]json.RawMessage, len(files))
+	for id, f := range files {
+		if !fileIDs[id] {
+			continue
+		}
+		if err := validateExcalidrawFile(id, f); err != nil {
+			return nil, err
+		}
+		scene.Files[id] = f
+	}
+
+	out, err := json.Marshal(scene)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidExcalidraw, err)
+	}
+	return datatypes.JSON(out), nil
+}
+
+// normalizeElements validates the elements array, dropping deleted elements. It returns
+// the remaining elements and the file IDs referenced by image elements.
+func normalizeElements(raw json.RawMessage) ([]json.RawMessage, map[string]bool, error) {
+	fileIDs := map[string]bool{}
+	if len(raw) == 0 || string(raw) == "null" {
+		return []json.RawMessage{}, fileIDs, nil
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, nil, fmt.Errorf("%w: elements must be an array", ErrInvalidExcalidraw)
+	}
+	if len(items) > MaxExcalidrawElements {
+		return nil, nil, fmt.Errorf("%w: scene has more than %d elements", ErrInvalidExcalidraw, MaxExcalidrawElements)
+	}
+
+	elements := make([]json.RawMessage, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for i, item := range items {
+		var el struct {
+			ID        *string  `json:"id"`
+			Type      *string  `json:"type"`
+			X         *float64 `json:"x"`
+			Y         *float64 `json:"y"`
+			IsDeleted bool     `json:"isDeleted"`
+			FileID    *string  `json:"fileId"`
+		}
+		if err := json.Unmarshal(item, &el); err != nil || !isJSONObject(item) {
+			return nil, nil, fmt.Errorf("%w: elements[%d] is not a valid element", ErrInvalidExcalidraw, i)
+		}
+		if el.IsDeleted {
+			continue
+		}
+		switch {
+		case el.ID == nil || strings.TrimSpace(*el.ID) == "":
+			return nil, nil, fmt.Errorf("%w: elements[%d] is missing an id", ErrInvalidExcalidraw, i)
+		case seen[*el.ID]:
+			return nil, nil, fmt.Errorf("%w: elements[%d] has duplicate id %q", ErrInvalidExcalidraw, i, *el.ID)
+		case el.Type == nil || !excalidrawElementTypes[*el.Type]:
+			return nil, nil, fmt.Errorf("%w: elements[%d] has an unknown type", ErrInvalidExcalidraw, i)
+		case el.X == nil || el.Y == nil:
+			return nil, nil, fmt.Errorf("%w: elements[%d] is missing x/y coordinates", ErrInvalidExcalidraw, i)
+		}
+		seen[*el.ID] = true
+		if *el.Type == "image" && el.FileID != nil {
+			fileIDs[*el.FileID] = true
+		}
+
+		var compact bytes.Buffer
+		_ = json.Compact(&compact, item)
+		elements = append(elements, compact.Bytes())
+	}
+	return elements, fileIDs, nil
+}
+
+// validateExcalidrawFile checks an embedded binary file entry
+func validateExcalidrawFile(id string, raw json.RawMessage) error {
+	var f struct {
+		MimeType string `json:"mimeType"`
+		DataURL  string `json:"dataURL"`
+	}
+	if err := json.Unmarshal(raw, &f); err != nil || !isJSONObject(raw) {
+		return fmt.Errorf("%w: files[%q] is not a valid file", ErrInvalidExcalidraw, id)
+	}
+	if f.MimeType == "" || !strings.HasPrefix(f.DataURL, "data:") {
+		return fmt.Errorf("%w: files[%q] must have a mimeType and a data: URL", ErrInvalidExcalidraw, id)
+	}
+	return nil
+}
+
+// decodeObject decodes an optional JSON object member of the scene
+func decodeObject(raw json.RawMessage, name string) (map[string]json.RawMessage, error) {
+	out := map[string]json.RawMessage{}
+	if len(raw) == 0 || string(raw) == "null" {
+		return out, nil
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("%w: %s must be an object", ErrInvalidExcalidraw, name)
+	}
+	return out, nil
+}
+
+func isJSONObject(raw json.RawMessage) bool {
+	raw = bytes.TrimSpace(raw)
+	return len(raw) > 0 && raw[0] == '{'
+}
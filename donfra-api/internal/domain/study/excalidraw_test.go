@@ -0,0 +1,89 @@
+package study_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"donfra-api/internal/domain/study"
+)
+
+func TestNormalizeExcalidraw(t *testing.T) {
+	raw := `{
+		"type": "excalidraw",
+		"version": 2,
+		"source": "https://excalidraw.com",
+		"elements": [
+			{"id": "a", "type": "rectangle", "x": 1, "y": 2},
+			{"id": "b", "type": "ellipse", "x": 0, "y": 0, "isDeleted": true},
+			{"id": "c", "type": "image", "x": 0, "y": 0, "fileId": "f1"}
+		],
+		"appState": {"viewBackgroundColor": "#fff", "scrollX": 120, "collaborators": {}, "selectedElementIds": {"a": true}},
+		"files": {
+			"f1": {"id": "f1", "mimeType": "image/png", "dataURL": "data:image/png;base64,AAAA"},
+			"f2": {"id": "f2", "mimeType": "image/png", "dataURL": "data:image/png;base64,BBBB"}
+		}
+	}`
+
+	got, err := study.NormalizeExcalidraw([]byte(raw))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var scene struct {
+		Type     string                     `json:"type"`
+		Version  int                        `json:"version"`
+		Elements []map[string]any           `json:"elements"`
+		AppState map[string]json.RawMessage `json:"appState"`
+		Files    map[string]json.RawMessage `json:"files"`
+	}
+	if err := json.Unmarshal(got, &scene); err != nil {
+		t.Fatalf("normalized scene is not valid JSON: %v", err)
+	}
+	if len(scene.Elements) != 2 || scene.Elements[0]["id"] != "a" || scene.Elements[1]["id"] != "c" {
+		t.Errorf("deleted element not stripped: %+v", scene.Elements)
+	}
+	if _, ok := scene.AppState["viewBackgroundColor"]; !ok || len(scene.AppState) != 1 {
+		t.Errorf("volatile appState not stripped: %s", got)
+	}
+	if _, ok := scene.Files["f1"]; !ok || len(scene.Files) != 1 {
+		t.Errorf("unreferenced file not stripped: %s", got)
+	}
+}
+
+func TestNormalizeExcalidraw_Defaults(t *testing.T) {
+	for _, raw := range []string{"", "null", "  ", "{}", `"{\"elements\":[]}"`} {
+		got, err := study.NormalizeExcalidraw([]byte(raw))
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", raw, err)
+		}
+		if !strings.Contains(string(got), `"type":"excalidraw"`) || !strings.Contains(string(got), `"elements":[]`) {
+			t.Errorf("%q: expected an empty scene, got %s", raw, got)
+		}
+	}
+}
+
+func TestNormalizeExcalidraw_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"not an object": `[1, 2]`,
+		"not JSON":      `{"elements": [`,
+		"wrong type":    `{"type": "drawing"}`,
+		"bad version":   `{"version": -1}`,
+		"elements":      `{"elements": {}}`,
+		"element":       `{"elements": ["x"]}`,
+		"missing id":    `{"elements": [{"type": "text", "x": 0, "y": 0}]}`,
+		"duplicate id":  `{"elements": [{"id": "a", "type": "text", "x": 0, "y": 0}, {"id": "a", "type": "line", "x": 0, "y": 0}]}`,
+		"unknown type":  `{"elements": [{"id": "a", "type": "blob", "x": 0, "y": 0}]}`,
+		"missing x":     `{"elements": [{"id": "a", "type": "text", "y": 0}]}`,
+		"appState":      `{"appState": []}`,
+		"files":         `{"files": "x"}`,
+		"file":          `{"elements": [{"id": "a", "type": "image", "x": 0, "y": 0, "fileId": "f"}], "files": {"f": {"dataURL": "http://x"}}}`,
+		"too large":     `{"source": "` + strings.Repeat("x", study.MaxExcalidrawSize) + `"}`,
+	}
+	for name, raw := range cases {
+		_, err := study.NormalizeExcalidraw([]byte(raw))
+		if !errors.Is(err, study.ErrInvalidExcalidraw) {
+			t.Errorf("%s: expected ErrInvalidExcalidraw, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package study
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"donfra-api/internal/domain/run"
+	"donfra-api/internal/pkg/tracing"
+)
+
+var (
+	ErrExerciseNotFound = errors.New("exercise not found")
+	ErrInvalidExercise  = errors.New("invalid exercise")
+	ErrEmptySubmission  = errors.New("code is required")
+	ErrSubmissionSize   = errors.New("code is too long")
+)
+
+const (
+	// MaxExercisesPerLesson is the maximum number of exercises a lesson may declare.
+	MaxExercisesPerLesson = 20
+	// MaxExerciseTests is the maximum number of test cases of an exercise.
+	MaxExerciseTests = 20
+	// MaxSubmissionLength is the maximum size of submitted code in bytes.
+	MaxSubmissionLength = 64 << 10
+	// ExerciseCaseTimeout bounds the run time of each test case.
+	ExerciseCaseTimeout = 5 * time.Second
+	// ExerciseJudgeTimeout bounds the run time of all test cases of a submission.
+	ExerciseJudgeTimeout = 20 * time.Second
+)
+
+var exerciseKeyRe = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// ValidateExercises checks and normalizes the exercises of a SetExercisesRequest.
+// Keys must be unique slugs; only Python exercises are supported by the runner.
+func ValidateExercises(inputs []ExerciseInput) ([]ExerciseInput, error) {
+	if len(inputs) > MaxExercisesPerLesson {
+		return nil, fmt.Errorf("%w: at most %d exercises per lesson", ErrInvalidExercise, MaxExercisesPerLesson)
+	}
+	seen := make(map[string]bool, len(inputs))
+	out := make([]ExerciseInput, 0, len(inputs))
+	for i, in := range inputs {
+		in.Key = strings.TrimSpace(in.Key)
+		in.Title = strings.TrimSpace(in.Title)
+		in.Language = strings.ToLower(strings.TrimSpace(in.Language))
+		if in.Language == "" {
+			in.Language = "python"
+		}
+		switch {
+		case len(in.Key) > 100 || !exerciseKeyRe.MatchString(in.Key):
+			return nil, fmt.Errorf("%w: exercise %d: key must contain only lowercase letters, digits and single hyphens", ErrInvalidExercise, i+1)
+		case seen[in.Key]:
+			return nil, fmt.Errorf("%w: duplicate key %q", ErrInvalidExercise, in.Key)
+		case in.Title == "":
+			return nil, fmt.Errorf("%w: %s: title is required", ErrInvalidExercise, in.Key)
+		case in.Language != "python":
+			return nil, fmt.Errorf("%w: %s: unsupported language %q", ErrInvalidExercise, in.Key, in.Language)
+		case len(in.Tests) == 0 || len(in.Tests) > MaxExerciseTests:
+			return nil, fmt.Errorf("%w: %s: between 1 and %d tests are required", ErrInvalidExercise, in.Key, MaxExerciseTests)
+		}
+		seen[in.Key] = true
+		out = append(out, in)
+	}
+	return out, nil
+}
+
+// NewExerciseView builds the student-facing view of an exercise. Hidden tests are
+// counted but not shown.
+func NewExerciseView(ex *Exercise, result *ExerciseResult) ExerciseView {
+	view := ExerciseView{
+		Key:         ex.Key,
+		Title:       ex.Title,
+		Prompt:      ex.Prompt,
+		Language:    ex.Language,
+		StarterCode: ex.StarterCode,
+		Examples:    []ExerciseTest{},
+		TestCount:   len(ex.Tests),
+		Result:      result,
+	}
+	for _, tc := range ex.Tests {
+		if !tc.Hidden {
+			view.Examples = append(view.Examples, tc)
+		}
+	}
+	return view
+}
+
+// ListExercises returns all exercises of a lesson including hidden tests (admin view).
+func (s *Service) ListExercises(ctx context.Context, slug string) ([]Exercise, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.ListExercises",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("lesson_exercises"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	lesson, err := s.GetLessonBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	exercises, err := listExercises(s.db.WithContext(ctx), lesson.ID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return exercises, nil
+}
+
+// SetExercises replaces the exercises of a lesson. Exercises are matched by key, so
+// students keep their results for exercises that are edited rather than removed.
+// The editor must pass CheckLessonEditable.
+func (s *Service) SetExercises(ctx context.Context, slug string, req *SetExercisesRequest, editor LessonEditor) ([]Exercise, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.SetExercises",
+		tracing.AttrDBOperation.String("UPDATE"),
+		tracing.AttrDBTable.String("lesson_exercises"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	inputs, err := ValidateExercises(req.Exercises)
+	if err != nil {
+		return nil, err
+	}
+
+	var lesson *Lesson
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if lesson, err = lockEditableLesson(tx, slug, editor); err != nil {
+			return err
+		}
+
+		keys := make([]string, 0, len(inputs))
+		for i, in := range inputs {
+			ex := &Exercise{
+				LessonID:    lesson.ID,
+				Key:         in.Key,
+				Position:    i,
+				Title:       in.Title,
+				Prompt:      in.Prompt,
+				Language:    in.Language,
+				StarterCode: in.StarterCode,
+				Tests:       in.Tests,
+			}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "lesson_id"}, {Name: "key"}},
+				DoUpdates: clause.AssignmentColumns([]string{"position", "title", "prompt", "language", "starter_code", "tests", "updated_at"}),
+			}).Create(ex).Error; err != nil {
+				return err
+			}
+			keys = append(keys, in.Key)
+		}
+
+		del := tx.Where("lesson_id = ?", lesson.ID)
+		if len(keys) > 0 {
+			del = del.Where("key NOT IN ?", keys)
+		}
+		if err := del.Delete(&Exercise{}).Error; err != nil {
+			return err
+		}
+		return touchLessons(tx, lesson.ID)
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	s.invalidateLessons(ctx, slug)
+	return listExercises(s.db.WithContext(ctx), lesson.ID)
+}
+
+// GetExerciseViews returns the student-facing exercises of a lesson. When userID is set,
+// each exercise carries the user's best result.
+func (s *Service) GetExerciseViews(ctx context.Context, lessonID uint, userID *uint) ([]ExerciseView, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.GetExerciseViews",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("lesson_exercises"),
+	)
+	defer span.End()
+
+	db := s.db.WithContext(ctx)
+	exercises, err := listExercises(db, lessonID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	results := map[uint]*ExerciseResult{}
+	if userID != nil && len(exercises) > 0 {
+		ids := make([]uint, len(exercises))
+		for i := range exercises {
+			ids[i] = exercises[i].ID
+		}
+		var rows []ExerciseResult
+		if err := db.Where("user_id = ? AND exercise_id IN ?", *userID, ids).Find(&rows).Error; err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		for i := range rows {
+			results[rows[i].ExerciseID] = &rows[i]
+		}
+	}
+
+	views := make([]ExerciseView, len(exercises))
+	for i := range exercises {
+		views[i] = NewExerciseView(&exercises[i], results[exercises[i].ID])
+	}
+	return views, nil
+}
+
+// SubmitExercise judges code against every test of an exercise and records the attempt.
+// The stored result only changes when the submission passes more tests than the best so far.
+// Gated lessons need viewer to pass CheckLessonAccess.
+func (s *Service) SubmitExercise(ctx context.Context, slug, key string, userID uint, code string, viewer LessonViewer) (*ExerciseSubmission, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.SubmitExercise",
+		tracing.AttrDBOperation.String("UPSERT"),
+		tracing.AttrDBTable.String("exercise_results"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	if strings.TrimSpace(code) == "" {
+		return nil, ErrEmptySubmission
+	}
+	if len(code) > MaxSubmissionLength {
+		return nil, ErrSubmissionSize
+	}
+
+	lesson, err := s.getAccessibleLesson(ctx, slug, viewer)
+	if err != nil {
+		return nil, err
+	}
+	var ex Exercise
+	if err := s.db.WithContext(ctx).Where("lesson_id = ? AND key = ?", lesson.ID, key).First(&ex).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExerciseNotFound
+		}
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	tests := make([]run.TestCase, len(ex.Tests))
+	for i, tc := range ex.Tests {
+		tests[i] = run.TestCase{Input: tc.Input, ExpectedOutput: tc.ExpectedOutput}
+	}
+	judged, err := run.Judge(ctx, code, tests, ExerciseCaseTimeout, ExerciseJudgeTimeout)
+	if err != nil {
+		return nil, err
+	}
+	submission := NewExerciseSubmission(ex.Tests, judged)
+
+	var best ExerciseResult
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND exercise_id = ?", userID, ex.ID).
+			First(&best).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if best.ID == 0 {
+			best = ExerciseResult{UserID: userID, ExerciseID: ex.ID}
+		}
+		best.Attempts++
+		if best.Attempts == 1 || judged.Passed > best.BestPassed || judged.Total != best.Total {
+			best.BestPassed = judged.Passed
+			best.Total = judged.Total
+			best.Passed = judged.AllPassed()
+			best.BestCode = code
+		}
+		return tx.Save(&best).Error
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	submission.Best = &best
+	return submission, nil
+}
+
+// NewExerciseSubmission converts a judge result into the response for a submission,
+// leaving out the input and output of hidden tests.
+func NewExerciseSubmission(tests []ExerciseTest, judged run.JudgeResult) *ExerciseSubmission {
+	sub := &ExerciseSubmission{
+		Passed:      judged.AllPassed(),
+		PassedCount: judged.Passed,
+		Total:       judged.Total,
+		Cases:       make([]ExerciseCaseResult, len(judged.Cases)),
+	}
+	for i, c := range judged.Cases {
+		cr := ExerciseCaseResult{
+			Passed:     c.Passed,
+			TimedOut:   c.TimedOut,
+			DurationMs: c.DurationMs,
+		}
+		if i < len(tests) && tests[i].Hidden {
+			cr.Hidden = true
+		} else {
+			if i < len(tests) {
+				cr.Input = tests[i].Input
+				cr.ExpectedOutput = tests[i].ExpectedOutput
+			}
+			cr.Stdout = c.Stdout
+			cr.Stderr = c.Stderr
+		}
+		sub.Cases[i] = cr
+	}
+	return sub
+}
+
+func listExercises(db *gorm.DB, lessonID uint) ([]Exercise, error) {
+	var exercises []Exercise
+	err := db.Where("lesson_id = ?", lessonID).Order("position ASC, id ASC").Find(&exercises).Error
+	return exercises, err
+}
@@ -0,0 +1,71 @@
+package study_test
+
+import (
+	"errors"
+	"testing"
+
+	"donfra-api/internal/domain/run"
+	"donfra-api/internal/domain/study"
+)
+
+func TestValidateExercises(t *testing.T) {
+	tests := []study.ExerciseTest{{Input: "1 2\n", ExpectedOutput: "3\n"}}
+
+	got, err := study.ValidateExercises([]study.ExerciseInput{{Key: " sum ", Title: " Sum ", Tests: tests}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got[0].Key != "sum" || got[0].Title != "Sum" || got[0].Language != "python" {
+		t.Errorf("input not normalized: %+v", got[0])
+	}
+
+	invalid := map[string][]study.ExerciseInput{
+		"bad key":   {{Key: "Sum It", Title: "x", Tests: tests}},
+		"duplicate": {{Key: "a", Title: "x", Tests: tests}, {Key: "a", Title: "y", Tests: tests}},
+		"no title":  {{Key: "a", Tests: tests}},
+		"no tests":  {{Key: "a", Title: "x"}},
+		"language":  {{Key: "a", Title: "x", Language: "ruby", Tests: tests}},
+	}
+	for name, inputs := range invalid {
+		if _, err := study.ValidateExercises(inputs); !errors.Is(err, study.ErrInvalidExercise) {
+			t.Errorf("%s: expected ErrInvalidExercise, got %v", name, err)
+		}
+	}
+}
+
+func TestNewExerciseView_HidesHiddenTests(t *testing.T) {
+	ex := &study.Exercise{
+		Key: "sum",
+		Tests: []study.ExerciseTest{
+			{Input: "1 2\n", ExpectedOutput: "3\n"},
+			{Input: "secret\n", ExpectedOutput: "42\n", Hidden: true},
+		},
+	}
+	view := study.NewExerciseView(ex, nil)
+	if view.TestCount != 2 || len(view.Examples) != 1 || view.Examples[0].Input != "1 2\n" {
+		t.Errorf("unexpected view %+v", view)
+	}
+}
+
+func TestNewExerciseSubmission_RedactsHiddenCases(t *testing.T) {
+	tests := []study.ExerciseTest{
+		{Input: "1\n", ExpectedOutput: "1\n"},
+		{Input: "secret\n", ExpectedOutput: "42\n", Hidden: true},
+	}
+	judged := run.JudgeResult{Passed: 1, Total: 2, Cases: []run.CaseResult{
+		{Passed: true, Stdout: "1\n"},
+		{Passed: false, Stdout: "41\n"},
+	}}
+
+	sub := study.NewExerciseSubmission(tests, judged)
+	if sub.Passed || sub.PassedCount != 1 || sub.Total != 2 {
+		t.Errorf("unexpected totals %+v", sub)
+	}
+	if sub.Cases[0].Input != "1\n" || sub.Cases[0].Stdout != "1\n" {
+		t.Errorf("visible case should include details: %+v", sub.Cases[0])
+	}
+	hidden := sub.Cases[1]
+	if !hidden.Hidden || hidden.Input != "" || hidden.ExpectedOutput != "" || hidden.Stdout != "" {
+		t.Errorf("hidden case leaked details: %+v", hidden)
+	}
+}
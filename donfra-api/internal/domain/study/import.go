@@ -0,0 +1,186 @@
+package study
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"donfra-api/internal/pkg/tracing"
+)
+
+// ExportLessons renders every lesson (published or not) as a bundle.
+// Caller must ensure admin authorization (e.g., via middleware).
+func (s *Service) ExportLessons(ctx context.Context, format BundleFormat) ([]byte, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.ExportLessons",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("lessons"),
+	)
+	defer span.End()
+
+	var lessons []Lesson
+	if err := s.db.WithContext(ctx).Order("slug ASC").Find(&lessons).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	entries := make([]BundleLesson, len(lessons))
+	for i := range lessons {
+		entries[i] = lessons[i].ToBundleLesson()
+	}
+
+	var buf bytes.Buffer
+	if err := WriteBundle(&buf, format, entries); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ImportLessons upserts lessons from a bundle by slug, recording a revision for each
+// lesson written. A lesson updated after the bundle's updated_at is a conflict and is
+// skipped unless opts.Force is set. With opts.DryRun nothing is written.
+// Caller must ensure admin authorization (e.g., via middleware).
+func (s *Service) ImportLessons(ctx context.Context, data []byte, opts ImportOptions, author RevisionAuthor) (*ImportReport, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.ImportLessons",
+		tracing.AttrDBOperation.String("UPSERT"),
+		tracing.AttrDBTable.String("lessons"),
+	)
+	defer span.End()
+
+	entries, err := ReadBundle(data)
+	if err != nil {
+		return nil, err
+	}
+
+	slugs := make([]string, len(entries))
+	for i, e := range entries {
+		slugs[i] = e.Slug
+	}
+	var existing []Lesson
+	if err := s.db.WithContext(ctx).Where("slug IN ?", slugs).Find(&existing).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	bySlug := make(map[string]*Lesson, len(existing))
+	for i := range existing {
+		bySlug[existing[i].Slug] = &existing[i]
+	}
+
+	report := &ImportReport{DryRun: opts.DryRun, Items: make([]ImportItem, 0, len(entries))}
+	for _, e := range entries {
+		item := planImport(e, bySlug[e.Slug], opts.Force)
+		switch item.Action {
+		case ImportCreate:
+			report.Created++
+		case ImportUpdate:
+			report.Updated++
+		case ImportUnchanged:
+			report.Unchanged++
+		case ImportConflict:
+			report.Conflicts++
+		}
+		report.Items = append(report.Items, item)
+	}
+	if opts.DryRun {
+		return report, nil
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, e := range entries {
+			switch report.Items[i].Action {
+			case ImportCreate:
+				if err := importCreate(tx, e, author); err != nil {
+					return fmt.Errorf("failed to create %q: %w", e.Slug, err)
+				}
+			case ImportUpdate:
+				if err := importUpdate(tx, bySlug[e.Slug], e, author); err != nil {
+					return fmt.Errorf("failed to update %q: %w", e.Slug, err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	s.invalidateLessons(ctx, slugs...)
+	return report, nil
+}
+
+// planImport decides what to do with a bundle lesson given the current lesson (nil if new)
+func planImport(e BundleLesson, current *Lesson, force bool) ImportItem {
+	item := ImportItem{Slug: e.Slug}
+	switch {
+	case current == nil:
+		item.Action = ImportCreate
+	case sameContent(e, current):
+		item.Action = ImportUnchanged
+	case !force && e.UpdatedAt != nil && current.UpdatedAt.After(*e.UpdatedAt):
+		item.Action = ImportConflict
+		item.Reason = fmt.Sprintf("lesson was updated at %s, after the bundle copy (%s)",
+			current.UpdatedAt.UTC().Format(time.RFC3339), e.UpdatedAt.UTC().Format(time.RFC3339))
+	default:
+		item.Action = ImportUpdate
+	}
+	return item
+}
+
+// sameContent reports whether importing e would leave the lesson unchanged
+func sameContent(e BundleLesson, l *Lesson) bool {
+	if e.Title != l.Title || e.Markdown != l.Markdown || e.IsPublished != l.IsPublished || e.Category != l.Category {
+		return false
+	}
+	if !reflect.DeepEqual(NormalizeTags(e.Tags), NormalizeTags(l.Tags)) {
+		return false
+	}
+	// A bundle without a drawing keeps the current one
+	return len(e.Excalidraw) == 0 || sameJSON(e.Excalidraw, l.Excalidraw)
+}
+
+// sameJSON compares two JSON documents ignoring formatting and key order
+func sameJSON(a, b []byte) bool {
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+func importCreate(tx *gorm.DB, e BundleLesson, author RevisionAuthor) error {
+	lesson := e.ToLesson(author.UserID)
+	if err := tx.Create(lesson).Error; err != nil {
+		return err
+	}
+	// A new lesson takes precedence over a redirect left by a renamed one
+	if err := releaseSlug(tx, lesson.Slug); err != nil {
+		return err
+	}
+	return recordRevision(tx, lesson, author, nil)
+}
+
+func importUpdate(tx *gorm.DB, lesson *Lesson, e BundleLesson, author RevisionAuthor) error {
+	updates := map[string]any{
+		"title":        e.Title,
+		"markdown":     e.Markdown,
+		"is_published": e.IsPublished,
+		"category":     e.Category,
+		"tags":         datatypes.JSONSlice[string](NormalizeTags(e.Tags)),
+	}
+	if len(e.Excalidraw) > 0 {
+		updates["excalidraw"] = e.Excalidraw
+	}
+	if err := tx.Model(lesson).Updates(updates).Error; err != nil {
+		return err
+	}
+	if err := tx.First(lesson, lesson.ID).Error; err != nil {
+		return err
+	}
+	return recordRevision(tx, lesson, author, nil)
+}
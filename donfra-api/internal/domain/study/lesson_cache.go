@@ -0,0 +1,113 @@
+package study
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultLessonCacheTTL bounds how long a cached lesson is served without a write.
+const DefaultLessonCacheTTL = 5 * time.Minute
+
+// LessonCache caches lessons by slug for GetLessonBySlug. The service invalidates
+// entries on every lesson write; the TTL bounds staleness from other instances
+// when the cache is not shared.
+type LessonCache interface {
+	Get(ctx context.Context, slug string) (*Lesson, bool)
+	Set(ctx context.Context, lesson *Lesson)
+	Invalidate(ctx context.Context, slugs ...string)
+	Clear(ctx context.Context)
+}
+
+// MemoryLessonCache is an in-process LessonCache.
+type MemoryLessonCache struct {
+	mu         sync.RWMutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]memoryLessonEntry
+}
+
+type memoryLessonEntry struct {
+	lesson  Lesson
+	expires time.Time
+}
+
+// NewMemoryLessonCache creates an in-process cache holding at most maxEntries lessons.
+func NewMemoryLessonCache(ttl time.Duration, maxEntries int) *MemoryLessonCache {
+	if ttl <= 0 {
+		ttl = DefaultLessonCacheTTL
+	}
+	return &MemoryLessonCache{ttl: ttl, maxEntries: maxEntries, entries: make(map[string]memoryLessonEntry)}
+}
+
+// Get returns a copy of the cached lesson.
+func (c *MemoryLessonCache) Get(_ context.Context, slug string) (*Lesson, bool) {
+	c.mu.RLock()
+	entry, ok := c.entries[slug]
+	c.mu.RUnlock()
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+	return cloneLesson(&entry.lesson), true
+}
+
+// Set stores a copy of the lesson, making room by dropping expired (or arbitrary) entries.
+func (c *MemoryLessonCache) Set(_ context.Context, lesson *Lesson) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if c.maxEntries > 0 && len(c.entries) >= c.maxEntries {
+		for slug, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, slug)
+			}
+		}
+		for slug := range c.entries {
+			if len(c.entries) < c.maxEntries {
+				break
+			}
+			delete(c.entries, slug)
+		}
+	}
+	c.entries[lesson.Slug] = memoryLessonEntry{lesson: *cloneLesson(lesson), expires: now.Add(c.ttl)}
+}
+
+// Invalidate drops the given slugs.
+func (c *MemoryLessonCache) Invalidate(_ context.Context, slugs ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, slug := range slugs {
+		delete(c.entries, slug)
+	}
+}
+
+// Clear drops every entry.
+func (c *MemoryLessonCache) Clear(_ context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]memoryLessonEntry)
+}
+
+// cloneLesson deep-copies a lesson so callers never share its slices or pointers with the cache
+func cloneLesson(lesson *Lesson) *Lesson {
+	clone := *lesson
+	clone.Excalidraw = slices.Clone(lesson.Excalidraw)
+	clone.Tags = slices.Clone(lesson.Tags)
+	clone.PublishAt = clonePtr(lesson.PublishAt)
+	clone.UnpublishAt = clonePtr(lesson.UnpublishAt)
+	clone.OwnerID = clonePtr(lesson.OwnerID)
+	clone.SubmittedAt = clonePtr(lesson.SubmittedAt)
+	clone.ReviewedByID = clonePtr(lesson.ReviewedByID)
+	clone.ReviewedAt = clonePtr(lesson.ReviewedAt)
+	return &clone
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
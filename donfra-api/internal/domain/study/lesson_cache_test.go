@@ -0,0 +1,104 @@
+package study_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"donfra-api/internal/domain/study"
+)
+
+func TestMemoryLessonCache(t *testing.T) {
+	ctx := context.Background()
+	c := study.NewMemoryLessonCache(time.Minute, 10)
+
+	lesson := &study.Lesson{ID: 1, Slug: "graphs", Title: "Graphs"}
+	c.Set(ctx, lesson)
+	lesson.Title = "changed after set"
+
+	got, ok := c.Get(ctx, "graphs")
+	if !ok || got.Title != "Graphs" {
+		t.Fatalf("expected cached copy titled Graphs, got %+v (hit=%v)", got, ok)
+	}
+	got.Title = "changed after get"
+	if again, _ := c.Get(ctx, "graphs"); again.Title != "Graphs" {
+		t.Errorf("cache entry was mutated through a returned lesson: %q", again.Title)
+	}
+
+	c.Set(ctx, &study.Lesson{ID: 2, Slug: "trees"})
+	c.Invalidate(ctx, "graphs")
+	if _, ok := c.Get(ctx, "graphs"); ok {
+		t.Error("expected graphs to be invalidated")
+	}
+	if _, ok := c.Get(ctx, "trees"); !ok {
+		t.Error("expected trees to stay cached")
+	}
+
+	c.Clear(ctx)
+	if _, ok := c.Get(ctx, "trees"); ok {
+		t.Error("expected cache to be empty after Clear")
+	}
+}
+
+func TestMemoryLessonCacheExpiryAndLimit(t *testing.T) {
+	ctx := context.Background()
+
+	c := study.NewMemoryLessonCache(time.Millisecond, 10)
+	c.Set(ctx, &study.Lesson{Slug: "graphs"})
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := c.Get(ctx, "graphs"); ok {
+		t.Error("expected expired entry to miss")
+	}
+
+	c = study.NewMemoryLessonCache(time.Minute, 2)
+	for _, slug := range []string{"a", "b", "c"} {
+		c.Set(ctx, &study.Lesson{Slug: slug})
+	}
+	if _, ok := c.Get(ctx, "c"); !ok {
+		t.Error("expected the latest entry to be cached")
+	}
+	hits := 0
+	for _, slug := range []string{"a", "b", "c"} {
+		if _, ok := c.Get(ctx, slug); ok {
+			hits++
+		}
+	}
+	if hits > 2 {
+		t.Errorf("expected at most 2 cached lessons, got %d", hits)
+	}
+}
+
+// TestMemoryLessonCacheDeepCopies tests that tags, drawings and pointer fields of cached
+// lessons cannot be changed through the lesson passed to Set or returned by Get
+func TestMemoryLessonCacheDeepCopies(t *testing.T) {
+	ctx := context.Background()
+	c := study.NewMemoryLessonCache(time.Minute, 10)
+
+	owner := uint(3)
+	lesson := &study.Lesson{
+		Slug:       "graphs",
+		Tags:       []string{"graphs"},
+		Excalidraw: []byte(`{"elements":[]}`),
+		OwnerID:    &owner,
+	}
+	c.Set(ctx, lesson)
+	lesson.Tags[0] = "set"
+	lesson.Excalidraw[2] = 'X'
+	*lesson.OwnerID = 4
+
+	got, _ := c.Get(ctx, "graphs")
+	got.Tags[0] = "get"
+	got.Excalidraw[3] = 'Y'
+	*got.OwnerID = 5
+
+	again, _ := c.Get(ctx, "graphs")
+	if again.Tags[0] != "graphs" {
+		t.Errorf("tags were mutated through a shared slice: %v", again.Tags)
+	}
+	if string(again.Excalidraw) != `{"elements":[]}` {
+		t.Errorf("excalidraw was mutated through a shared slice: %s", again.Excalidraw)
+	}
+	if *again.OwnerID != 3 {
+		t.Errorf("owner was mutated through a shared pointer: %d", *again.OwnerID)
+	}
+}
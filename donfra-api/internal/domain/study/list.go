@@ -0,0 +1,208 @@
+package study
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"donfra-api/internal/pkg/tracing"
+)
+
+var (
+	// ErrInvalidSort is returned for an unknown sort field or order.
+	ErrInvalidSort = errors.New("sort must be created, updated or title and order must be asc or desc")
+	// ErrInvalidCursor is returned when a cursor cannot be decoded or was issued for a different sort.
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+const (
+	// DefaultListLimit is the page size used when no limit is given.
+	DefaultListLimit = 50
+	// MaxListLimit caps the page size.
+	MaxListLimit = 100
+	// ExcerptLength is the maximum number of characters in a lesson excerpt.
+	ExcerptLength = 200
+)
+
+// sortColumns maps the public sort names to lesson columns
+var sortColumns = map[string]string{
+	"created": "created_at",
+	"updated": "updated_at",
+	"title":   "title",
+}
+
+// listCursor is the decoded form of a pagination cursor: the sort key and ID of
+// the last item on the previous page.
+type listCursor struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+}
+
+// ListLessonSummaries returns one page of lesson summaries using keyset pagination.
+// Caller is responsible for restricting Published for non-admin users.
+func (s *Service) ListLessonSummaries(ctx context.Context, q ListLessonsQuery) (*LessonPage, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.ListLessonSummaries",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("lessons"),
+	)
+	defer span.End()
+
+	if q.Sort == "" {
+		q.Sort = "created"
+	}
+	if q.Order == "" {
+		q.Order = "asc"
+	}
+	column, ok := sortColumns[q.Sort]
+	if !ok || (q.Order != "asc" && q.Order != "desc") {
+		return nil, ErrInvalidSort
+	}
+	if q.Limit <= 0 {
+		q.Limit = DefaultListLimit
+	}
+	q.Limit = min(q.Limit, MaxListLimit)
+
+	db := s.db.WithContext(ctx).Model(&Lesson{}).
+		Select("id, slug, title, category, tags, is_published, publish_at, unpublish_at, owner_id, required_role, review_status, created_at, updated_at, left(markdown, ?) AS excerpt", ExcerptLength*3)
+	if q.Published != nil {
+		db = wherePublished(db, "lessons", time.Now(), *q.Published)
+	}
+	if tag := strings.ToLower(strings.TrimSpace(q.Tag)); tag != "" {
+		tagJSON, err := json.Marshal([]string{tag})
+		if err != nil {
+			return nil, err
+		}
+		db = db.Where("tags @> ?", string(tagJSON))
+	}
+	if q.Category != "" {
+		db = db.Where("category = ?", strings.TrimSpace(q.Category))
+	}
+	if q.OwnerID != nil {
+		db = db.Where("owner_id = ?", *q.OwnerID)
+	}
+	if q.ReviewStatus != "" {
+		db = db.Where("review_status = ?", q.ReviewStatus)
+	}
+
+	if q.Cursor != "" {
+		cursor, err := decodeCursor(q.Cursor)
+		if err != nil || cursor.Sort != q.Sort || cursor.Order != q.Order {
+			return nil, ErrInvalidCursor
+		}
+		value, err := cursorValue(q.Sort, cursor.Value)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		op := ">"
+		if q.Order == "desc" {
+			op = "<"
+		}
+		db = db.Where(fmt.Sprintf("(%s %s ? OR (%s = ? AND id %s ?))", column, op, column, op), value, value, cursor.ID)
+	}
+
+	var items []LessonSummary
+	if err := db.Order(fmt.Sprintf("%s %s, id %s", column, q.Order, q.Order)).
+		Limit(q.Limit + 1).
+		Scan(&items).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	page := &LessonPage{Items: items}
+	if len(items) > q.Limit {
+		page.Items = items[:q.Limit]
+		last := page.Items[q.Limit-1]
+		page.NextCursor = encodeCursor(listCursor{
+			Sort:  q.Sort,
+			Order: q.Order,
+			Value: sortValue(q.Sort, &last),
+			ID:    last.ID,
+		})
+	}
+	for i := range page.Items {
+		if !q.Viewer.HasRequiredRole(page.Items[i].RequiredRole) {
+			page.Items[i].Excerpt = ""
+			continue
+		}
+		page.Items[i].Excerpt = Excerpt(page.Items[i].Excerpt, ExcerptLength)
+	}
+	if page.Items == nil {
+		page.Items = []LessonSummary{}
+	}
+
+	span.SetAttributes(tracing.AttrResponseCount.Int(len(page.Items)))
+	return page, nil
+}
+
+// sortValue returns the cursor representation of the item's sort key
+func sortValue(sort string, item *LessonSummary) string {
+	switch sort {
+	case "updated":
+		return item.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	case "title":
+		return item.Title
+	default:
+		return item.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+// cursorValue parses a cursor sort key back into a query argument
+func cursorValue(sort, raw string) (any, error) {
+	if sort == "title" {
+		return raw, nil
+	}
+	return time.Parse(time.RFC3339Nano, raw)
+}
+
+func encodeCursor(c listCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(raw string) (*listCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, err
+	}
+	var c listCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+var (
+	fencedCodeRe  = regexp.MustCompile("(?s)```.*?(```|$)")
+	markdownImgRe = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	markdownURLRe = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	lineMarkerRe  = regexp.MustCompile(`(?m)^\s*(#{1,6}\s+|>\s*|[-*+]\s+|\d+\.\s+)`)
+	emphasisRe    = regexp.MustCompile("[*_`~]+")
+)
+
+// Excerpt turns the beginning of a markdown document into plain text of at most
+// maxLen characters, cutting at a word boundary.
+func Excerpt(markdown string, maxLen int) string {
+	text := fencedCodeRe.ReplaceAllString(markdown, " ")
+	text = markdownImgRe.ReplaceAllString(text, "$1")
+	text = markdownURLRe.ReplaceAllString(text, "$1")
+	text = lineMarkerRe.ReplaceAllString(text, "")
+	text = emphasisRe.ReplaceAllString(text, "")
+	text = strings.Join(strings.Fields(text), " ")
+
+	if utf8.RuneCountInString(text) <= maxLen {
+		return text
+	}
+	cut := string([]rune(text)[:maxLen])
+	if i := strings.LastIndex(cut, " "); i > maxLen/2 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " .,;:") + "…"
+}
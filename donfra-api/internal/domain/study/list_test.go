@@ -0,0 +1,27 @@
+package study_test
+
+import (
+	"strings"
+	"testing"
+
+	"donfra-api/internal/domain/study"
+)
+
+func TestExcerpt_StripsMarkdown(t *testing.T) {
+	md := "# Graphs\n\nA **graph** is a set of [nodes](https://example.com) and `edges`.\n\n```python\nprint('hidden')\n```\n\n- item one\n> quoted"
+
+	got := study.Excerpt(md, 200)
+	want := "Graphs A graph is a set of nodes and edges. item one quoted"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestExcerpt_TruncatesAtWordBoundary(t *testing.T) {
+	md := strings.Repeat("word ", 100)
+
+	got := study.Excerpt(md, 23)
+	if got != "word word word word…" {
+		t.Errorf("unexpected excerpt %q", got)
+	}
+}
@@ -8,14 +8,30 @@ import (
 
 // Lesson represents an educational lesson in the system.
 type Lesson struct {
-	ID          uint           `gorm:"primaryKey" json:"id"`
-	Slug        string         `gorm:"not null" json:"slug"`
-	Title       string         `gorm:"not null" json:"title"`
-	Markdown    string         `gorm:"type:text;not null" json:"markdown"`
-	Excalidraw  datatypes.JSON `gorm:"type:jsonb;not null" json:"excalidraw"`
-	IsPublished bool           `gorm:"column:is_published;not null;default:false" json:"isPublished"`
-	CreatedAt   time.Time      `json:"createdAt"`
-	UpdatedAt   time.Time      `json:"updatedAt"`
+	ID          uint                        `gorm:"primaryKey" json:"id"`
+	Slug        string                      `gorm:"not null" json:"slug"`
+	Title       string                      `gorm:"not null" json:"title"`
+	Markdown    string                      `gorm:"type:text;not null" json:"markdown"`
+	Excalidraw  datatypes.JSON              `gorm:"type:jsonb;not null" json:"excalidraw"`
+	IsPublished bool                        `gorm:"column:is_published;not null;default:false" json:"isPublished"`
+	PublishAt   *time.Time                  `json:"publishAt"`
+	UnpublishAt *time.Time                  `json:"unpublishAt"`
+	Category    string                      `gorm:"size:100;not null;default:''" json:"category"`
+	Tags        datatypes.JSONSlice[string] `gorm:"type:jsonb;not null" json:"tags"`
+	// RequiredRole restricts the lesson to users with that role (admins always pass)
+	RequiredRole string `gorm:"size:20;not null;default:''" json:"requiredRole"`
+	// RequirePrerequisites locks the lesson until its prerequisites are completed
+	RequirePrerequisites bool `gorm:"not null;default:false" json:"requirePrerequisites"`
+	// OwnerID is the user who created the lesson; mentors may only edit their own lessons
+	OwnerID *uint `json:"ownerId"`
+	// Publishing review of lessons written by mentors, see SubmitLessonForReview
+	ReviewStatus string     `gorm:"size:20;not null;default:''" json:"reviewStatus"`
+	ReviewNote   string     `gorm:"type:text;not null;default:''" json:"reviewNote,omitempty"`
+	SubmittedAt  *time.Time `json:"submittedAt,omitempty"`
+	ReviewedByID *uint      `json:"reviewedById,omitempty"`
+	ReviewedAt   *time.Time `json:"reviewedAt,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
 }
 
 // CreateLessonRequest represents a request to create a new lesson.
@@ -25,14 +41,31 @@ type CreateLessonRequest struct {
 	Markdown    string         `json:"markdown"`
 	Excalidraw  datatypes.JSON `json:"excalidraw"`
 	IsPublished bool           `json:"isPublished"`
+	PublishAt   *time.Time     `json:"publishAt"`
+	UnpublishAt *time.Time     `json:"unpublishAt"`
+	Category    string         `json:"category"`
+	Tags        []string       `json:"tags"`
+	// Access gating, see Lesson
+	RequiredRole         string `json:"requiredRole"`
+	RequirePrerequisites bool   `json:"requirePrerequisites"`
 }
 
 // UpdateLessonRequest represents a request to update an existing lesson.
+// Send publishAt/unpublishAt as null to clear a schedule. Changing slug renames the
+// lesson; the old slug redirects to the new one.
 type UpdateLessonRequest struct {
+	Slug        *string        `json:"slug"`
 	Title       string         `json:"title"`
 	Markdown    string         `json:"markdown"`
 	Excalidraw  datatypes.JSON `json:"excalidraw"`
 	IsPublished *bool          `json:"isPublished"`
+	PublishAt   NullableTime   `json:"publishAt"`
+	UnpublishAt NullableTime   `json:"unpublishAt"`
+	Category    *string        `json:"category"`
+	Tags        *[]string      `json:"tags"`
+	// Access gating, see Lesson
+	RequiredRole         *string `json:"requiredRole"`
+	RequirePrerequisites *bool   `json:"requirePrerequisites"`
 }
 
 // UpdateLessonResponse represents the response after updating a lesson.
@@ -40,3 +73,704 @@ type UpdateLessonResponse struct {
 	Slug    string `json:"slug"`
 	Updated bool   `json:"updated"`
 }
+
+// RevisionAuthor identifies who made a lesson change.
+// UserID is nil when the change was made with the legacy admin token.
+type RevisionAuthor struct {
+	UserID *uint
+	Email  string
+}
+
+// LessonRevision is a full snapshot of a lesson written on every change.
+type LessonRevision struct {
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	LessonID     uint           `gorm:"not null;index" json:"lessonId"`
+	Number       int            `gorm:"not null" json:"number"`
+	Title        string         `gorm:"not null" json:"title"`
+	Markdown     string         `gorm:"type:text;not null" json:"markdown"`
+	Excalidraw   datatypes.JSON `gorm:"type:jsonb;not null" json:"excalidraw"`
+	IsPublished  bool           `gorm:"column:is_published;not null" json:"isPublished"`
+	AuthorID     *uint          `json:"authorId"`
+	AuthorEmail  string         `json:"authorEmail"`
+	RestoredFrom *uint          `json:"restoredFrom,omitempty"`
+	CreatedAt    time.Time      `json:"createdAt"`
+
+	// Rendered markdown cached by RenderLesson
+	RenderedHTML    *string                       `gorm:"type:text" json:"-"`
+	RenderedTOC     datatypes.JSONSlice[TOCEntry] `gorm:"type:jsonb" json:"-"`
+	RendererVersion int                           `gorm:"not null;default:0" json:"-"`
+}
+
+// TableName specifies the table name for GORM.
+func (LessonRevision) TableName() string {
+	return "lesson_revisions"
+}
+
+// LessonRevisionSummary is a revision without its content, used in history listings.
+type LessonRevisionSummary struct {
+	ID           uint      `json:"id"`
+	Number       int       `json:"number"`
+	Title        string    `json:"title"`
+	IsPublished  bool      `json:"isPublished"`
+	AuthorID     *uint     `json:"authorId"`
+	AuthorEmail  string    `json:"authorEmail"`
+	RestoredFrom *uint     `json:"restoredFrom,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// RevisionDiff describes the changes between two revisions of a lesson.
+type RevisionDiff struct {
+	Slug              string                `json:"slug"`
+	From              LessonRevisionSummary `json:"from"`
+	To                LessonRevisionSummary `json:"to"`
+	TitleChanged      bool                  `json:"titleChanged"`
+	PublishedChanged  bool                  `json:"publishedChanged"`
+	ExcalidrawChanged bool                  `json:"excalidrawChanged"`
+	Added             int                   `json:"added"`
+	Removed           int                   `json:"removed"`
+	Lines             []DiffLine            `json:"lines"`
+	Unified           string                `json:"unified"`
+}
+
+// LessonSearchResult is a ranked full-text search hit.
+// TitleHighlight and Snippet are HTML-escaped with matches wrapped in <mark> tags.
+type LessonSearchResult struct {
+	ID             uint      `json:"id"`
+	Slug           string    `json:"slug"`
+	Title          string    `json:"title"`
+	TitleHighlight string    `json:"titleHighlight"`
+	Snippet        string    `json:"snippet"`
+	Rank           float64   `json:"rank"`
+	IsPublished    bool      `json:"isPublished"`
+	RequiredRole   string    `json:"requiredRole,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// LessonSummary is the lightweight projection of a lesson used in list views.
+type LessonSummary struct {
+	ID          uint                        `json:"id"`
+	Slug        string                      `json:"slug"`
+	Title       string                      `json:"title"`
+	Excerpt     string                      `json:"excerpt"`
+	Category    string                      `json:"category"`
+	Tags        datatypes.JSONSlice[string] `json:"tags"`
+	IsPublished bool                        `json:"isPublished"`
+	PublishAt   *time.Time                  `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time                  `json:"unpublishAt,omitempty"`
+	OwnerID     *uint                       `json:"ownerId,omitempty"`
+	// RequiredRole is set for role-gated lessons, whose excerpt is hidden from
+	// viewers without that role
+	RequiredRole string `json:"requiredRole,omitempty"`
+	// ReviewStatus is empty for lessons that never went through review
+	ReviewStatus string    `json:"reviewStatus,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// ListLessonsQuery controls sorting, filtering and pagination of lesson lists.
+type ListLessonsQuery struct {
+	Sort   string // created (default), updated or title
+	Order  string // asc (default) or desc
+	Cursor string // opaque cursor from a previous page
+	Limit  int
+	// Published filters by published state; nil returns both.
+	Published *bool
+	Tag       string
+	Category  string
+	// OwnerID restricts the list to lessons of one author.
+	OwnerID *uint
+	// ReviewStatus filters by publishing review status (e.g. ReviewPending).
+	ReviewStatus string
+	// Viewer is the caller; excerpts of role-gated lessons it cannot read are left empty.
+	Viewer LessonViewer
+}
+
+// LessonPage is one page of lesson summaries.
+type LessonPage struct {
+	Items      []LessonSummary `json:"items"`
+	NextCursor string          `json:"nextCursor,omitempty"`
+}
+
+// Track is an ordered curriculum grouping lessons.
+type Track struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Slug        string    `gorm:"not null;uniqueIndex" json:"slug"`
+	Title       string    `gorm:"not null" json:"title"`
+	Description string    `gorm:"type:text;not null;default:''" json:"description"`
+	IsPublished bool      `gorm:"column:is_published;not null;default:false" json:"isPublished"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// TableName specifies the table name for GORM.
+func (Track) TableName() string {
+	return "tracks"
+}
+
+// TrackLesson places a lesson at a position within a track.
+type TrackLesson struct {
+	TrackID  uint `gorm:"primaryKey" json:"trackId"`
+	LessonID uint `gorm:"primaryKey" json:"lessonId"`
+	Position int  `gorm:"not null" json:"position"`
+}
+
+// TableName specifies the table name for GORM.
+func (TrackLesson) TableName() string {
+	return "track_lessons"
+}
+
+// TrackLessonEntry is a lesson within a track with navigation to its neighbours.
+type TrackLessonEntry struct {
+	Position     int    `json:"position"`
+	Slug         string `json:"slug"`
+	Title        string `json:"title"`
+	IsPublished  bool   `json:"isPublished"`
+	PreviousSlug string `json:"previousSlug,omitempty"`
+	NextSlug     string `json:"nextSlug,omitempty"`
+}
+
+// TrackDetail is a track with its ordered lessons.
+type TrackDetail struct {
+	Track
+	Lessons []TrackLessonEntry `json:"lessons"`
+}
+
+// CreateTrackRequest represents a request to create a track.
+type CreateTrackRequest struct {
+	Slug        string   `json:"slug"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	IsPublished bool     `json:"isPublished"`
+	LessonSlugs []string `json:"lessons"`
+}
+
+// UpdateTrackRequest represents a request to update a track. Nil fields are left unchanged.
+type UpdateTrackRequest struct {
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	IsPublished *bool   `json:"isPublished"`
+}
+
+// SetTrackLessonsRequest replaces the ordered lessons of a track.
+type SetTrackLessonsRequest struct {
+	LessonSlugs []string `json:"lessons"`
+}
+
+// Lesson progress statuses
+const (
+	ProgressInProgress = "in_progress"
+	ProgressCompleted  = "completed"
+)
+
+// LessonProgress records how far a user got in a lesson.
+type LessonProgress struct {
+	ID           uint       `gorm:"primaryKey" json:"-"`
+	UserID       uint       `gorm:"not null;uniqueIndex:idx_lesson_progress_user_lesson" json:"-"`
+	LessonID     uint       `gorm:"not null;uniqueIndex:idx_lesson_progress_user_lesson" json:"lessonId"`
+	Status       string     `gorm:"size:20;not null" json:"status"`
+	LastPosition string     `gorm:"size:255;not null;default:''" json:"lastPosition"`
+	CompletedAt  *time.Time `json:"completedAt,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+}
+
+// TableName specifies the table name for GORM.
+func (LessonProgress) TableName() string {
+	return "lesson_progress"
+}
+
+// UpdateProgressRequest is the request payload for PUT /api/me/progress/{slug}.
+// An empty status keeps the current status (in_progress for a first visit).
+type UpdateProgressRequest struct {
+	Status       string `json:"status"`
+	LastPosition string `json:"lastPosition"`
+}
+
+// LessonProgressEntry is a user's progress in one lesson, used in the progress summary.
+type LessonProgressEntry struct {
+	Slug         string     `json:"slug"`
+	Title        string     `json:"title"`
+	Status       string     `json:"status"`
+	LastPosition string     `json:"lastPosition"`
+	CompletedAt  *time.Time `json:"completedAt,omitempty"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+}
+
+// TrackProgress summarizes completion of a track for one user.
+type TrackProgress struct {
+	Slug      string `json:"slug"`
+	Title     string `json:"title"`
+	Total     int    `json:"total"`
+	Completed int    `json:"completed"`
+	Percent   int    `json:"percent"`
+}
+
+// ProgressSummary is the response of GET /api/me/progress.
+type ProgressSummary struct {
+	Completed  int                   `json:"completed"`
+	InProgress int                   `json:"inProgress"`
+	Tracks     []TrackProgress       `json:"tracks"`
+	Lessons    []LessonProgressEntry `json:"lessons"`
+}
+
+// Bookmark saves a lesson to a user's reading list.
+type Bookmark struct {
+	ID        uint      `gorm:"primaryKey" json:"-"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_bookmarks_user_lesson" json:"-"`
+	LessonID  uint      `gorm:"not null;uniqueIndex:idx_bookmarks_user_lesson" json:"-"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// TableName specifies the table name for GORM.
+func (Bookmark) TableName() string {
+	return "lesson_bookmarks"
+}
+
+// BookmarkEntry is a bookmarked lesson as returned by GET /api/me/bookmarks.
+type BookmarkEntry struct {
+	Slug      string    `json:"slug"`
+	Title     string    `json:"title"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// LessonNote is a private markdown note a user attached to a lesson,
+// optionally anchored to a heading.
+type LessonNote struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"not null;index" json:"-"`
+	LessonID   uint      `gorm:"not null;index" json:"-"`
+	LessonSlug string    `gorm:"->;-:migration" json:"lessonSlug"`
+	Anchor     string    `gorm:"size:255;not null;default:''" json:"anchor"`
+	Markdown   string    `gorm:"type:text;not null" json:"markdown"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// TableName specifies the table name for GORM.
+func (LessonNote) TableName() string {
+	return "lesson_notes"
+}
+
+// CreateNoteRequest is the request payload for POST /api/me/notes.
+type CreateNoteRequest struct {
+	LessonSlug string `json:"lessonSlug"`
+	Anchor     string `json:"anchor"`
+	Markdown   string `json:"markdown"`
+}
+
+// UpdateNoteRequest is the request payload for PATCH /api/me/notes/{id}. Nil fields are left unchanged.
+type UpdateNoteRequest struct {
+	Anchor   *string `json:"anchor"`
+	Markdown *string `json:"markdown"`
+}
+
+// Comment is a lesson discussion comment. Replies point at their parent and at the
+// root comment of their thread; locking is recorded on the root.
+type Comment struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	LessonID   uint       `gorm:"not null;index" json:"-"`
+	ParentID   *uint      `json:"parentId,omitempty"`
+	RootID     *uint      `gorm:"index" json:"rootId,omitempty"`
+	AuthorID   uint       `gorm:"not null;index" json:"authorId"`
+	AuthorName string     `gorm:"->;-:migration" json:"authorName"`
+	Body       string     `gorm:"type:text;not null" json:"body"`
+	IsHidden   bool       `gorm:"not null;default:false" json:"isHidden"`
+	IsLocked   bool       `gorm:"not null;default:false" json:"isLocked"`
+	EditedAt   *time.Time `json:"editedAt,omitempty"`
+	RemovedAt  *time.Time `json:"removedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+}
+
+// TableName specifies the table name for GORM.
+func (Comment) TableName() string {
+	return "lesson_comments"
+}
+
+// CommentNode is a comment with its replies, as returned by GET /api/lessons/{slug}/comments.
+type CommentNode struct {
+	Comment
+	Replies []*CommentNode `json:"replies"`
+}
+
+// CreateCommentRequest is the request payload for POST /api/lessons/{slug}/comments.
+type CreateCommentRequest struct {
+	Body     string `json:"body"`
+	ParentID *uint  `json:"parentId"`
+}
+
+// UpdateCommentRequest is the request payload for PATCH /api/lessons/{slug}/comments/{id}.
+type UpdateCommentRequest struct {
+	Body string `json:"body"`
+}
+
+// ModerationAction is an action a moderator can take on a comment.
+type ModerationAction string
+
+const (
+	ModerationHide   ModerationAction = "hide"
+	ModerationUnhide ModerationAction = "unhide"
+	ModerationLock   ModerationAction = "lock"
+	ModerationUnlock ModerationAction = "unlock"
+)
+
+// ImportAction is what an import does (or would do, in a dry run) with a bundle lesson.
+type ImportAction string
+
+const (
+	ImportCreate    ImportAction = "create"
+	ImportUpdate    ImportAction = "update"
+	ImportUnchanged ImportAction = "unchanged"
+	ImportConflict  ImportAction = "conflict"
+)
+
+// ImportOptions controls POST /api/lessons/import.
+type ImportOptions struct {
+	// DryRun reports what would change without writing anything
+	DryRun bool
+	// Force overwrites lessons that changed after the bundle was exported
+	Force bool
+}
+
+// ImportItem is the outcome for one lesson of a bundle.
+type ImportItem struct {
+	Slug   string       `json:"slug"`
+	Action ImportAction `json:"action"`
+	Reason string       `json:"reason,omitempty"`
+}
+
+// ImportReport summarizes an import. Conflicting lessons are never written unless forced.
+type ImportReport struct {
+	DryRun    bool         `json:"dryRun"`
+	Created   int          `json:"created"`
+	Updated   int          `json:"updated"`
+	Unchanged int          `json:"unchanged"`
+	Conflicts int          `json:"conflicts"`
+	Items     []ImportItem `json:"items"`
+}
+
+// AssetURLPrefix is the path under which asset contents are served; asset keys are
+// content-addressed ({sha256}{ext}) so URLs never change once uploaded.
+const AssetURLPrefix = "/api/v1/assets/"
+
+// Asset is an uploaded file (image, PDF) that lessons reference by URL.
+type Asset struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Hash        string    `gorm:"size:64;not null;uniqueIndex" json:"hash"`
+	Key         string    `gorm:"size:80;not null" json:"key"`
+	Filename    string    `gorm:"size:255;not null;default:''" json:"filename"`
+	ContentType string    `gorm:"size:100;not null" json:"contentType"`
+	Size        int64     `gorm:"not null" json:"size"`
+	UploadedBy  *uint     `json:"uploadedBy,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	URL         string    `gorm:"-" json:"url"`
+}
+
+// TableName specifies the table name for GORM.
+func (Asset) TableName() string {
+	return "lesson_assets"
+}
+
+// SlugRedirect maps a lesson's former slug to the lesson, so old links keep working after a rename.
+type SlugRedirect struct {
+	OldSlug   string    `gorm:"primaryKey" json:"oldSlug"`
+	LessonID  uint      `gorm:"not null;index" json:"lessonId"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// TableName specifies the table name for GORM.
+func (SlugRedirect) TableName() string {
+	return "lesson_slug_redirects"
+}
+
+// ExerciseTest is a stdin/stdout test case of an exercise. Hidden tests are used for
+// judging but never shown to students.
+type ExerciseTest struct {
+	Input          string `json:"input"`
+	ExpectedOutput string `json:"expectedOutput"`
+	Hidden         bool   `json:"hidden"`
+}
+
+// Exercise is a runnable coding exercise embedded in a lesson, identified by a key
+// that is unique within the lesson (e.g. "sum-two-numbers").
+type Exercise struct {
+	ID          uint                              `gorm:"primaryKey" json:"id"`
+	LessonID    uint                              `gorm:"not null;uniqueIndex:idx_lesson_exercises_lesson_key" json:"-"`
+	Key         string                            `gorm:"size:100;not null;uniqueIndex:idx_lesson_exercises_lesson_key" json:"key"`
+	Position    int                               `gorm:"not null" json:"position"`
+	Title       string                            `gorm:"not null" json:"title"`
+	Prompt      string                            `gorm:"type:text;not null" json:"prompt"`
+	Language    string                            `gorm:"size:20;not null;default:'python'" json:"language"`
+	StarterCode string                            `gorm:"type:text;not null" json:"starterCode"`
+	Tests       datatypes.JSONSlice[ExerciseTest] `gorm:"type:jsonb;not null" json:"tests"`
+	CreatedAt   time.Time                         `json:"createdAt"`
+	UpdatedAt   time.Time                         `json:"updatedAt"`
+}
+
+// TableName specifies the table name for GORM.
+func (Exercise) TableName() string {
+	return "lesson_exercises"
+}
+
+// ExerciseInput declares one exercise in a SetExercisesRequest.
+type ExerciseInput struct {
+	Key         string         `json:"key"`
+	Title       string         `json:"title"`
+	Prompt      string         `json:"prompt"`
+	Language    string         `json:"language"`
+	StarterCode string         `json:"starterCode"`
+	Tests       []ExerciseTest `json:"tests"`
+}
+
+// SetExercisesRequest is the request payload for PUT /api/lessons/{slug}/exercises.
+// It replaces the lesson's exercises; results are kept for exercises whose key is unchanged.
+type SetExercisesRequest struct {
+	Exercises []ExerciseInput `json:"exercises"`
+}
+
+// ExerciseResult is a user's best submission for an exercise.
+type ExerciseResult struct {
+	ID         uint      `gorm:"primaryKey" json:"-"`
+	UserID     uint      `gorm:"not null;uniqueIndex:idx_exercise_results_user_exercise" json:"-"`
+	ExerciseID uint      `gorm:"not null;uniqueIndex:idx_exercise_results_user_exercise" json:"-"`
+	Passed     bool      `gorm:"not null" json:"passed"`
+	BestPassed int       `gorm:"not null" json:"bestPassed"`
+	Total      int       `gorm:"not null" json:"total"`
+	BestCode   string    `gorm:"type:text;not null" json:"bestCode"`
+	Attempts   int       `gorm:"not null" json:"attempts"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// TableName specifies the table name for GORM.
+func (ExerciseResult) TableName() string {
+	return "exercise_results"
+}
+
+// ExerciseView is an exercise as shown in a lesson: hidden tests are removed and the
+// caller's best result is attached when signed in.
+type ExerciseView struct {
+	Key         string          `json:"key"`
+	Title       string          `json:"title"`
+	Prompt      string          `json:"prompt"`
+	Language    string          `json:"language"`
+	StarterCode string          `json:"starterCode"`
+	Examples    []ExerciseTest  `json:"examples"`
+	TestCount   int             `json:"testCount"`
+	Result      *ExerciseResult `json:"result,omitempty"`
+}
+
+// SubmitExerciseRequest is the request payload for POST /api/lessons/{slug}/exercises/{key}/submit.
+type SubmitExerciseRequest struct {
+	Code string `json:"code"`
+}
+
+// ExerciseCaseResult is the outcome of one test case. Input, output and expected output
+// are only included for visible tests.
+type ExerciseCaseResult struct {
+	Passed         bool   `json:"passed"`
+	Hidden         bool   `json:"hidden"`
+	TimedOut       bool   `json:"timedOut"`
+	Input          string `json:"input,omitempty"`
+	ExpectedOutput string `json:"expectedOutput,omitempty"`
+	Stdout         string `json:"stdout,omitempty"`
+	Stderr         string `json:"stderr,omitempty"`
+	DurationMs     int64  `json:"durationMs"`
+}
+
+// ExerciseSubmission is the judged result of a submission plus the user's best result.
+type ExerciseSubmission struct {
+	Passed      bool                 `json:"passed"`
+	PassedCount int                  `json:"passedCount"`
+	Total       int                  `json:"total"`
+	Cases       []ExerciseCaseResult `json:"cases"`
+	Best        *ExerciseResult      `json:"best"`
+}
+
+// Quiz question types.
+const (
+	QuestionMultipleChoice = "multiple_choice"
+	QuestionShortAnswer    = "short_answer"
+)
+
+// QuizQuestion is a question of a lesson quiz. Correct (option indexes) and Answers
+// (accepted short answers) are never sent to students.
+type QuizQuestion struct {
+	ID            string   `json:"id"`
+	Type          string   `json:"type"`
+	Prompt        string   `json:"prompt"`
+	Options       []string `json:"options,omitempty"`
+	Correct       []int    `json:"correct,omitempty"`
+	Answers       []string `json:"answers,omitempty"`
+	CaseSensitive bool     `json:"caseSensitive,omitempty"`
+	Explanation   string   `json:"explanation,omitempty"`
+}
+
+// Quiz is the graded quiz of a lesson. PassingScore is a percentage; when
+// CompletesLesson is set, a passing attempt marks the lesson completed.
+type Quiz struct {
+	ID              uint                              `gorm:"primaryKey" json:"id"`
+	LessonID        uint                              `gorm:"not null;uniqueIndex" json:"-"`
+	PassingScore    int                               `gorm:"not null" json:"passingScore"`
+	CompletesLesson bool                              `gorm:"not null;default:false" json:"completesLesson"`
+	Questions       datatypes.JSONSlice[QuizQuestion] `gorm:"type:jsonb;not null" json:"questions"`
+	CreatedAt       time.Time                         `json:"createdAt"`
+	UpdatedAt       time.Time                         `json:"updatedAt"`
+}
+
+// TableName specifies the table name for GORM.
+func (Quiz) TableName() string {
+	return "lesson_quizzes"
+}
+
+// SetQuizRequest is the request payload for PUT /api/lessons/{slug}/quiz.
+// A zero PassingScore defaults to DefaultPassingScore.
+type SetQuizRequest struct {
+	PassingScore    int            `json:"passingScore"`
+	CompletesLesson bool           `json:"completesLesson"`
+	Questions       []QuizQuestion `json:"questions"`
+}
+
+// QuizAnswer is a student's answer to one question: option indexes for
+// multiple choice, text for short answer.
+type QuizAnswer struct {
+	Choices []int  `json:"choices,omitempty"`
+	Text    string `json:"text,omitempty"`
+}
+
+// SubmitQuizRequest is the request payload for POST /api/lessons/{slug}/quiz/attempts,
+// keyed by question ID.
+type SubmitQuizRequest struct {
+	Answers map[string]QuizAnswer `json:"answers"`
+}
+
+// QuizAttempt is one graded submission of a quiz.
+type QuizAttempt struct {
+	ID        uint                                      `gorm:"primaryKey" json:"id"`
+	QuizID    uint                                      `gorm:"not null;index" json:"-"`
+	UserID    uint                                      `gorm:"not null;index" json:"-"`
+	Score     int                                       `gorm:"not null" json:"score"`
+	Correct   int                                       `gorm:"not null" json:"correct"`
+	Total     int                                       `gorm:"not null" json:"total"`
+	Passed    bool                                      `gorm:"not null" json:"passed"`
+	Answers   datatypes.JSONType[map[string]QuizAnswer] `gorm:"type:jsonb;not null" json:"answers"`
+	CreatedAt time.Time                                 `json:"createdAt"`
+}
+
+// TableName specifies the table name for GORM.
+func (QuizAttempt) TableName() string {
+	return "quiz_attempts"
+}
+
+// QuizQuestionView is a question as shown to students, without its answers.
+type QuizQuestionView struct {
+	ID       string   `json:"id"`
+	Type     string   `json:"type"`
+	Prompt   string   `json:"prompt"`
+	Options  []string `json:"options,omitempty"`
+	Multiple bool     `json:"multiple,omitempty"`
+}
+
+// QuizView is the student-facing quiz of a lesson with the caller's attempt summary.
+type QuizView struct {
+	PassingScore int                `json:"passingScore"`
+	Questions    []QuizQuestionView `json:"questions"`
+	Attempts     int                `json:"attempts"`
+	BestScore    *int               `json:"bestScore,omitempty"`
+	Passed       bool               `json:"passed"`
+}
+
+// QuestionResult reports whether one question was answered correctly. The explanation
+// is only revealed once the attempt passes.
+type QuestionResult struct {
+	ID          string `json:"id"`
+	Correct     bool   `json:"correct"`
+	Explanation string `json:"explanation,omitempty"`
+}
+
+// QuizAttemptResult is the graded response to a quiz submission.
+type QuizAttemptResult struct {
+	*QuizAttempt
+	PassingScore int              `json:"passingScore"`
+	Questions    []QuestionResult `json:"questions"`
+}
+
+// LessonPrerequisite links a lesson to a lesson that should be completed first.
+type LessonPrerequisite struct {
+	LessonID       uint `gorm:"primaryKey"`
+	PrerequisiteID uint `gorm:"primaryKey"`
+	Position       int  `gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM.
+func (LessonPrerequisite) TableName() string {
+	return "lesson_prerequisites"
+}
+
+// SetPrerequisitesRequest is the request payload for PUT /api/lessons/{slug}/prerequisites.
+type SetPrerequisitesRequest struct {
+	LessonSlugs []string `json:"lessonSlugs"`
+}
+
+// PrerequisiteStatus is a prerequisite of a lesson and whether the viewer completed it.
+type PrerequisiteStatus struct {
+	Slug      string `json:"slug"`
+	Title     string `json:"title"`
+	Completed bool   `json:"completed"`
+}
+
+// LessonViewer identifies who is reading a lesson for access checks.
+// UserID is nil for anonymous readers.
+type LessonViewer struct {
+	UserID  *uint
+	Role    string
+	IsAdmin bool
+}
+
+// LessonPreview is a revocable preview link that lets reviewers read an unpublished
+// lesson. Only the preview's ID is stored; the link carries a signed token.
+type LessonPreview struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	LessonID       uint       `gorm:"not null;index" json:"lessonId"`
+	Label          string     `gorm:"not null;default:''" json:"label"`
+	CreatedByID    *uint      `json:"createdById"`
+	CreatedByEmail string     `gorm:"not null;default:''" json:"createdByEmail"`
+	ExpiresAt      time.Time  `gorm:"not null" json:"expiresAt"`
+	RevokedAt      *time.Time `json:"revokedAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+// TableName specifies the table name for GORM.
+func (LessonPreview) TableName() string {
+	return "lesson_previews"
+}
+
+// CreatePreviewRequest is the request payload for POST /api/lessons/{slug}/previews.
+// ExpiresInHours defaults to DefaultPreviewTTL.
+type CreatePreviewRequest struct {
+	Label          string `json:"label"`
+	ExpiresInHours int    `json:"expiresInHours"`
+}
+
+// PreviewLink is a newly created preview together with its token, which is only
+// returned once. Reviewers pass the token as GET /api/lessons/{slug}?preview=.
+type PreviewLink struct {
+	LessonPreview
+	Token string `json:"token"`
+}
+
+// LessonEditor identifies who is changing a lesson for ownership checks.
+// UserID is nil for the legacy admin token.
+type LessonEditor struct {
+	UserID  *uint
+	IsAdmin bool
+}
+
+// ReviewLessonRequest is the request payload for POST /api/lessons/{slug}/review.
+// Approving publishes the lesson, or schedules it when PublishAt is in the future.
+type ReviewLessonRequest struct {
+	Action    string     `json:"action"` // approve or request_changes
+	Note      string     `json:"note"`
+	PublishAt *time.Time `json:"publishAt"`
+}
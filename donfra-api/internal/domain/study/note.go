@@ -0,0 +1,183 @@
+package study
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+
+	"donfra-api/internal/pkg/tracing"
+)
+
+var (
+	// ErrNoteNotFound is returned when a note does not exist or belongs to another user.
+	ErrNoteNotFound = errors.New("note not found")
+	// ErrNoteEmpty is returned when a note has no content.
+	ErrNoteEmpty = errors.New("markdown is required")
+	// ErrNoteTooLong is returned when a note exceeds MaxNoteLength.
+	ErrNoteTooLong = errors.New("note is too long")
+	// ErrInvalidAnchor is returned when an anchor is not a heading id.
+	ErrInvalidAnchor = errors.New("anchor must be a heading id such as \"getting-started\"")
+)
+
+// MaxNoteLength is the maximum number of characters in a note.
+const MaxNoteLength = 20000
+
+// anchorRe matches heading ids as produced for lesson headings
+var anchorRe = regexp.MustCompile(`^[\p{L}\p{N}_-]{1,255}$`)
+
+// ListNotes returns the user's notes, optionally restricted to one lesson, newest first.
+func (s *Service) ListNotes(ctx context.Context, userID uint, lessonSlug string) ([]LessonNote, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.ListNotes",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("lesson_notes"),
+	)
+	defer span.End()
+
+	db := notesQuery(s.db.WithContext(ctx)).Where("lesson_notes.user_id = ?", userID)
+	if lessonSlug != "" {
+		db = db.Where("lessons.slug = ?", lessonSlug)
+	}
+
+	notes := []LessonNote{}
+	if err := db.Order("lesson_notes.updated_at DESC").Find(&notes).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return notes, nil
+}
+
+// GetNote returns one of the user's notes.
+func (s *Service) GetNote(ctx context.Context, userID, noteID uint) (*LessonNote, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.GetNote",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("lesson_notes"),
+	)
+	defer span.End()
+
+	var note LessonNote
+	err := notesQuery(s.db.WithContext(ctx)).
+		Where("lesson_notes.id = ? AND lesson_notes.user_id = ?", noteID, userID).
+		First(&note).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNoteNotFound
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return &note, nil
+}
+
+// CreateNote attaches a private note to a lesson the user can see.
+func (s *Service) CreateNote(ctx context.Context, userID uint, req *CreateNoteRequest, includeUnpublished bool) (*LessonNote, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.CreateNote",
+		tracing.AttrDBOperation.String("INSERT"),
+		tracing.AttrDBTable.String("lesson_notes"),
+		tracing.AttrLessonSlug.String(req.LessonSlug),
+	)
+	defer span.End()
+
+	anchor, err := normalizeAnchor(req.Anchor)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateNote(req.Markdown); err != nil {
+		return nil, err
+	}
+
+	lesson, err := s.getVisibleLesson(ctx, req.LessonSlug, includeUnpublished)
+	if err != nil {
+		return nil, err
+	}
+
+	note := &LessonNote{
+		UserID:   userID,
+		LessonID: lesson.ID,
+		Anchor:   anchor,
+		Markdown: req.Markdown,
+	}
+	if err := s.db.WithContext(ctx).Create(note).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	note.LessonSlug = lesson.Slug
+	return note, nil
+}
+
+// UpdateNote edits one of the user's notes.
+func (s *Service) UpdateNote(ctx context.Context, userID, noteID uint, req *UpdateNoteRequest) (*LessonNote, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.UpdateNote",
+		tracing.AttrDBOperation.String("UPDATE"),
+		tracing.AttrDBTable.String("lesson_notes"),
+	)
+	defer span.End()
+
+	note, err := s.GetNote(ctx, userID, noteID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Anchor != nil {
+		anchor, err := normalizeAnchor(*req.Anchor)
+		if err != nil {
+			return nil, err
+		}
+		note.Anchor = anchor
+	}
+	if req.Markdown != nil {
+		if err := validateNote(*req.Markdown); err != nil {
+			return nil, err
+		}
+		note.Markdown = *req.Markdown
+	}
+
+	if err := s.db.WithContext(ctx).Save(note).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return note, nil
+}
+
+// DeleteNote deletes one of the user's notes.
+func (s *Service) DeleteNote(ctx context.Context, userID, noteID uint) error {
+	res := s.db.WithContext(ctx).Where("id = ? AND user_id = ?", noteID, userID).Delete(&LessonNote{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNoteNotFound
+	}
+	return nil
+}
+
+// notesQuery selects notes together with the slug of their lesson
+func notesQuery(db *gorm.DB) *gorm.DB {
+	return db.Model(&LessonNote{}).
+		Select("lesson_notes.*, lessons.slug AS lesson_slug").
+		Joins("JOIN lessons ON lessons.id = lesson_notes.lesson_id")
+}
+
+// normalizeAnchor accepts "heading-id" or "#heading-id"; empty means the note is not anchored
+func normalizeAnchor(anchor string) (string, error) {
+	anchor = strings.TrimPrefix(strings.TrimSpace(anchor), "#")
+	if anchor == "" {
+		return "", nil
+	}
+	if !anchorRe.MatchString(anchor) {
+		return "", ErrInvalidAnchor
+	}
+	return anchor, nil
+}
+
+func validateNote(markdown string) error {
+	if strings.TrimSpace(markdown) == "" {
+		return ErrNoteEmpty
+	}
+	if utf8.RuneCountInString(markdown) > MaxNoteLength {
+		return ErrNoteTooLong
+	}
+	return nil
+}
@@ -0,0 +1,283 @@
+package study
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"donfra-api/internal/pkg/tracing"
+)
+
+var (
+	// ErrInvalidPrerequisites is returned for duplicate, self-referencing or cyclic prerequisites.
+	ErrInvalidPrerequisites = errors.New("invalid prerequisites")
+	// ErrInvalidRequiredRole is returned when requiredRole is not a known user role.
+	ErrInvalidRequiredRole = errors.New("requiredRole must be empty, user, mentor or admin")
+	// ErrLessonLocked is wrapped by every LessonAccessError.
+	ErrLessonLocked = errors.New("lesson is locked")
+)
+
+// prerequisitesLockKey is the Postgres advisory lock serializing prerequisite changes,
+// so two concurrent updates cannot each pass the cycle check and together form a cycle.
+// The key is "donfra" in ASCII followed by 01.
+const prerequisitesLockKey = 0x646f6e66726101
+
+// Reasons reported by LessonAccessError.
+const (
+	AccessLoginRequired           = "login_required"
+	AccessRoleRequired            = "role_required"
+	AccessPrerequisitesIncomplete = "prerequisites_incomplete"
+)
+
+// LessonAccessError explains why a viewer may not read a gated lesson.
+type LessonAccessError struct {
+	Reason       string
+	RequiredRole string
+	Missing      []PrerequisiteStatus
+}
+
+func (e *LessonAccessError) Error() string {
+	switch e.Reason {
+	case AccessLoginRequired:
+		return "sign in to access this lesson"
+	case AccessRoleRequired:
+		return fmt.Sprintf("this lesson is only available to %s users", e.RequiredRole)
+	default:
+		slugs := make([]string, len(e.Missing))
+		for i, p := range e.Missing {
+			slugs[i] = p.Slug
+		}
+		return "complete the prerequisites first: " + strings.Join(slugs, ", ")
+	}
+}
+
+func (e *LessonAccessError) Unwrap() error {
+	return ErrLessonLocked
+}
+
+// NormalizeRequiredRole validates a lesson's requiredRole ("" means no restriction).
+func NormalizeRequiredRole(role string) (string, error) {
+	role = strings.ToLower(strings.TrimSpace(role))
+	switch role {
+	case "", "user", "mentor", "admin":
+		return role, nil
+	}
+	return "", ErrInvalidRequiredRole
+}
+
+// HasRequiredRole reports whether the viewer satisfies a lesson's requiredRole.
+// Admins always pass; "user" only requires signing in.
+func (v LessonViewer) HasRequiredRole(role string) bool {
+	if role == "" || v.IsAdmin {
+		return true
+	}
+	return v.UserID != nil && (role == "user" || v.Role == role)
+}
+
+// CheckLessonAccess reports whether viewer may read lesson given the viewer's status on
+// its prerequisites, returning a *LessonAccessError when not. Admins always pass;
+// "user" only requires signing in.
+func CheckLessonAccess(lesson *Lesson, viewer LessonViewer, prerequisites []PrerequisiteStatus) error {
+	if viewer.IsAdmin {
+		return nil
+	}
+
+	if role := lesson.RequiredRole; !viewer.HasRequiredRole(role) {
+		if viewer.UserID == nil {
+			return &LessonAccessError{Reason: AccessLoginRequired, RequiredRole: role}
+		}
+		return &LessonAccessError{Reason: AccessRoleRequired, RequiredRole: role}
+	}
+
+	if lesson.RequirePrerequisites {
+		var missing []PrerequisiteStatus
+		for _, p := range prerequisites {
+			if !p.Completed {
+				missing = append(missing, p)
+			}
+		}
+		if len(missing) > 0 {
+			if viewer.UserID == nil {
+				return &LessonAccessError{Reason: AccessLoginRequired, Missing: missing}
+			}
+			return &LessonAccessError{Reason: AccessPrerequisitesIncomplete, Missing: missing}
+		}
+	}
+	return nil
+}
+
+// getAccessibleLesson is getVisibleLesson for the per-lesson features of students
+// (quizzes, exercises, comments, completing a lesson): the viewer must also pass
+// CheckLessonAccess, so gated content is not reachable besides GET /lessons/{slug}.
+// Unpublished lessons are only visible to admins; owners skip the gate, as when reading.
+func (s *Service) getAccessibleLesson(ctx context.Context, slug string, viewer LessonViewer) (*Lesson, error) {
+	lesson, err := s.getVisibleLesson(ctx, slug, viewer.IsAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if viewer.IsAdmin || (viewer.UserID != nil && lesson.OwnerID != nil && *lesson.OwnerID == *viewer.UserID) {
+		return lesson, nil
+	}
+	var prerequisites []PrerequisiteStatus
+	if lesson.RequirePrerequisites {
+		if prerequisites, err = s.GetLessonPrerequisites(ctx, lesson.ID, viewer.UserID, false); err != nil {
+			return nil, err
+		}
+	}
+	if err := CheckLessonAccess(lesson, viewer, prerequisites); err != nil {
+		return nil, err
+	}
+	return lesson, nil
+}
+
+// GetLessonPrerequisites returns the prerequisites of a lesson in order, with whether
+// the user completed each one. Unpublished prerequisites are left out unless
+// includeUnpublished, so they never lock a lesson for students.
+func (s *Service) GetLessonPrerequisites(ctx context.Context, lessonID uint, userID *uint, includeUnpublished bool) ([]PrerequisiteStatus, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.GetLessonPrerequisites",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("lesson_prerequisites"),
+	)
+	defer span.End()
+
+	var viewer uint
+	if userID != nil {
+		viewer = *userID
+	}
+	db := s.db.WithContext(ctx)
+	if !includeUnpublished {
+		db = wherePublished(db, "l", time.Now(), true)
+	}
+	prerequisites := []PrerequisiteStatus{}
+	if err := db.Table("lesson_prerequisites lp").
+		Select("l.slug, l.title, COALESCE(pr.status = ?, FALSE) AS completed", ProgressCompleted).
+		Joins("JOIN lessons l ON l.id = lp.prerequisite_id").
+		Joins("LEFT JOIN lesson_progress pr ON pr.lesson_id = lp.prerequisite_id AND pr.user_id = ?", viewer).
+		Where("lp.lesson_id = ?", lessonID).
+		Order("lp.position ASC").
+		Scan(&prerequisites).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return prerequisites, nil
+}
+
+// SetLessonPrerequisites replaces the ordered prerequisites of a lesson. A lesson cannot
+// require itself or any lesson that (transitively) requires it. The editor must pass
+// CheckLessonEditable.
+func (s *Service) SetLessonPrerequisites(ctx context.Context, slug string, lessonSlugs []string, editor LessonEditor) ([]PrerequisiteStatus, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.SetLessonPrerequisites",
+		tracing.AttrDBOperation.String("UPDATE"),
+		tracing.AttrDBTable.String("lesson_prerequisites"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	var lessonID uint
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", prerequisitesLockKey).Error; err != nil {
+			return err
+		}
+		lesson, err := lockEditableLesson(tx, slug, editor)
+		if err != nil {
+			return err
+		}
+		lessonID = lesson.ID
+
+		ids, err := resolvePrerequisites(tx, lesson, lessonSlugs)
+		if err != nil {
+			return err
+		}
+		if err := checkPrerequisiteCycle(tx, lesson.ID, ids); err != nil {
+			return err
+		}
+
+		if err := tx.Where("lesson_id = ?", lesson.ID).Delete(&LessonPrerequisite{}).Error; err != nil {
+			return err
+		}
+		if len(ids) > 0 {
+			rows := make([]LessonPrerequisite, len(ids))
+			for i, id := range ids {
+				rows[i] = LessonPrerequisite{LessonID: lesson.ID, PrerequisiteID: id, Position: i + 1}
+			}
+			if err := tx.Create(&rows).Error; err != nil {
+				return err
+			}
+		}
+		return touchLessons(tx, lesson.ID)
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	s.invalidateLessons(ctx, slug)
+	return s.GetLessonPrerequisites(ctx, lessonID, nil, true)
+}
+
+// resolvePrerequisites maps prerequisite slugs to lesson IDs, keeping their order
+func resolvePrerequisites(tx *gorm.DB, lesson *Lesson, lessonSlugs []string) ([]uint, error) {
+	seen := make(map[string]bool, len(lessonSlugs))
+	for _, slug := range lessonSlugs {
+		switch {
+		case slug == lesson.Slug:
+			return nil, fmt.Errorf("%w: a lesson cannot require itself", ErrInvalidPrerequisites)
+		case seen[slug]:
+			return nil, fmt.Errorf("%w: %s is listed twice", ErrInvalidPrerequisites, slug)
+		}
+		seen[slug] = true
+	}
+
+	var lessons []Lesson
+	if len(lessonSlugs) > 0 {
+		if err := tx.Select("id", "slug").Where("slug IN ?", lessonSlugs).Find(&lessons).Error; err != nil {
+			return nil, err
+		}
+	}
+	bySlug := make(map[string]uint, len(lessons))
+	for _, l := range lessons {
+		bySlug[l.Slug] = l.ID
+	}
+
+	ids := make([]uint, len(lessonSlugs))
+	for i, slug := range lessonSlugs {
+		id, ok := bySlug[slug]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownLesson, slug)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// checkPrerequisiteCycle rejects prerequisites that already depend on lessonID. The
+// caller must hold prerequisitesLockKey so the edges cannot change before it commits.
+func checkPrerequisiteCycle(tx *gorm.DB, lessonID uint, prerequisiteIDs []uint) error {
+	var edges []LessonPrerequisite
+	if err := tx.Where("lesson_id <> ?", lessonID).Find(&edges).Error; err != nil {
+		return err
+	}
+	requires := make(map[uint][]uint, len(edges))
+	for _, e := range edges {
+		requires[e.LessonID] = append(requires[e.LessonID], e.PrerequisiteID)
+	}
+
+	visited := map[uint]bool{}
+	queue := append([]uint(nil), prerequisiteIDs...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if id == lessonID {
+			return fmt.Errorf("%w: prerequisites would form a cycle", ErrInvalidPrerequisites)
+		}
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		queue = append(queue, requires[id]...)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package study_test
+
+import (
+	"errors"
+	"testing"
+
+	"donfra-api/internal/domain/study"
+)
+
+func TestCheckLessonAccess(t *testing.T) {
+	uid := uint(1)
+	anonymous := study.LessonViewer{}
+	member := study.LessonViewer{UserID: &uid, Role: "user"}
+	mentor := study.LessonViewer{UserID: &uid, Role: "mentor"}
+	admin := study.LessonViewer{IsAdmin: true}
+
+	done := []study.PrerequisiteStatus{{Slug: "a", Completed: true}}
+	pending := []study.PrerequisiteStatus{{Slug: "a", Completed: true}, {Slug: "b"}}
+
+	tests := []struct {
+		name    string
+		lesson  study.Lesson
+		viewer  study.LessonViewer
+		prereqs []study.PrerequisiteStatus
+		reason  string
+	}{
+		{"open lesson", study.Lesson{}, anonymous, pending, ""},
+		{"prerequisites not enforced", study.Lesson{}, member, pending, ""},
+		{"user role needs sign in", study.Lesson{RequiredRole: "user"}, anonymous, nil, study.AccessLoginRequired},
+		{"user role", study.Lesson{RequiredRole: "user"}, member, nil, ""},
+		{"mentor content", study.Lesson{RequiredRole: "mentor"}, member, nil, study.AccessRoleRequired},
+		{"mentor", study.Lesson{RequiredRole: "mentor"}, mentor, nil, ""},
+		{"admin bypass", study.Lesson{RequiredRole: "mentor", RequirePrerequisites: true}, admin, pending, ""},
+		{"prerequisites done", study.Lesson{RequirePrerequisites: true}, member, done, ""},
+		{"prerequisites pending", study.Lesson{RequirePrerequisites: true}, member, pending, study.AccessPrerequisitesIncomplete},
+		{"prerequisites anonymous", study.Lesson{RequirePrerequisites: true}, anonymous, pending, study.AccessLoginRequired},
+	}
+	for _, tt := range tests {
+		err := study.CheckLessonAccess(&tt.lesson, tt.viewer, tt.prereqs)
+		if tt.reason == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+			continue
+		}
+		var locked *study.LessonAccessError
+		if !errors.As(err, &locked) || !errors.Is(err, study.ErrLessonLocked) {
+			t.Errorf("%s: expected LessonAccessError, got %v", tt.name, err)
+			continue
+		}
+		if locked.Reason != tt.reason {
+			t.Errorf("%s: expected reason %s, got %s", tt.name, tt.reason, locked.Reason)
+		}
+		if tt.reason == study.AccessPrerequisitesIncomplete && (len(locked.Missing) != 1 || locked.Missing[0].Slug != "b") {
+			t.Errorf("%s: unexpected missing prerequisites %+v", tt.name, locked.Missing)
+		}
+	}
+}
+
+func TestLessonViewer_HasRequiredRole(t *testing.T) {
+	uid := uint(1)
+	anonymous := study.LessonViewer{}
+	member := study.LessonViewer{UserID: &uid, Role: "user"}
+	mentor := study.LessonViewer{UserID: &uid, Role: "mentor"}
+	admin := study.LessonViewer{IsAdmin: true}
+
+	tests := []struct {
+		viewer study.LessonViewer
+		role   string
+		want   bool
+	}{
+		{anonymous, "", true},
+		{anonymous, "user", false},
+		{member, "user", true},
+		{member, "mentor", false},
+		{mentor, "mentor", true},
+		{mentor, "admin", false},
+		{admin, "mentor", true},
+	}
+	for _, tt := range tests {
+		if got := tt.viewer.HasRequiredRole(tt.role); got != tt.want {
+			t.Errorf("%+v.HasRequiredRole(%q) = %v, want %v", tt.viewer, tt.role, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeRequiredRole(t *testing.T) {
+	if role, err := study.NormalizeRequiredRole(" Mentor "); err != nil || role != "mentor" {
+		t.Errorf("expected mentor, got %q, %v", role, err)
+	}
+	if _, err := study.NormalizeRequiredRole("superuser"); !errors.Is(err, study.ErrInvalidRequiredRole) {
+		t.Errorf("expected ErrInvalidRequiredRole, got %v", err)
+	}
+}
@@ -0,0 +1,203 @@
+package study
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+
+	"donfra-api/internal/pkg/tracing"
+)
+
+var (
+	// ErrInvalidPreview is returned for preview tokens that are malformed, expired,
+	// revoked or issued for another lesson.
+	ErrInvalidPreview        = errors.New("invalid or expired preview link")
+	ErrPreviewNotFound       = errors.New("preview not found")
+	ErrInvalidPreviewRequest = errors.New("invalid preview request")
+)
+
+const (
+	// DefaultPreviewTTL is how long a preview link is valid when no expiry is requested.
+	DefaultPreviewTTL = 7 * 24 * time.Hour
+	// MaxPreviewTTL is the longest expiry a preview link may be created with.
+	MaxPreviewTTL = 30 * 24 * time.Hour
+
+	previewSubject = "lesson_preview"
+)
+
+// previewClaims are the JWT claims of a preview token. The registered ID is the
+// LessonPreview row, which is checked on every use so links can be revoked.
+type previewClaims struct {
+	LessonID uint `json:"lesson_id"`
+	jwt.RegisteredClaims
+}
+
+// SignPreviewToken creates the token of a preview link.
+func SignPreviewToken(secret []byte, previewID, lessonID uint, expiresAt time.Time) (string, error) {
+	claims := previewClaims{
+		LessonID: lessonID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        strconv.FormatUint(uint64(previewID), 10),
+			Subject:   previewSubject,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			Issuer:    "donfra-api",
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+}
+
+// ParsePreviewToken verifies the signature and expiry of a preview token and returns
+// the preview and lesson it was issued for.
+func ParsePreviewToken(secret []byte, token string, now time.Time) (previewID, lessonID uint, err error) {
+	parsed, err := jwt.ParseWithClaims(token, &previewClaims{}, func(t *jwt.Token) (interface{}, error) {
+		return secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithTimeFunc(func() time.Time { return now }))
+	if err != nil {
+		return 0, 0, ErrInvalidPreview
+	}
+	claims, ok := parsed.Claims.(*previewClaims)
+	if !ok || !parsed.Valid || claims.Subject != previewSubject || claims.LessonID == 0 {
+		return 0, 0, ErrInvalidPreview
+	}
+	id, err := strconv.ParseUint(claims.ID, 10, 64)
+	if err != nil || id == 0 {
+		return 0, 0, ErrInvalidPreview
+	}
+	return uint(id), claims.LessonID, nil
+}
+
+// CreateLessonPreview creates a preview link for a lesson. The token is only returned
+// here; the stored preview is what makes it revocable.
+func (s *Service) CreateLessonPreview(ctx context.Context, slug string, req *CreatePreviewRequest, author RevisionAuthor) (*PreviewLink, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.CreateLessonPreview",
+		tracing.AttrDBOperation.String("INSERT"),
+		tracing.AttrDBTable.String("lesson_previews"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	ttl := DefaultPreviewTTL
+	if req.ExpiresInHours != 0 {
+		ttl = time.Duration(req.ExpiresInHours) * time.Hour
+		if ttl <= 0 || ttl > MaxPreviewTTL {
+			return nil, fmt.Errorf("%w: expiresInHours must be between 1 and %d", ErrInvalidPreviewRequest, int(MaxPreviewTTL/time.Hour))
+		}
+	}
+	label := strings.TrimSpace(req.Label)
+	if len(label) > 200 {
+		return nil, fmt.Errorf("%w: label is too long", ErrInvalidPreviewRequest)
+	}
+
+	lesson, err := s.GetLessonBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := LessonPreview{
+		LessonID:       lesson.ID,
+		Label:          label,
+		CreatedByID:    author.UserID,
+		CreatedByEmail: author.Email,
+		ExpiresAt:      time.Now().Add(ttl).Truncate(time.Second),
+	}
+	if err := s.db.WithContext(ctx).Create(&preview).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	token, err := SignPreviewToken(s.previewSecret, preview.ID, lesson.ID, preview.ExpiresAt)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return &PreviewLink{LessonPreview: preview, Token: token}, nil
+}
+
+// ListLessonPreviews returns the preview links of a lesson, newest first, including
+// revoked and expired ones.
+func (s *Service) ListLessonPreviews(ctx context.Context, slug string) ([]LessonPreview, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.ListLessonPreviews",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("lesson_previews"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	lesson, err := s.GetLessonBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	previews := []LessonPreview{}
+	if err := s.db.WithContext(ctx).Where("lesson_id = ?", lesson.ID).Order("id DESC").Find(&previews).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return previews, nil
+}
+
+// RevokeLessonPreview revokes a preview link of a lesson. Revoking twice is a no-op.
+func (s *Service) RevokeLessonPreview(ctx context.Context, slug string, previewID uint) error {
+	ctx, span := tracing.StartSpan(ctx, "study.RevokeLessonPreview",
+		tracing.AttrDBOperation.String("UPDATE"),
+		tracing.AttrDBTable.String("lesson_previews"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	lesson, err := s.GetLessonBySlug(ctx, slug)
+	if err != nil {
+		return err
+	}
+	var preview LessonPreview
+	db := s.db.WithContext(ctx)
+	if err := db.Where("id = ? AND lesson_id = ?", previewID, lesson.ID).First(&preview).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPreviewNotFound
+		}
+		tracing.RecordError(span, err)
+		return err
+	}
+	if preview.RevokedAt != nil {
+		return nil
+	}
+	if err := db.Model(&preview).Update("revoked_at", time.Now()).Error; err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	return nil
+}
+
+// CheckLessonPreview reports whether token is a valid, unrevoked preview link for lesson.
+func (s *Service) CheckLessonPreview(ctx context.Context, lesson *Lesson, token string) error {
+	ctx, span := tracing.StartSpan(ctx, "study.CheckLessonPreview",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("lesson_previews"),
+		tracing.AttrLessonSlug.String(lesson.Slug),
+	)
+	defer span.End()
+
+	previewID, lessonID, err := ParsePreviewToken(s.previewSecret, token, time.Now())
+	if err != nil || lessonID != lesson.ID {
+		return ErrInvalidPreview
+	}
+	var preview LessonPreview
+	if err := s.db.WithContext(ctx).Select("id", "revoked_at").
+		Where("id = ? AND lesson_id = ?", previewID, lesson.ID).
+		First(&preview).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidPreview
+		}
+		tracing.RecordError(span, err)
+		return err
+	}
+	if preview.RevokedAt != nil {
+		return ErrInvalidPreview
+	}
+	return nil
+}
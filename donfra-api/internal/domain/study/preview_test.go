@@ -0,0 +1,47 @@
+package study_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"donfra-api/internal/domain/study"
+)
+
+func TestPreviewTokenRoundTrip(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Now()
+
+	token, err := study.SignPreviewToken(secret, 12, 34, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	previewID, lessonID, err := study.ParsePreviewToken(secret, token, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if previewID != 12 || lessonID != 34 {
+		t.Errorf("expected preview 12 for lesson 34, got %d for %d", previewID, lessonID)
+	}
+}
+
+func TestParsePreviewTokenRejects(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Now()
+	valid, _ := study.SignPreviewToken(secret, 1, 2, now.Add(time.Hour))
+	expired, _ := study.SignPreviewToken(secret, 1, 2, now.Add(-time.Minute))
+	otherSecret, _ := study.SignPreviewToken([]byte("other"), 1, 2, now.Add(time.Hour))
+
+	for name, token := range map[string]string{
+		"expired":      expired,
+		"other secret": otherSecret,
+		"tampered":     valid[:len(valid)-2] + "xx",
+		"garbage":      "not-a-token",
+		"empty":        "",
+	} {
+		if _, _, err := study.ParsePreviewToken(secret, token, now); !errors.Is(err, study.ErrInvalidPreview) {
+			t.Errorf("%s: expected ErrInvalidPreview, got %v", name, err)
+		}
+	}
+}
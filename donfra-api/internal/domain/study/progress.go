@@ -0,0 +1,175 @@
+package study
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"donfra-api/internal/pkg/tracing"
+)
+
+var (
+	// ErrInvalidProgressStatus is returned for a status other than in_progress or completed.
+	ErrInvalidProgressStatus = errors.New("status must be in_progress or completed")
+	// ErrLastPositionTooLong is returned when lastPosition exceeds MaxLastPositionLength.
+	ErrLastPositionTooLong = errors.New("lastPosition is too long")
+)
+
+// MaxLastPositionLength is the maximum length of a stored reading position.
+const MaxLastPositionLength = 255
+
+// GetLessonProgress returns the user's progress in a lesson, or nil if they have not started it.
+func (s *Service) GetLessonProgress(ctx context.Context, userID, lessonID uint) (*LessonProgress, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.GetLessonProgress",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("lesson_progress"),
+	)
+	defer span.End()
+
+	var progress LessonProgress
+	err := s.db.WithContext(ctx).Where("user_id = ? AND lesson_id = ?", userID, lessonID).First(&progress).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return &progress, nil
+}
+
+// UpdateLessonProgress records the user's status and reading position in a lesson.
+// Unpublished lessons are only accepted from admins, and a lesson can only be marked
+// completed by a viewer who passes CheckLessonAccess, since completing it unlocks the
+// lessons that require it.
+func (s *Service) UpdateLessonProgress(ctx context.Context, userID uint, slug string, req *UpdateProgressRequest, viewer LessonViewer) (*LessonProgress, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.UpdateLessonProgress",
+		tracing.AttrDBOperation.String("UPSERT"),
+		tracing.AttrDBTable.String("lesson_progress"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	status := strings.TrimSpace(req.Status)
+	if status != "" && status != ProgressInProgress && status != ProgressCompleted {
+		return nil, ErrInvalidProgressStatus
+	}
+	position := strings.TrimSpace(req.LastPosition)
+	if utf8.RuneCountInString(position) > MaxLastPositionLength {
+		return nil, ErrLastPositionTooLong
+	}
+
+	var (
+		lesson *Lesson
+		err    error
+	)
+	if status == ProgressCompleted {
+		lesson, err = s.getAccessibleLesson(ctx, slug, viewer)
+	} else {
+		lesson, err = s.getVisibleLesson(ctx, slug, viewer.IsAdmin)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := s.GetLessonProgress(ctx, userID, lesson.ID)
+	if err != nil {
+		return nil, err
+	}
+	if progress == nil {
+		progress = &LessonProgress{UserID: userID, LessonID: lesson.ID, Status: ProgressInProgress}
+	}
+
+	if status != "" && status != progress.Status {
+		progress.Status = status
+		progress.CompletedAt = nil
+		if status == ProgressCompleted {
+			now := time.Now()
+			progress.CompletedAt = &now
+		}
+	}
+	if position != "" {
+		progress.LastPosition = position
+	}
+
+	db := s.db.WithContext(ctx)
+	if progress.ID != 0 {
+		err = db.Save(progress).Error
+	} else {
+		// A concurrent first visit may have inserted the row already
+		err = db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "lesson_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"status", "last_position", "completed_at", "updated_at"}),
+		}).Create(progress).Error
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return progress, nil
+}
+
+// GetProgressSummary returns the user's lesson progress and completion per track.
+// Only published lessons and tracks are counted.
+func (s *Service) GetProgressSummary(ctx context.Context, userID uint) (*ProgressSummary, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.GetProgressSummary",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("lesson_progress"),
+		tracing.AttrUserID.Int(int(userID)),
+	)
+	defer span.End()
+
+	summary := &ProgressSummary{
+		Tracks:  []TrackProgress{},
+		Lessons: []LessonProgressEntry{},
+	}
+
+	now := time.Now()
+	if err := wherePublished(s.db.WithContext(ctx), "l", now, true).
+		Table("lesson_progress lp").
+		Select("l.slug, l.title, lp.status, lp.last_position, lp.completed_at, lp.updated_at").
+		Joins("JOIN lessons l ON l.id = lp.lesson_id").
+		Where("lp.user_id = ?", userID).
+		Order("lp.updated_at DESC").
+		Scan(&summary.Lessons).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	for _, l := range summary.Lessons {
+		switch l.Status {
+		case ProgressCompleted:
+			summary.Completed++
+		case ProgressInProgress:
+			summary.InProgress++
+		}
+	}
+
+	// Tracks the user has started, with completion counted over the track's published lessons
+	if err := s.db.WithContext(ctx).
+		Table("tracks t").
+		Select(`t.slug, t.title, COUNT(tl.lesson_id) AS total,
+			COUNT(lp.id) FILTER (WHERE lp.status = ?) AS completed`, ProgressCompleted).
+		Joins("JOIN track_lessons tl ON tl.track_id = t.id").
+		Joins("JOIN lessons l ON l.id = tl.lesson_id AND "+publishedCondition("l"), now, now).
+		Joins("LEFT JOIN lesson_progress lp ON lp.lesson_id = tl.lesson_id AND lp.user_id = ?", userID).
+		Where("t.is_published = ?", true).
+		Group("t.id, t.slug, t.title").
+		Having("COUNT(lp.id) > 0").
+		Order("t.title ASC").
+		Scan(&summary.Tracks).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	for i := range summary.Tracks {
+		if summary.Tracks[i].Total > 0 {
+			summary.Tracks[i].Percent = summary.Tracks[i].Completed * 100 / summary.Tracks[i].Total
+		}
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,482 @@
+package study
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"donfra-api/internal/pkg/tracing"
+)
+
+var (
+	ErrQuizNotFound    = errors.New("quiz not found")
+	ErrInvalidQuiz     = errors.New("invalid quiz")
+	ErrQuizRateLimited = errors.New("too many quiz attempts, please slow down")
+)
+
+const (
+	// DefaultPassingScore is the passing percentage used when a quiz does not set one.
+	DefaultPassingScore = 70
+	// MaxQuizQuestions is the maximum number of questions of a quiz.
+	MaxQuizQuestions = 50
+	// MaxQuizAttempts is the number of attempts returned in a user's history.
+	MaxQuizAttempts = 50
+	// MaxQuizAnswerLength is the maximum number of characters in a short answer.
+	MaxQuizAnswerLength = 1000
+	// MaxQuizSubmissionSize is the maximum size of a quiz attempt request body in bytes.
+	MaxQuizSubmissionSize = 256 << 10
+	// QuizAttemptRateLimit is the number of attempts a user may submit per QuizAttemptRateWindow.
+	QuizAttemptRateLimit = 10
+	// QuizAttemptRateWindow is the sliding window for QuizAttemptRateLimit.
+	QuizAttemptRateWindow = time.Minute
+)
+
+// quizRateLockClass is the first key of the per-user Postgres advisory lock taken
+// while checking QuizAttemptRateLimit; the second key is the user's ID. It is "qz"
+// in ASCII.
+const quizRateLockClass = 0x717a
+
+// ValidateQuiz checks and normalizes a SetQuizRequest. Questions without an ID are
+// numbered "q1", "q2", ... by position.
+func ValidateQuiz(req *SetQuizRequest) (*SetQuizRequest, error) {
+	out := &SetQuizRequest{PassingScore: req.PassingScore, CompletesLesson: req.CompletesLesson}
+	if out.PassingScore == 0 {
+		out.PassingScore = DefaultPassingScore
+	}
+	if out.PassingScore < 1 || out.PassingScore > 100 {
+		return nil, fmt.Errorf("%w: passingScore must be between 1 and 100", ErrInvalidQuiz)
+	}
+	if len(req.Questions) == 0 || len(req.Questions) > MaxQuizQuestions {
+		return nil, fmt.Errorf("%w: between 1 and %d questions are required", ErrInvalidQuiz, MaxQuizQuestions)
+	}
+
+	seen := make(map[string]bool, len(req.Questions))
+	for i, q := range req.Questions {
+		q.ID = strings.TrimSpace(q.ID)
+		if q.ID == "" {
+			q.ID = fmt.Sprintf("q%d", i+1)
+		}
+		q.Prompt = strings.TrimSpace(q.Prompt)
+		if seen[q.ID] {
+			return nil, fmt.Errorf("%w: duplicate question id %q", ErrInvalidQuiz, q.ID)
+		}
+		if q.Prompt == "" {
+			return nil, fmt.Errorf("%w: %s: prompt is required", ErrInvalidQuiz, q.ID)
+		}
+
+		switch q.Type {
+		case QuestionMultipleChoice:
+			if len(q.Options) < 2 {
+				return nil, fmt.Errorf("%w: %s: at least 2 options are required", ErrInvalidQuiz, q.ID)
+			}
+			q.Correct = uniqueSorted(q.Correct)
+			if len(q.Correct) == 0 {
+				return nil, fmt.Errorf("%w: %s: at least one correct option is required", ErrInvalidQuiz, q.ID)
+			}
+			for _, c := range q.Correct {
+				if c < 0 || c >= len(q.Options) {
+					return nil, fmt.Errorf("%w: %s: correct option %d is out of range", ErrInvalidQuiz, q.ID, c)
+				}
+			}
+			q.Answers = nil
+		case QuestionShortAnswer:
+			var answers []string
+			for _, a := range q.Answers {
+				if a = strings.TrimSpace(a); a != "" {
+					answers = append(answers, a)
+				}
+			}
+			if len(answers) == 0 {
+				return nil, fmt.Errorf("%w: %s: at least one accepted answer is required", ErrInvalidQuiz, q.ID)
+			}
+			q.Answers = answers
+			q.Options, q.Correct = nil, nil
+		default:
+			return nil, fmt.Errorf("%w: %s: type must be %s or %s", ErrInvalidQuiz, q.ID, QuestionMultipleChoice, QuestionShortAnswer)
+		}
+
+		seen[q.ID] = true
+		out.Questions = append(out.Questions, q)
+	}
+	return out, nil
+}
+
+// ValidateQuizAnswers keeps the answers to questions of the quiz, each reduced to the
+// part its question type uses. Choices must be options of the question and short
+// answers at most MaxQuizAnswerLength characters.
+func ValidateQuizAnswers(questions []QuizQuestion, answers map[string]QuizAnswer) (map[string]QuizAnswer, error) {
+	out := make(map[string]QuizAnswer, len(questions))
+	for _, q := range questions {
+		a, ok := answers[q.ID]
+		if !ok {
+			continue
+		}
+		switch q.Type {
+		case QuestionMultipleChoice:
+			if len(a.Choices) > len(q.Options) {
+				return nil, fmt.Errorf("%w: %s: at most %d choices are allowed", ErrInvalidQuiz, q.ID, len(q.Options))
+			}
+			for _, c := range a.Choices {
+				if c < 0 || c >= len(q.Options) {
+					return nil, fmt.Errorf("%w: %s: choice %d is out of range", ErrInvalidQuiz, q.ID, c)
+				}
+			}
+			out[q.ID] = QuizAnswer{Choices: a.Choices}
+		case QuestionShortAnswer:
+			if utf8.RuneCountInString(a.Text) > MaxQuizAnswerLength {
+				return nil, fmt.Errorf("%w: %s: answer must be at most %d characters", ErrInvalidQuiz, q.ID, MaxQuizAnswerLength)
+			}
+			out[q.ID] = QuizAnswer{Text: a.Text}
+		}
+	}
+	return out, nil
+}
+
+// GradeQuiz grades answers against the questions and returns the number answered
+// correctly. Multiple choice answers must select exactly the correct options;
+// short answers are compared ignoring surrounding and repeated whitespace (and case,
+// unless the question is case sensitive).
+func GradeQuiz(questions []QuizQuestion, answers map[string]QuizAnswer) (int, []QuestionResult) {
+	correct := 0
+	results := make([]QuestionResult, len(questions))
+	for i, q := range questions {
+		answer := answers[q.ID]
+		ok := false
+		switch q.Type {
+		case QuestionMultipleChoice:
+			ok = equalInts(uniqueSorted(answer.Choices), uniqueSorted(q.Correct))
+		case QuestionShortAnswer:
+			given := normalizeShortAnswer(answer.Text, q.CaseSensitive)
+			for _, accepted := range q.Answers {
+				if given != "" && given == normalizeShortAnswer(accepted, q.CaseSensitive) {
+					ok = true
+					break
+				}
+			}
+		}
+		if ok {
+			correct++
+		}
+		results[i] = QuestionResult{ID: q.ID, Correct: ok}
+	}
+	return correct, results
+}
+
+// NewQuizView builds the student-facing view of a quiz from the caller's attempts.
+func NewQuizView(quiz *Quiz, attempts []QuizAttempt) *QuizView {
+	view := &QuizView{
+		PassingScore: quiz.PassingScore,
+		Questions:    make([]QuizQuestionView, len(quiz.Questions)),
+		Attempts:     len(attempts),
+	}
+	for i, q := range quiz.Questions {
+		view.Questions[i] = QuizQuestionView{
+			ID:       q.ID,
+			Type:     q.Type,
+			Prompt:   q.Prompt,
+			Options:  q.Options,
+			Multiple: len(q.Correct) > 1,
+		}
+	}
+	for _, a := range attempts {
+		if view.BestScore == nil || a.Score > *view.BestScore {
+			score := a.Score
+			view.BestScore = &score
+		}
+		view.Passed = view.Passed || a.Passed
+	}
+	return view
+}
+
+// GetQuiz returns the quiz of a lesson including answers (admin view).
+func (s *Service) GetQuiz(ctx context.Context, slug string) (*Quiz, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.GetQuiz",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("lesson_quizzes"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	lesson, err := s.GetLessonBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	return s.getQuiz(ctx, lesson.ID)
+}
+
+// SetQuiz creates or replaces the quiz of a lesson. Earlier attempts are kept.
+// The editor must pass CheckLessonEditable.
+func (s *Service) SetQuiz(ctx context.Context, slug string, req *SetQuizRequest, editor LessonEditor) (*Quiz, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.SetQuiz",
+		tracing.AttrDBOperation.String("UPSERT"),
+		tracing.AttrDBTable.String("lesson_quizzes"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	valid, err := ValidateQuiz(req)
+	if err != nil {
+		return nil, err
+	}
+
+	quiz := &Quiz{
+		PassingScore:    valid.PassingScore,
+		CompletesLesson: valid.CompletesLesson,
+		Questions:       valid.Questions,
+	}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		lesson, err := lockEditableLesson(tx, slug, editor)
+		if err != nil {
+			return err
+		}
+		quiz.LessonID = lesson.ID
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "lesson_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"passing_score", "completes_lesson", "questions", "updated_at"}),
+		}).Create(quiz).Error; err != nil {
+			return err
+		}
+		return touchLessons(tx, lesson.ID)
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	s.invalidateLessons(ctx, slug)
+	return s.getQuiz(ctx, quiz.LessonID)
+}
+
+// DeleteQuiz removes the quiz of a lesson together with its attempts.
+// The editor must pass CheckLessonEditable.
+func (s *Service) DeleteQuiz(ctx context.Context, slug string, editor LessonEditor) error {
+	ctx, span := tracing.StartSpan(ctx, "study.DeleteQuiz",
+		tracing.AttrDBOperation.String("DELETE"),
+		tracing.AttrDBTable.String("lesson_quizzes"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		lesson, err := lockEditableLesson(tx, slug, editor)
+		if err != nil {
+			return err
+		}
+		res := tx.Where("lesson_id = ?", lesson.ID).Delete(&Quiz{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrQuizNotFound
+		}
+		return touchLessons(tx, lesson.ID)
+	})
+	if err != nil {
+		if !errors.Is(err, ErrQuizNotFound) {
+			tracing.RecordError(span, err)
+		}
+		return err
+	}
+	s.invalidateLessons(ctx, slug)
+	return nil
+}
+
+// GetQuizView returns the student-facing quiz of a lesson, or nil when the lesson has
+// no quiz. When userID is set the view summarizes the user's attempts.
+func (s *Service) GetQuizView(ctx context.Context, lessonID uint, userID *uint) (*QuizView, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.GetQuizView",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("lesson_quizzes"),
+	)
+	defer span.End()
+
+	quiz, err := s.getQuiz(ctx, lessonID)
+	if errors.Is(err, ErrQuizNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	var attempts []QuizAttempt
+	if userID != nil {
+		if err := s.db.WithContext(ctx).
+			Select("id, score, passed").
+			Where("quiz_id = ? AND user_id = ?", quiz.ID, *userID).
+			Find(&attempts).Error; err != nil {
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+	}
+	return NewQuizView(quiz, attempts), nil
+}
+
+// SubmitQuiz grades an attempt and records it in the user's history. A passing attempt
+// on a quiz with CompletesLesson marks the lesson completed for the user. Gated lessons
+// need viewer to pass CheckLessonAccess.
+func (s *Service) SubmitQuiz(ctx context.Context, slug string, userID uint, req *SubmitQuizRequest, viewer LessonViewer) (*QuizAttemptResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.SubmitQuiz",
+		tracing.AttrDBOperation.String("INSERT"),
+		tracing.AttrDBTable.String("quiz_attempts"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	lesson, err := s.getAccessibleLesson(ctx, slug, viewer)
+	if err != nil {
+		return nil, err
+	}
+	quiz, err := s.getQuiz(ctx, lesson.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	answers, err := ValidateQuizAnswers(quiz.Questions, req.Answers)
+	if err != nil {
+		return nil, err
+	}
+
+	correct, results := GradeQuiz(quiz.Questions, answers)
+	total := len(quiz.Questions)
+	score := 0
+	if total > 0 {
+		score = correct * 100 / total
+	}
+	attempt := &QuizAttempt{
+		QuizID:  quiz.ID,
+		UserID:  userID,
+		Score:   score,
+		Correct: correct,
+		Total:   total,
+		Passed:  score >= quiz.PassingScore,
+		Answers: datatypes.NewJSONType(answers),
+	}
+	if attempt.Passed {
+		for i, q := range quiz.Questions {
+			results[i].Explanation = q.Explanation
+		}
+	}
+
+	// Counting and inserting under a per-user lock keeps parallel attempts from all
+	// passing the check
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", quizRateLockClass, int32(userID)).Error; err != nil {
+			return err
+		}
+		var recent int64
+		if err := tx.Model(&QuizAttempt{}).
+			Where("user_id = ? AND created_at > ?", userID, time.Now().Add(-QuizAttemptRateWindow)).
+			Count(&recent).Error; err != nil {
+			return err
+		}
+		if recent >= QuizAttemptRateLimit {
+			return ErrQuizRateLimited
+		}
+		if err := tx.Create(attempt).Error; err != nil {
+			return err
+		}
+		if !attempt.Passed || !quiz.CompletesLesson {
+			return nil
+		}
+		now := time.Now()
+		progress := &LessonProgress{UserID: userID, LessonID: lesson.ID, Status: ProgressCompleted, CompletedAt: &now}
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}, {Name: "lesson_id"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"status":       ProgressCompleted,
+				"completed_at": gorm.Expr("COALESCE(lesson_progress.completed_at, EXCLUDED.completed_at)"),
+				"updated_at":   now,
+			}),
+		}).Create(progress).Error
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	return &QuizAttemptResult{QuizAttempt: attempt, PassingScore: quiz.PassingScore, Questions: results}, nil
+}
+
+// ListQuizAttempts returns the user's attempts at a lesson quiz, newest first. Gated
+// lessons need viewer to pass CheckLessonAccess.
+func (s *Service) ListQuizAttempts(ctx context.Context, slug string, userID uint, viewer LessonViewer) ([]QuizAttempt, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.ListQuizAttempts",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("quiz_attempts"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	lesson, err := s.getAccessibleLesson(ctx, slug, viewer)
+	if err != nil {
+		return nil, err
+	}
+	quiz, err := s.getQuiz(ctx, lesson.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts := []QuizAttempt{}
+	if err := s.db.WithContext(ctx).
+		Where("quiz_id = ? AND user_id = ?", quiz.ID, userID).
+		Order("created_at DESC, id DESC").
+		Limit(MaxQuizAttempts).
+		Find(&attempts).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return attempts, nil
+}
+
+func (s *Service) getQuiz(ctx context.Context, lessonID uint) (*Quiz, error) {
+	var quiz Quiz
+	if err := s.db.WithContext(ctx).Where("lesson_id = ?", lessonID).First(&quiz).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrQuizNotFound
+		}
+		return nil, err
+	}
+	return &quiz, nil
+}
+
+// normalizeShortAnswer trims and collapses whitespace, lowercasing unless caseSensitive
+func normalizeShortAnswer(s string, caseSensitive bool) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if !caseSensitive {
+		s = strings.ToLower(s)
+	}
+	return s
+}
+
+func uniqueSorted(xs []int) []int {
+	out := make([]int, 0, len(xs))
+	seen := make(map[int]bool, len(xs))
+	for _, x := range xs {
+		if !seen[x] {
+			seen[x] = true
+			out = append(out, x)
+		}
+	}
+	sort.Ints(out)
+	return out
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+package study_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"donfra-api/internal/domain/study"
+)
+
+func sampleQuestions() []study.QuizQuestion {
+	return []study.QuizQuestion{
+		{ID: "q1", Type: study.QuestionMultipleChoice, Prompt: "Pick primes", Options: []string{"2", "4", "5"}, Correct: []int{0, 2}},
+		{ID: "q2", Type: study.QuestionShortAnswer, Prompt: "Capital of France?", Answers: []string{"Paris"}},
+		{ID: "q3", Type: study.QuestionShortAnswer, Prompt: "Go keyword", Answers: []string{"func"}, CaseSensitive: true},
+	}
+}
+
+func TestValidateQuiz(t *testing.T) {
+	got, err := study.ValidateQuiz(&study.SetQuizRequest{Questions: []study.QuizQuestion{
+		{Type: study.QuestionShortAnswer, Prompt: " What? ", Answers: []string{" yes ", ""}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.PassingScore != study.DefaultPassingScore {
+		t.Errorf("expected default passing score, got %d", got.PassingScore)
+	}
+	q := got.Questions[0]
+	if q.ID != "q1" || q.Prompt != "What?" || len(q.Answers) != 1 || q.Answers[0] != "yes" {
+		t.Errorf("question not normalized: %+v", q)
+	}
+
+	invalid := map[string]*study.SetQuizRequest{
+		"no questions":  {},
+		"score":         {PassingScore: 101, Questions: sampleQuestions()},
+		"bad type":      {Questions: []study.QuizQuestion{{Type: "essay", Prompt: "x"}}},
+		"one option":    {Questions: []study.QuizQuestion{{Type: study.QuestionMultipleChoice, Prompt: "x", Options: []string{"a"}, Correct: []int{0}}}},
+		"out of range":  {Questions: []study.QuizQuestion{{Type: study.QuestionMultipleChoice, Prompt: "x", Options: []string{"a", "b"}, Correct: []int{2}}}},
+		"no answers":    {Questions: []study.QuizQuestion{{Type: study.QuestionShortAnswer, Prompt: "x", Answers: []string{" "}}}},
+		"duplicate ids": {Questions: append(sampleQuestions(), sampleQuestions()[0])},
+	}
+	for name, req := range invalid {
+		if _, err := study.ValidateQuiz(req); !errors.Is(err, study.ErrInvalidQuiz) {
+			t.Errorf("%s: expected ErrInvalidQuiz, got %v", name, err)
+		}
+	}
+}
+
+func TestGradeQuiz(t *testing.T) {
+	correct, results := study.GradeQuiz(sampleQuestions(), map[string]study.QuizAnswer{
+		"q1": {Choices: []int{2, 0, 2}},
+		"q2": {Text: "  paris "},
+		"q3": {Text: "FUNC"},
+	})
+	if correct != 2 {
+		t.Errorf("expected 2 correct, got %d", correct)
+	}
+	want := []bool{true, true, false}
+	for i, r := range results {
+		if r.Correct != want[i] {
+			t.Errorf("%s: expected correct=%v", r.ID, want[i])
+		}
+		if r.Explanation != "" {
+			t.Errorf("%s: explanation should not be set by grading", r.ID)
+		}
+	}
+
+	if correct, _ := study.GradeQuiz(sampleQuestions(), map[string]study.QuizAnswer{"q1": {Choices: []int{0}}}); correct != 0 {
+		t.Errorf("partial or missing answers should not count, got %d", correct)
+	}
+}
+
+func TestValidateQuizAnswers(t *testing.T) {
+	got, err := study.ValidateQuizAnswers(sampleQuestions(), map[string]study.QuizAnswer{
+		"q1":    {Choices: []int{0, 2}, Text: "ignored"},
+		"q2":    {Text: "Paris", Choices: []int{1}},
+		"other": {Text: "dropped"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got["q1"].Text != "" || got["q2"].Choices != nil {
+		t.Errorf("expected answers reduced to their question type, got %+v", got)
+	}
+
+	invalid := map[string]study.QuizAnswer{
+		"q1": {Choices: []int{0, 1, 2, 0}},
+		"q2": {Text: strings.Repeat("x", study.MaxQuizAnswerLength+1)},
+	}
+	for id, answer := range invalid {
+		if _, err := study.ValidateQuizAnswers(sampleQuestions(), map[string]study.QuizAnswer{id: answer}); !errors.Is(err, study.ErrInvalidQuiz) {
+			t.Errorf("%s: expected ErrInvalidQuiz, got %v", id, err)
+		}
+	}
+	for _, c := range []int{-1, 3} {
+		if _, err := study.ValidateQuizAnswers(sampleQuestions(), map[string]study.QuizAnswer{"q1": {Choices: []int{c}}}); !errors.Is(err, study.ErrInvalidQuiz) {
+			t.Errorf("choice %d: expected ErrInvalidQuiz, got %v", c, err)
+		}
+	}
+}
+
+func TestNewQuizView_HidesAnswers(t *testing.T) {
+	quiz := &study.Quiz{PassingScore: 60, Questions: sampleQuestions()}
+	view := study.NewQuizView(quiz, []study.QuizAttempt{{Score: 33}, {Score: 66, Passed: true}})
+
+	if view.Attempts != 2 || view.BestScore == nil || *view.BestScore != 66 || !view.Passed {
+		t.Errorf("unexpected attempt summary %+v", view)
+	}
+	if !view.Questions[0].Multiple || view.Questions[1].Multiple {
+		t.Errorf("unexpected multiple flags %+v", view.Questions)
+	}
+
+	if empty := study.NewQuizView(quiz, nil); empty.BestScore != nil || empty.Passed {
+		t.Errorf("expected empty summary, got %+v", empty)
+	}
+}
@@ -0,0 +1,90 @@
+package study
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"donfra-api/internal/pkg/tracing"
+)
+
+var (
+	// ErrInvalidSlug is returned when creating, importing or renaming a lesson with a malformed slug.
+	ErrInvalidSlug = errors.New("slug must contain only lowercase letters, digits and single hyphens")
+	// ErrSlugTaken is returned when renaming a lesson to a slug another lesson uses.
+	ErrSlugTaken = errors.New("slug already exists")
+)
+
+var slugRe = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// ValidateSlug checks the format of a new lesson slug (e.g. "intro-to-graphs").
+func ValidateSlug(slug string) error {
+	if len(slug) > 200 || !slugRe.MatchString(slug) {
+		return ErrInvalidSlug
+	}
+	return nil
+}
+
+// ResolveSlugRedirect returns the lesson a former slug now points to,
+// or gorm.ErrRecordNotFound when the slug was never renamed.
+func (s *Service) ResolveSlugRedirect(ctx context.Context, oldSlug string) (*Lesson, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.ResolveSlugRedirect",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("lesson_slug_redirects"),
+		tracing.AttrLessonSlug.String(oldSlug),
+	)
+	defer span.End()
+
+	var lesson Lesson
+	err := s.db.WithContext(ctx).
+		Joins("JOIN lesson_slug_redirects r ON r.lesson_id = lessons.id").
+		Where("r.old_slug = ?", oldSlug).
+		First(&lesson).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			tracing.RecordError(span, err)
+		}
+		return nil, err
+	}
+	return &lesson, nil
+}
+
+// renameLesson moves a lesson to newSlug inside tx and records the old slug as a redirect.
+// Redirects store the lesson ID, so earlier renames keep resolving to the current slug.
+func renameLesson(tx *gorm.DB, lesson *Lesson, newSlug string) error {
+	if err := ValidateSlug(newSlug); err != nil {
+		return err
+	}
+	var taken int64
+	if err := tx.Model(&Lesson{}).Where("slug = ? AND id <> ?", newSlug, lesson.ID).Count(&taken).Error; err != nil {
+		return err
+	}
+	if taken > 0 {
+		return ErrSlugTaken
+	}
+
+	if err := tx.Model(lesson).Update("slug", newSlug).Error; err != nil {
+		return err
+	}
+	// The new slug is live again, so it must no longer redirect anywhere
+	if err := releaseSlug(tx, newSlug); err != nil {
+		return err
+	}
+	redirect := &SlugRedirect{OldSlug: lesson.Slug, LessonID: lesson.ID}
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "old_slug"}},
+		DoUpdates: clause.AssignmentColumns([]string{"lesson_id", "created_at"}),
+	}).Create(redirect).Error; err != nil {
+		return err
+	}
+	lesson.Slug = newSlug
+	return nil
+}
+
+// releaseSlug drops a redirect for a slug that is taken by a live lesson
+func releaseSlug(tx *gorm.DB, slug string) error {
+	return tx.Where("old_slug = ?", slug).Delete(&SlugRedirect{}).Error
+}
@@ -0,0 +1,21 @@
+package study_test
+
+import (
+	"errors"
+	"testing"
+
+	"donfra-api/internal/domain/study"
+)
+
+func TestValidateSlug(t *testing.T) {
+	for _, slug := range []string{"intro", "intro-to-graphs", "week-2"} {
+		if err := study.ValidateSlug(slug); err != nil {
+			t.Errorf("expected %q to be valid, got %v", slug, err)
+		}
+	}
+	for _, slug := range []string{"", "Intro", "intro--graphs", "-intro", "intro-", "intro graphs", "a/b"} {
+		if err := study.ValidateSlug(slug); !errors.Is(err, study.ErrInvalidSlug) {
+			t.Errorf("expected %q to be rejected, got %v", slug, err)
+		}
+	}
+}
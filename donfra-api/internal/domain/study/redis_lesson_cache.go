@@ -0,0 +1,84 @@
+package study
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisLessonCache is a LessonCache shared by all API instances. Cache errors are
+// logged and treated as misses, so Redis being down only costs database reads.
+type RedisLessonCache struct {
+	client *redis.Client
+	prefix string
+	ttl    time.Duration
+}
+
+// NewRedisLessonCache creates a Redis-backed lesson cache.
+func NewRedisLessonCache(client *redis.Client, ttl time.Duration) *RedisLessonCache {
+	if ttl <= 0 {
+		ttl = DefaultLessonCacheTTL
+	}
+	return &RedisLessonCache{client: client, prefix: "study:lesson:", ttl: ttl}
+}
+
+// Get returns the cached lesson.
+func (c *RedisLessonCache) Get(ctx context.Context, slug string) (*Lesson, bool) {
+	data, err := c.client.Get(ctx, c.prefix+slug).Bytes()
+	if err != nil {
+		if err != redis.Nil {
+			log.Printf("[study] lesson cache get %s: %v", slug, err)
+		}
+		return nil, false
+	}
+	var lesson Lesson
+	if err := json.Unmarshal(data, &lesson); err != nil {
+		return nil, false
+	}
+	return &lesson, true
+}
+
+// Set stores the lesson with the cache TTL.
+func (c *RedisLessonCache) Set(ctx context.Context, lesson *Lesson) {
+	data, err := json.Marshal(lesson)
+	if err != nil {
+		return
+	}
+	if err := c.client.Set(ctx, c.prefix+lesson.Slug, data, c.ttl).Err(); err != nil {
+		log.Printf("[study] lesson cache set %s: %v", lesson.Slug, err)
+	}
+}
+
+// Invalidate deletes the given slugs.
+func (c *RedisLessonCache) Invalidate(ctx context.Context, slugs ...string) {
+	if len(slugs) == 0 {
+		return
+	}
+	keys := make([]string, len(slugs))
+	for i, slug := range slugs {
+		keys[i] = c.prefix + slug
+	}
+	if err := c.client.Del(ctx, keys...).Err(); err != nil {
+		log.Printf("[study] lesson cache invalidate: %v", err)
+	}
+}
+
+// Clear deletes every cached lesson.
+func (c *RedisLessonCache) Clear(ctx context.Context) {
+	iter := c.client.Scan(ctx, 0, c.prefix+"*", 100).Iterator()
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		log.Printf("[study] lesson cache clear: %v", err)
+	}
+	if len(keys) > 0 {
+		if err := c.client.Del(ctx, keys...).Err(); err != nil {
+			log.Printf("[study] lesson cache clear: %v", err)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package study
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"donfra-api/internal/pkg/tracing"
+)
+
+// RendererVersion identifies the markdown renderer configuration. Cached HTML rendered
+// by an older version is rendered again.
+const RendererVersion = 1
+
+// TOCEntry is a heading of a rendered lesson, linking to its anchor.
+type TOCEntry struct {
+	Level int    `json:"level"`
+	ID    string `json:"id"`
+	Text  string `json:"text"`
+}
+
+// RenderedLesson is the sanitized HTML of a lesson's markdown and its table of contents.
+type RenderedLesson struct {
+	HTML string     `json:"html"`
+	TOC  []TOCEntry `json:"toc"`
+}
+
+var markdown = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+	// Raw HTML is passed through and then sanitized, so admins can still use safe tags
+	goldmark.WithRendererOptions(html.WithUnsafe()),
+)
+
+var sanitizer = newSanitizer()
+
+// newSanitizer allows user-generated content plus the attributes the renderer emits:
+// heading anchors, code language classes and table alignment.
+func newSanitizer() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("id").Matching(regexp.MustCompile(`^[\w-]+$`)).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#.-]+$`)).OnElements("code")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^anchor$`)).OnElements("a")
+	p.AllowAttrs("align").Matching(regexp.MustCompile(`^(left|center|right)$`)).OnElements("th", "td")
+	p.AllowStyles("text-align").Matching(regexp.MustCompile(`^(left|center|right)$`)).OnElements("th", "td")
+	// Only GFM task list checkboxes; other input types would let lessons embed form fields
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	return p
+}
+
+// RenderMarkdown converts lesson markdown (GitHub flavored) to sanitized HTML. Headings
+// get an id and a "#" anchor link, fenced code blocks a language-* class for client-side
+// highlighting, and the headings are collected into a table of contents.
+func RenderMarkdown(source string) (*RenderedLesson, error) {
+	src := []byte(source)
+	doc := markdown.Parser().Parse(text.NewReader(src))
+
+	toc := []TOCEntry{}
+	err := ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := n.(*ast.Heading)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+		id, ok := heading.AttributeString("id")
+		if !ok {
+			return ast.WalkSkipChildren, nil
+		}
+		anchorID, _ := id.([]byte)
+		toc = append(toc, TOCEntry{Level: heading.Level, ID: string(anchorID), Text: headingText(heading, src)})
+
+		anchor := ast.NewLink()
+		anchor.Destination = append([]byte("#"), anchorID...)
+		anchor.SetAttributeString("class", []byte("anchor"))
+		anchor.AppendChild(anchor, ast.NewString([]byte("#")))
+		heading.InsertBefore(heading, heading.FirstChild(), anchor)
+		return ast.WalkSkipChildren, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := markdown.Renderer().Render(&buf, src, doc); err != nil {
+		return nil, err
+	}
+	return &RenderedLesson{HTML: sanitizer.Sanitize(buf.String()), TOC: toc}, nil
+}
+
+// headingText returns the plain text of a heading
+func headingText(n ast.Node, src []byte) string {
+	var buf bytes.Buffer
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch t := c.(type) {
+		case *ast.Text:
+			buf.Write(t.Segment.Value(src))
+			if t.SoftLineBreak() {
+				buf.WriteByte(' ')
+			}
+		case *ast.String:
+			buf.Write(t.Value)
+		default:
+			buf.WriteString(headingText(c, src))
+		}
+	}
+	return buf.String()
+}
+
+// RenderLesson returns the rendered markdown of a lesson. The result is cached on the
+// lesson's latest revision, so each revision is rendered once; lessons without a
+// matching revision are rendered on every call.
+func (s *Service) RenderLesson(ctx context.Context, lesson *Lesson) (*RenderedLesson, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.RenderLesson",
+		tracing.AttrDBTable.String("lesson_revisions"),
+		tracing.AttrLessonSlug.String(lesson.Slug),
+	)
+	defer span.End()
+
+	db := s.db.WithContext(ctx)
+	var revision LessonRevision
+	err := db.Select("id, markdown, rendered_html, rendered_toc, renderer_version").
+		Where("lesson_id = ?", lesson.ID).
+		Order("number DESC").
+		First(&revision).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	cacheable := err == nil && revision.Markdown == lesson.Markdown
+	if cacheable && revision.RendererVersion == RendererVersion && revision.RenderedHTML != nil {
+		return &RenderedLesson{HTML: *revision.RenderedHTML, TOC: revision.RenderedTOC}, nil
+	}
+
+	rendered, err := RenderMarkdown(lesson.Markdown)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if cacheable {
+		// A failed cache write only costs a render on the next read
+		if err := db.Model(&LessonRevision{}).Where("id = ?", revision.ID).Updates(map[string]any{
+			"rendered_html":    rendered.HTML,
+			"rendered_toc":     datatypes.JSONSlice[TOCEntry](rendered.TOC),
+			"renderer_version": RendererVersion,
+		}).Error; err != nil {
+			tracing.RecordError(span, err)
+		}
+	}
+	return rendered, nil
+}
@@ -0,0 +1,79 @@
+package study_test
+
+import (
+	"strings"
+	"testing"
+
+	"donfra-api/internal/domain/study"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	src := "# Graphs *101*\n\n## Setup\n\n## Setup\n\n" +
+		"| a | b |\n|:-|-:|\n| 1 | 2 |\n\n" +
+		"```go\nfunc main() {}\n```\n"
+
+	r, err := study.RenderMarkdown(src)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		`<h1 id="graphs-101"><a href="#graphs-101" class="anchor"`,
+		`<h2 id="setup-1">`,
+		`<th style="text-align: left">a</th>`,
+		`<code class="language-go">`,
+	} {
+		if !strings.Contains(r.HTML, want) {
+			t.Errorf("expected %q in HTML:\n%s", want, r.HTML)
+		}
+	}
+
+	want := []study.TOCEntry{
+		{Level: 1, ID: "graphs-101", Text: "Graphs 101"},
+		{Level: 2, ID: "setup", Text: "Setup"},
+		{Level: 2, ID: "setup-1", Text: "Setup"},
+	}
+	if len(r.TOC) != len(want) {
+		t.Fatalf("expected %d TOC entries, got %+v", len(want), r.TOC)
+	}
+	for i := range want {
+		if r.TOC[i] != want[i] {
+			t.Errorf("TOC[%d] = %+v, want %+v", i, r.TOC[i], want[i])
+		}
+	}
+}
+
+func TestRenderMarkdown_Sanitizes(t *testing.T) {
+	src := "Hi <script>alert(1)</script><b onclick=\"steal()\">bold</b>\n\n" +
+		"[link](javascript:alert(1)) <img src=x onerror=alert(1)>\n\n" +
+		"<code class=\"evil\">x</code>\n"
+
+	r, err := study.RenderMarkdown(src)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, bad := range []string{"<script", "onclick", "javascript:", "onerror", `class="evil"`} {
+		if strings.Contains(r.HTML, bad) {
+			t.Errorf("unsafe %q survived sanitization:\n%s", bad, r.HTML)
+		}
+	}
+	if !strings.Contains(r.HTML, "<b>bold</b>") {
+		t.Errorf("safe HTML should be kept:\n%s", r.HTML)
+	}
+}
+
+func TestRenderMarkdown_OnlyTaskListInputs(t *testing.T) {
+	src := "- [x] done\n- [ ] todo\n\n" +
+		"<input type=\"password\" name=\"pw\"> <input value=\"x\">\n"
+
+	r, err := study.RenderMarkdown(src)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Count(r.HTML, `type="checkbox"`) != 2 {
+		t.Errorf("expected both task list checkboxes to be kept:\n%s", r.HTML)
+	}
+	if strings.Count(r.HTML, "<input") != 2 || strings.Contains(r.HTML, "password") {
+		t.Errorf("expected other inputs to be removed:\n%s", r.HTML)
+	}
+}
@@ -0,0 +1,187 @@
+package study
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"donfra-api/internal/pkg/tracing"
+)
+
+// Publishing review statuses of a lesson. Lessons created by admins have no status.
+const (
+	ReviewPending          = "pending"
+	ReviewApproved         = "approved"
+	ReviewChangesRequested = "changes_requested"
+)
+
+// Review actions of ReviewLessonRequest.
+const (
+	ReviewActionApprove        = "approve"
+	ReviewActionRequestChanges = "request_changes"
+)
+
+var (
+	// ErrNotLessonOwner is returned when a mentor changes a lesson written by someone else.
+	ErrNotLessonOwner = errors.New("only the lesson's author can change it")
+	// ErrLessonPublishedLocked is returned when a mentor changes a published or scheduled lesson.
+	ErrLessonPublishedLocked = errors.New("published lessons can only be changed by admins")
+	// ErrLessonInReview is returned when a mentor changes a lesson while it awaits review.
+	ErrLessonInReview = errors.New("lessons awaiting review cannot be changed until they are reviewed")
+	// ErrPublishRequiresReview is returned when a mentor tries to publish or schedule a lesson.
+	ErrPublishRequiresReview = errors.New("mentors cannot publish lessons; submit the lesson for review instead")
+	// ErrInvalidReview is returned for an unknown review action or a missing note.
+	ErrInvalidReview = errors.New("invalid review")
+	// ErrReviewState is returned when a lesson cannot be submitted or reviewed in its current state.
+	ErrReviewState = errors.New("lesson cannot be reviewed")
+)
+
+// CheckLessonOwner reports whether editor may change lesson. Admins may change every
+// lesson; mentors only their own, and only until it is published or scheduled.
+func CheckLessonOwner(lesson *Lesson, editor LessonEditor) error {
+	if editor.IsAdmin {
+		return nil
+	}
+	if editor.UserID == nil || lesson.OwnerID == nil || *lesson.OwnerID != *editor.UserID {
+		return ErrNotLessonOwner
+	}
+	if lesson.IsPublished || lesson.PublishAt != nil {
+		return ErrLessonPublishedLocked
+	}
+	return nil
+}
+
+// CheckLessonEditable is CheckLessonOwner for changes to a lesson's content: mentors
+// also cannot change a lesson while it awaits review, so approving it publishes
+// exactly what was submitted.
+func CheckLessonEditable(lesson *Lesson, editor LessonEditor) error {
+	if err := CheckLessonOwner(lesson, editor); err != nil {
+		return err
+	}
+	if !editor.IsAdmin && lesson.ReviewStatus == ReviewPending {
+		return ErrLessonInReview
+	}
+	return nil
+}
+
+// lockEditableLesson reads the lesson for update within tx and checks that editor may
+// change its content. Checking the locked row, not a possibly cached copy, keeps a
+// mentor from editing a lesson that was just submitted or published.
+func lockEditableLesson(tx *gorm.DB, slug string, editor LessonEditor) (*Lesson, error) {
+	var lesson Lesson
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("slug = ?", slug).First(&lesson).Error; err != nil {
+		return nil, err
+	}
+	if err := CheckLessonEditable(&lesson, editor); err != nil {
+		return nil, err
+	}
+	return &lesson, nil
+}
+
+// SubmitLessonForReview asks admins to publish an unpublished lesson. Resubmitting a
+// lesson after changes were requested clears the previous review note.
+// Caller must ensure the editor passes CheckLessonOwner.
+func (s *Service) SubmitLessonForReview(ctx context.Context, slug string) (*Lesson, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.SubmitLessonForReview",
+		tracing.AttrDBOperation.String("UPDATE"),
+		tracing.AttrDBTable.String("lessons"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	var lesson Lesson
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("slug = ?", slug).First(&lesson).Error; err != nil {
+			return err
+		}
+		if lesson.IsPublishedAt(time.Now()) || lesson.PublishAt != nil {
+			return fmt.Errorf("%w: lesson is already published", ErrReviewState)
+		}
+		if lesson.ReviewStatus == ReviewPending {
+			return nil
+		}
+		return tx.Model(&lesson).Updates(map[string]any{
+			"review_status":  ReviewPending,
+			"review_note":    "",
+			"submitted_at":   time.Now(),
+			"reviewed_by_id": nil,
+			"reviewed_at":    nil,
+		}).Error
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	s.invalidateLessons(ctx, slug)
+	return s.GetLessonBySlug(ctx, slug)
+}
+
+// ReviewLesson approves or requests changes to a lesson awaiting review. Approving
+// publishes the lesson (or schedules it for req.PublishAt) and records a revision.
+// Caller must ensure admin authorization.
+func (s *Service) ReviewLesson(ctx context.Context, slug string, req *ReviewLessonRequest, reviewer RevisionAuthor) (*Lesson, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.ReviewLesson",
+		tracing.AttrDBOperation.String("UPDATE"),
+		tracing.AttrDBTable.String("lessons"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	note := strings.TrimSpace(req.Note)
+	now := time.Now()
+	fields := map[string]any{
+		"review_note":    note,
+		"reviewed_by_id": reviewer.UserID,
+		"reviewed_at":    now,
+	}
+	switch req.Action {
+	case ReviewActionApprove:
+		fields["review_status"] = ReviewApproved
+		if req.PublishAt != nil && req.PublishAt.After(now) {
+			fields["publish_at"] = *req.PublishAt
+		} else {
+			fields["is_published"] = true
+		}
+	case ReviewActionRequestChanges:
+		if note == "" {
+			return nil, fmt.Errorf("%w: a note is required when requesting changes", ErrInvalidReview)
+		}
+		fields["review_status"] = ReviewChangesRequested
+	default:
+		return nil, fmt.Errorf("%w: action must be approve or request_changes", ErrInvalidReview)
+	}
+
+	var lesson Lesson
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("slug = ?", slug).First(&lesson).Error; err != nil {
+			return err
+		}
+		if lesson.ReviewStatus != ReviewPending {
+			return fmt.Errorf("%w: lesson is not awaiting review", ErrReviewState)
+		}
+		if err := tx.Model(&lesson).Updates(fields).Error; err != nil {
+			return err
+		}
+		if err := tx.First(&lesson, lesson.ID).Error; err != nil {
+			return err
+		}
+		if req.Action != ReviewActionApprove {
+			return nil
+		}
+		if err := ValidateSchedule(lesson.PublishAt, lesson.UnpublishAt); err != nil {
+			return err
+		}
+		return recordRevision(tx, &lesson, reviewer, nil)
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	s.invalidateLessons(ctx, slug)
+	return &lesson, nil
+}
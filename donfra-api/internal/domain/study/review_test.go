@@ -0,0 +1,59 @@
+package study_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"donfra-api/internal/domain/study"
+)
+
+func TestCheckLessonOwner(t *testing.T) {
+	owner, other := uint(1), uint(2)
+	later := time.Now().Add(time.Hour)
+
+	draft := &study.Lesson{OwnerID: &owner}
+	published := &study.Lesson{OwnerID: &owner, IsPublished: true}
+	scheduled := &study.Lesson{OwnerID: &owner, PublishAt: &later}
+	unowned := &study.Lesson{}
+	pending := &study.Lesson{OwnerID: &owner, ReviewStatus: study.ReviewPending}
+
+	for _, tc := range []struct {
+		name   string
+		lesson *study.Lesson
+		editor study.LessonEditor
+		want   error
+	}{
+		{"owner edits draft", draft, study.LessonEditor{UserID: &owner}, nil},
+		{"other mentor", draft, study.LessonEditor{UserID: &other}, study.ErrNotLessonOwner},
+		{"no user", draft, study.LessonEditor{}, study.ErrNotLessonOwner},
+		{"lesson without owner", unowned, study.LessonEditor{UserID: &owner}, study.ErrNotLessonOwner},
+		{"owner edits published", published, study.LessonEditor{UserID: &owner}, study.ErrLessonPublishedLocked},
+		{"owner edits scheduled", scheduled, study.LessonEditor{UserID: &owner}, study.ErrLessonPublishedLocked},
+		{"admin edits published", published, study.LessonEditor{IsAdmin: true}, nil},
+		{"admin token edits unowned", unowned, study.LessonEditor{IsAdmin: true}, nil},
+	} {
+		if err := study.CheckLessonOwner(tc.lesson, tc.editor); !errors.Is(err, tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+
+	if err := study.CheckLessonOwner(pending, study.LessonEditor{UserID: &owner}); err != nil {
+		t.Errorf("owner should still reach a lesson in review, got %v", err)
+	}
+	for _, tc := range []struct {
+		name   string
+		lesson *study.Lesson
+		editor study.LessonEditor
+		want   error
+	}{
+		{"owner edits draft", draft, study.LessonEditor{UserID: &owner}, nil},
+		{"owner edits in review", pending, study.LessonEditor{UserID: &owner}, study.ErrLessonInReview},
+		{"other mentor edits in review", pending, study.LessonEditor{UserID: &other}, study.ErrNotLessonOwner},
+		{"admin edits in review", pending, study.LessonEditor{IsAdmin: true}, nil},
+	} {
+		if err := study.CheckLessonEditable(tc.lesson, tc.editor); !errors.Is(err, tc.want) {
+			t.Errorf("CheckLessonEditable %s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package study
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"donfra-api/internal/pkg/tracing"
+)
+
+// ErrRevisionNotFound is returned when a revision does not exist for the lesson.
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// diffContextLines is the number of unchanged lines shown around each hunk.
+const diffContextLines = 3
+
+// ListLessonRevisions returns the revision history of a lesson, newest first.
+func (s *Service) ListLessonRevisions(ctx context.Context, slug string) ([]LessonRevisionSummary, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.ListLessonRevisions",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("lesson_revisions"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	lesson, err := s.GetLessonBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	var revisions []LessonRevisionSummary
+	if err := s.db.WithContext(ctx).Model(&LessonRevision{}).
+		Where("lesson_id = ?", lesson.ID).
+		Order("number DESC").
+		Find(&revisions).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// GetLessonRevision returns a single revision with its full content.
+func (s *Service) GetLessonRevision(ctx context.Context, slug string, revisionID uint) (*LessonRevision, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.GetLessonRevision",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("lesson_revisions"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	lesson, err := s.GetLessonBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := getRevision(s.db.WithContext(ctx), lesson.ID, revisionID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return revision, nil
+}
+
+// DiffLessonRevisions compares two revisions of a lesson.
+func (s *Service) DiffLessonRevisions(ctx context.Context, slug string, fromID, toID uint) (*RevisionDiff, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.DiffLessonRevisions",
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	from, err := s.GetLessonRevision(ctx, slug, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.GetLessonRevision(ctx, slug, toID)
+	if err != nil {
+		return nil, err
+	}
+	return buildRevisionDiff(slug, from, to), nil
+}
+
+// RestoreLessonRevision rolls a lesson back to the content of an earlier revision.
+// The publish state is kept (see RestoreFromRevision). The restore itself is recorded
+// as a new revision, so it can be undone too.
+func (s *Service) RestoreLessonRevision(ctx context.Context, slug string, revisionID uint, author RevisionAuthor) (*Lesson, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.RestoreLessonRevision",
+		tracing.AttrDBOperation.String("UPDATE"),
+		tracing.AttrDBTable.String("lessons"),
+		tracing.AttrLessonSlug.String(slug),
+	)
+	defer span.End()
+
+	var restored Lesson
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("slug = ?", slug).First(&restored).Error; err != nil {
+			return err
+		}
+		revision, err := getRevision(tx, restored.ID, revisionID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&restored).Updates(RestoreFromRevision(&restored, revision)).Error; err != nil {
+			return err
+		}
+		return recordRevision(tx, &restored, author, &revision.ID)
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	s.invalidateLessons(ctx, slug)
+	return &restored, nil
+}
+
+// RestoreFromRevision copies the content of revision (title, markdown and drawing) onto
+// lesson and returns the column updates. The publish state is left alone: publishing
+// goes through the schedule and review flows, never through a content rollback.
+func RestoreFromRevision(lesson *Lesson, revision *LessonRevision) map[string]any {
+	lesson.Title = revision.Title
+	lesson.Markdown = revision.Markdown
+	lesson.Excalidraw = revision.Excalidraw
+	return map[string]any{
+		"title":      lesson.Title,
+		"markdown":   lesson.Markdown,
+		"excalidraw": lesson.Excalidraw,
+	}
+}
+
+// recordRevision appends a snapshot of the lesson's current content to its history.
+// The lesson row is locked first so concurrent writers number revisions one after
+// another instead of colliding on UNIQUE(lesson_id, number).
+func recordRevision(tx *gorm.DB, lesson *Lesson, author RevisionAuthor, restoredFrom *uint) error {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ?", lesson.ID).
+		Take(&Lesson{}).Error; err != nil {
+		return fmt.Errorf("failed to lock lesson: %w", err)
+	}
+
+	var latest int
+	if err := tx.Model(&LessonRevision{}).
+		Where("lesson_id = ?", lesson.ID).
+		Select("COALESCE(MAX(number), 0)").
+		Scan(&latest).Error; err != nil {
+		return fmt.Errorf("failed to number revision: %w", err)
+	}
+
+	revision := &LessonRevision{
+		LessonID:     lesson.ID,
+		Number:       latest + 1,
+		Title:        lesson.Title,
+		Markdown:     lesson.Markdown,
+		Excalidraw:   lesson.Excalidraw,
+		IsPublished:  lesson.IsPublished,
+		AuthorID:     author.UserID,
+		AuthorEmail:  author.Email,
+		RestoredFrom: restoredFrom,
+	}
+	if err := tx.Create(revision).Error; err != nil {
+		return fmt.Errorf("failed to record revision: %w", err)
+	}
+	return nil
+}
+
+// getRevision loads a revision that belongs to the given lesson.
+func getRevision(db *gorm.DB, lessonID, revisionID uint) (*LessonRevision, error) {
+	var revision LessonRevision
+	if err := db.Where("id = ? AND lesson_id = ?", revisionID, lessonID).First(&revision).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// buildRevisionDiff compares the content of two revisions.
+func buildRevisionDiff(slug string, from, to *LessonRevision) *RevisionDiff {
+	lines := DiffLines(from.Markdown, to.Markdown)
+	diff := &RevisionDiff{
+		Slug:              slug,
+		From:              from.Summary(),
+		To:                to.Summary(),
+		TitleChanged:      from.Title != to.Title,
+		PublishedChanged:  from.IsPublished != to.IsPublished,
+		ExcalidrawChanged: !bytes.Equal(bytes.TrimSpace(from.Excalidraw), bytes.TrimSpace(to.Excalidraw)),
+		Lines:             lines,
+		Unified: UnifiedDiff(
+			fmt.Sprintf("%s@%d", slug, from.Number),
+			fmt.Sprintf("%s@%d", slug, to.Number),
+			lines, diffContextLines,
+		),
+	}
+	for _, l := range lines {
+		switch l.Op {
+		case DiffInsert:
+			diff.Added++
+		case DiffDelete:
+			diff.Removed++
+		}
+	}
+	return diff
+}
+
+// Summary returns the revision metadata without its content.
+func (r *LessonRevision) Summary() LessonRevisionSummary {
+	return LessonRevisionSummary{
+		ID:           r.ID,
+		Number:       r.Number,
+		Title:        r.Title,
+		IsPublished:  r.IsPublished,
+		AuthorID:     r.AuthorID,
+		AuthorEmail:  r.AuthorEmail,
+		RestoredFrom: r.RestoredFrom,
+		CreatedAt:    r.CreatedAt,
+	}
+}
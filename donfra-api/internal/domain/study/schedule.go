@@ -0,0 +1,175 @@
+package study
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"donfra-api/internal/pkg/tracing"
+)
+
+// ErrInvalidSchedule is returned when a lesson would be unpublished before it is published.
+var ErrInvalidSchedule = errors.New("unpublishAt must be after publishAt")
+
+// DefaultScheduleInterval is how often the PublishScheduler applies due schedules.
+const DefaultScheduleInterval = time.Minute
+
+// NullableTime is an optional timestamp in a PATCH body: Set reports whether the field
+// was present, so an explicit null (clear the schedule) differs from an omitted field.
+type NullableTime struct {
+	Set   bool
+	Value *time.Time
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullableTime) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	if bytes.Equal(data, []byte("null")) {
+		n.Value = nil
+		return nil
+	}
+	var t time.Time
+	if err := json.Unmarshal(data, &t); err != nil {
+		return err
+	}
+	n.Value = &t
+	return nil
+}
+
+// ValidateSchedule checks that a publish/unpublish window is ordered.
+func ValidateSchedule(publishAt, unpublishAt *time.Time) error {
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		return ErrInvalidSchedule
+	}
+	return nil
+}
+
+// IsPublishedAt reports whether learners can see the lesson at t. A due publishAt
+// publishes and a due unpublishAt hides the lesson even before the scheduler has
+// flipped IsPublished.
+func (l *Lesson) IsPublishedAt(t time.Time) bool {
+	published := l.IsPublished || (l.PublishAt != nil && !l.PublishAt.After(t))
+	if l.UnpublishAt != nil && !l.UnpublishAt.After(t) {
+		return false
+	}
+	return published
+}
+
+// publishedCondition is the SQL form of Lesson.IsPublishedAt for the given table alias.
+// It takes the evaluation time as its two bind parameters and never evaluates to NULL,
+// so it can be negated safely.
+func publishedCondition(table string) string {
+	return fmt.Sprintf("((%[1]s.is_published OR COALESCE(%[1]s.publish_at <= ?, FALSE)) AND (%[1]s.unpublish_at IS NULL OR %[1]s.unpublish_at > ?))", table)
+}
+
+// wherePublished filters lessons that are (or, with published=false, are not) visible at now.
+func wherePublished(db *gorm.DB, table string, now time.Time, published bool) *gorm.DB {
+	if published {
+		return db.Where(publishedCondition(table), now, now)
+	}
+	return db.Where("NOT "+publishedCondition(table), now, now)
+}
+
+// ApplyPublishSchedule flips IsPublished for lessons whose publishAt or unpublishAt is due,
+// clears the applied timestamps and records a revision for each change.
+// Returns the number of lessons updated.
+func (s *Service) ApplyPublishSchedule(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.ApplyPublishSchedule",
+		tracing.AttrDBOperation.String("UPDATE"),
+		tracing.AttrDBTable.String("lessons"),
+	)
+	defer span.End()
+
+	applied := 0
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var due []Lesson
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("publish_at <= ? OR unpublish_at <= ?", now, now).
+			Find(&due).Error; err != nil {
+			return err
+		}
+
+		for i := range due {
+			lesson := &due[i]
+			lesson.IsPublished = lesson.IsPublishedAt(now)
+			if lesson.PublishAt != nil && !lesson.PublishAt.After(now) {
+				lesson.PublishAt = nil
+			}
+			if lesson.UnpublishAt != nil && !lesson.UnpublishAt.After(now) {
+				lesson.UnpublishAt = nil
+			}
+			if err := tx.Model(lesson).Updates(map[string]any{
+				"is_published": lesson.IsPublished,
+				"publish_at":   lesson.PublishAt,
+				"unpublish_at": lesson.UnpublishAt,
+			}).Error; err != nil {
+				return err
+			}
+			if err := recordRevision(tx, lesson, RevisionAuthor{}, nil); err != nil {
+				return err
+			}
+			applied++
+		}
+		return nil
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return 0, err
+	}
+	if applied > 0 {
+		s.clearLessonCache(ctx)
+	}
+	return applied, nil
+}
+
+// PublishScheduler periodically applies due publish/unpublish schedules.
+type PublishScheduler struct {
+	svc      *Service
+	interval time.Duration
+}
+
+// NewPublishScheduler creates a scheduler that runs every interval.
+func NewPublishScheduler(svc *Service, interval time.Duration) *PublishScheduler {
+	if interval <= 0 {
+		interval = DefaultScheduleInterval
+	}
+	return &PublishScheduler{svc: svc, interval: interval}
+}
+
+// Start applies due schedules immediately and then on every tick.
+// This should be called in a goroutine as it blocks until the context is cancelled.
+func (p *PublishScheduler) Start(ctx context.Context) error {
+	log.Printf("[scheduler] applying lesson publish schedules every %s", p.interval)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		p.runOnce(ctx)
+		select {
+		case <-ctx.Done():
+			log.Println("[scheduler] publish scheduler shutting down")
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *PublishScheduler) runOnce(ctx context.Context) {
+	n, err := p.svc.ApplyPublishSchedule(ctx, time.Now())
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("[scheduler] failed to apply publish schedules: %v", err)
+		}
+		return
+	}
+	if n > 0 {
+		log.Printf("[scheduler] applied publish schedule to %d lesson(s)", n)
+	}
+}
@@ -0,0 +1,70 @@
+package study_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"donfra-api/internal/domain/study"
+)
+
+func TestLessonIsPublishedAt(t *testing.T) {
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	cases := []struct {
+		name   string
+		lesson study.Lesson
+		want   bool
+	}{
+		{"draft", study.Lesson{}, false},
+		{"published", study.Lesson{IsPublished: true}, true},
+		{"publish due", study.Lesson{PublishAt: &past}, true},
+		{"publish pending", study.Lesson{PublishAt: &future}, false},
+		{"unpublish due", study.Lesson{IsPublished: true, UnpublishAt: &past}, false},
+		{"unpublish pending", study.Lesson{IsPublished: true, UnpublishAt: &future}, true},
+		{"window open", study.Lesson{PublishAt: &past, UnpublishAt: &future}, true},
+		{"publish exactly now", study.Lesson{PublishAt: &now}, true},
+	}
+	for _, tc := range cases {
+		if got := tc.lesson.IsPublishedAt(now); got != tc.want {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestValidateSchedule(t *testing.T) {
+	start := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	if err := study.ValidateSchedule(&start, &end); err != nil {
+		t.Errorf("expected ordered window to be valid, got %v", err)
+	}
+	if err := study.ValidateSchedule(&end, &start); !errors.Is(err, study.ErrInvalidSchedule) {
+		t.Errorf("expected ErrInvalidSchedule, got %v", err)
+	}
+	if err := study.ValidateSchedule(nil, &start); err != nil {
+		t.Errorf("expected open-ended window to be valid, got %v", err)
+	}
+}
+
+func TestNullableTime(t *testing.T) {
+	var req study.UpdateLessonRequest
+	if err := json.Unmarshal([]byte(`{"publishAt":"2026-05-01T09:00:00Z","unpublishAt":null}`), &req); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !req.PublishAt.Set || req.PublishAt.Value == nil || req.PublishAt.Value.Hour() != 9 {
+		t.Errorf("expected publishAt to be set, got %+v", req.PublishAt)
+	}
+	if !req.UnpublishAt.Set || req.UnpublishAt.Value != nil {
+		t.Errorf("expected explicit null to clear unpublishAt, got %+v", req.UnpublishAt)
+	}
+
+	var omitted study.UpdateLessonRequest
+	_ = json.Unmarshal([]byte(`{"title":"x"}`), &omitted)
+	if omitted.PublishAt.Set || omitted.UnpublishAt.Set {
+		t.Error("omitted fields should not be marked as set")
+	}
+}
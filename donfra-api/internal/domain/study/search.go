@@ -0,0 +1,98 @@
+package study
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"donfra-api/internal/pkg/tracing"
+)
+
+var (
+	// ErrSearchQueryRequired is returned when the search query is blank.
+	ErrSearchQueryRequired = errors.New("search query is required")
+	// ErrSearchQueryTooLong is returned when the search query exceeds MaxSearchQueryLength.
+	ErrSearchQueryTooLong = errors.New("search query is too long")
+)
+
+const (
+	// MaxSearchQueryLength is the maximum number of characters in a search query.
+	MaxSearchQueryLength = 200
+	// DefaultSearchLimit is the number of hits returned when no limit is given.
+	DefaultSearchLimit = 20
+	// MaxSearchLimit caps the number of hits per request.
+	MaxSearchLimit = 50
+)
+
+// Highlight markers used by ts_headline. They come from the Unicode private use
+// area so they never collide with lesson text; they are swapped for <mark> tags
+// after the snippet has been HTML-escaped.
+const (
+	highlightStart = "\uE000"
+	highlightStop  = "\uE001"
+)
+
+// SearchLessons runs a ranked full-text search over lesson titles and markdown.
+// Unpublished lessons are only searched when includeUnpublished is true.
+// Snippets of role-gated lessons the viewer cannot read are left empty.
+func (s *Service) SearchLessons(ctx context.Context, query string, viewer LessonViewer, includeUnpublished bool, limit int) ([]LessonSearchResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.SearchLessons",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("lessons"),
+	)
+	defer span.End()
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrSearchQueryRequired
+	}
+	if utf8.RuneCountInString(query) > MaxSearchQueryLength {
+		return nil, ErrSearchQueryTooLong
+	}
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	limit = min(limit, MaxSearchLimit)
+
+	snippetOpts := fmt.Sprintf("StartSel=%s, StopSel=%s, MaxFragments=2, MaxWords=30, MinWords=10, FragmentDelimiter=\" … \"",
+		highlightStart, highlightStop)
+	titleOpts := fmt.Sprintf("StartSel=%s, StopSel=%s, HighlightAll=true", highlightStart, highlightStop)
+
+	db := s.db.WithContext(ctx).
+		Table("lessons, websearch_to_tsquery('english', ?) AS q", query).
+		Select(`lessons.id, lessons.slug, lessons.title, lessons.is_published, lessons.required_role, lessons.created_at, lessons.updated_at,
+			ts_rank_cd(lessons.search_vector, q) AS rank,
+			ts_headline('english', lessons.title, q, ?) AS title_highlight,
+			ts_headline('english', lessons.markdown, q, ?) AS snippet`, titleOpts, snippetOpts).
+		Where("lessons.search_vector @@ q")
+	if !includeUnpublished {
+		db = wherePublished(db, "lessons", time.Now(), true)
+	}
+
+	var results []LessonSearchResult
+	if err := db.Order("rank DESC, lessons.updated_at DESC").Limit(limit).Scan(&results).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	for i := range results {
+		results[i].TitleHighlight = renderHighlight(results[i].TitleHighlight)
+		if !viewer.HasRequiredRole(results[i].RequiredRole) {
+			results[i].Snippet = ""
+			continue
+		}
+		results[i].Snippet = renderHighlight(results[i].Snippet)
+	}
+	span.SetAttributes(tracing.AttrResponseCount.Int(len(results)))
+	return results, nil
+}
+
+// renderHighlight escapes a ts_headline fragment and turns the markers into <mark> tags
+func renderHighlight(fragment string) string {
+	escaped := html.EscapeString(fragment)
+	return strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>").Replace(escaped)
+}
@@ -3,7 +3,11 @@ package study
 import (
 	"context"
 	"errors"
+	"maps"
+	"strings"
+	"time"
 
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 
 	"donfra-api/internal/pkg/tracing"
@@ -11,11 +15,24 @@ import (
 
 // Service implements CRUD operations for lessons.
 type Service struct {
-	db *gorm.DB
+	db    *gorm.DB
+	blobs BlobStore
+	cache LessonCache
+
+	previewSecret []byte
+}
+
+// NewService creates the study service. blobs stores lesson assets; when nil,
+// asset endpoints report that storage is not configured. previewSecret signs
+// preview links for unpublished lessons.
+func NewService(db *gorm.DB, blobs BlobStore, previewSecret string) *Service {
+	return &Service{db: db, blobs: blobs, previewSecret: []byte(previewSecret)}
 }
 
-func NewService(db *gorm.DB) *Service {
-	return &Service{db: db}
+// SetLessonCache enables caching of GetLessonBySlug. Lesson writes made through the
+// service invalidate the cache; pass nil to disable it.
+func (s *Service) SetLessonCache(cache LessonCache) {
+	s.cache = cache
 }
 
 // GetLessonBySlug retrieves a lesson by its slug.
@@ -27,16 +44,42 @@ func (s *Service) GetLessonBySlug(ctx context.Context, slug string) (*Lesson, er
 	)
 	defer span.End()
 
+	if s.cache != nil {
+		if lesson, ok := s.cache.Get(ctx, slug); ok {
+			span.SetAttributes(tracing.AttrCacheHit.Bool(true))
+			return lesson, nil
+		}
+	}
+
 	var lesson Lesson
 	if err := s.db.WithContext(ctx).Where("slug = ?", slug).First(&lesson).Error; err != nil {
 		tracing.RecordError(span, err)
 		return nil, err
 	}
+	if s.cache != nil {
+		s.cache.Set(ctx, &lesson)
+	}
 	return &lesson, nil
 }
 
-// CreateLesson inserts a lesson. Caller must ensure admin authorization (e.g., via middleware).
-func (s *Service) CreateLesson(ctx context.Context, newLesson *Lesson) (*Lesson, error) {
+// invalidateLessons drops cached lessons after a write
+func (s *Service) invalidateLessons(ctx context.Context, slugs ...string) {
+	if s.cache != nil {
+		s.cache.Invalidate(ctx, slugs...)
+	}
+}
+
+// clearLessonCache drops every cached lesson after a bulk write
+func (s *Service) clearLessonCache(ctx context.Context) {
+	if s.cache != nil {
+		s.cache.Clear(ctx)
+	}
+}
+
+// CreateLesson inserts a lesson and records its first revision. PublishAt/UnpublishAt
+// schedule the lesson to be published or hidden later (see PublishScheduler).
+// Caller must ensure admin authorization (e.g., via middleware).
+func (s *Service) CreateLesson(ctx context.Context, newLesson *Lesson, author RevisionAuthor) (*Lesson, error) {
 	ctx, span := tracing.StartSpan(ctx, "study.CreateLesson",
 		tracing.AttrDBOperation.String("INSERT"),
 		tracing.AttrDBTable.String("lessons"),
@@ -45,69 +88,198 @@ func (s *Service) CreateLesson(ctx context.Context, newLesson *Lesson) (*Lesson,
 	)
 	defer span.End()
 
-	if err := s.db.WithContext(ctx).Create(newLesson).Error; err != nil {
+	if err := ValidateSlug(newLesson.Slug); err != nil {
+		return nil, err
+	}
+	if err := ValidateSchedule(newLesson.PublishAt, newLesson.UnpublishAt); err != nil {
+		return nil, err
+	}
+	excalidraw, err := NormalizeExcalidraw(newLesson.Excalidraw)
+	if err != nil {
+		return nil, err
+	}
+	newLesson.Excalidraw = excalidraw
+	if newLesson.RequiredRole, err = NormalizeRequiredRole(newLesson.RequiredRole); err != nil {
+		return nil, err
+	}
+	newLesson.Category = strings.TrimSpace(newLesson.Category)
+	newLesson.Tags = NormalizeTags(newLesson.Tags)
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(newLesson).Error; err != nil {
+			return err
+		}
+		// A new lesson takes precedence over a redirect left by a renamed one
+		if err := releaseSlug(tx, newLesson.Slug); err != nil {
+			return err
+		}
+		return recordRevision(tx, newLesson, author, nil)
+	})
+	if err != nil {
 		tracing.RecordError(span, err)
 		return nil, err
 	}
+	s.invalidateLessons(ctx, newLesson.Slug)
 
 	return newLesson, nil
 }
 
-// UpdateLessonBySlug updates fields for the given lesson slug.
-func (s *Service) UpdateLessonBySlug(ctx context.Context, slug string, updates map[string]any) error {
+// UpdateLessonBySlug updates fields for the given lesson slug and records the
+// resulting content as a new revision. A "slug" update renames the lesson and keeps
+// the old slug as a redirect. The editor must pass CheckLessonEditable.
+func (s *Service) UpdateLessonBySlug(ctx context.Context, slug string, updates map[string]any, editor LessonEditor, author RevisionAuthor) error {
 	if len(updates) == 0 {
 		return errors.New("no updates provided")
 	}
-	res := s.db.WithContext(ctx).Model(&Lesson{}).Where("slug = ?", slug).Updates(updates)
-	if res.Error != nil {
-		return res.Error
+	if raw, ok := updates["excalidraw"]; ok {
+		var data []byte
+		switch v := raw.(type) {
+		case datatypes.JSON:
+			data = v
+		case []byte:
+			data = v
+		case string:
+			data = []byte(v)
+		}
+		excalidraw, err := NormalizeExcalidraw(data)
+		if err != nil {
+			return err
+		}
+		updates = maps.Clone(updates)
+		updates["excalidraw"] = excalidraw
 	}
-	if res.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+	if raw, ok := updates["required_role"]; ok {
+		role, _ := raw.(string)
+		role, err := NormalizeRequiredRole(role)
+		if err != nil {
+			return err
+		}
+		updates = maps.Clone(updates)
+		updates["required_role"] = role
 	}
+	var lesson *Lesson
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if lesson, err = lockEditableLesson(tx, slug, editor); err != nil {
+			return err
+		}
+
+		fields := make(map[string]any, len(updates))
+		for k, v := range updates {
+			fields[k] = v
+		}
+		if newSlug, ok := fields["slug"].(string); ok {
+			delete(fields, "slug")
+			if newSlug != lesson.Slug {
+				if err := renameLesson(tx, lesson, newSlug); err != nil {
+					return err
+				}
+			}
+		}
+		if len(fields) > 0 {
+			if err := tx.Model(lesson).Updates(fields).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.First(lesson, lesson.ID).Error; err != nil {
+			return err
+		}
+		// Validate the resulting window, since a PATCH may change only one side of it
+		if err := ValidateSchedule(lesson.PublishAt, lesson.UnpublishAt); err != nil {
+			return err
+		}
+		return recordRevision(tx, lesson, author, nil)
+	})
+	if err != nil {
+		return err
+	}
+	// lesson.Slug is the new slug after a rename
+	s.invalidateLessons(ctx, slug, lesson.Slug)
 	return nil
 }
 
-// DeleteLessonBySlug deletes a lesson by slug.
+// DeleteLessonBySlug deletes a lesson by slug. Lessons that required it lose that
+// prerequisite and count as updated.
 func (s *Service) DeleteLessonBySlug(ctx context.Context, slug string) error {
-	res := s.db.WithContext(ctx).Where("slug = ?", slug).Delete(&Lesson{})
-	if res.Error != nil {
-		return res.Error
-	}
-	if res.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+	var dependents []string
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var lesson Lesson
+		if err := tx.Select("id").Where("slug = ?", slug).First(&lesson).Error; err != nil {
+			return err
+		}
+		var ids []uint
+		if err := tx.Model(&LessonPrerequisite{}).Where("prerequisite_id = ?", lesson.ID).Pluck("lesson_id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) > 0 {
+			if err := tx.Model(&Lesson{}).Where("id IN ?", ids).Pluck("slug", &dependents).Error; err != nil {
+				return err
+			}
+			if err := touchLessons(tx, ids...); err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&lesson).Error
+	})
+	if err != nil {
+		return err
 	}
+	s.invalidateLessons(ctx, append(dependents, slug)...)
 	return nil
 }
 
-// ListPublishedLessons returns all lessons marked as published.
-func (s *Service) ListPublishedLessons(ctx context.Context) ([]Lesson, error) {
-	ctx, span := tracing.StartSpan(ctx, "study.ListPublishedLessons",
+// LessonModifiedAt returns when the detail of a lesson last changed for a viewer: the
+// latest update of the lesson, its prerequisite lessons and, when userID is set, the
+// user's progress, exercise results and quiz attempts on it. Writes to exercises,
+// quizzes and prerequisites bump the lesson's updated_at, see touchLessons.
+func (s *Service) LessonModifiedAt(ctx context.Context, lesson *Lesson, userID *uint) (time.Time, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.LessonModifiedAt",
 		tracing.AttrDBOperation.String("SELECT"),
 		tracing.AttrDBTable.String("lessons"),
+		tracing.AttrLessonSlug.String(lesson.Slug),
 	)
 	defer span.End()
 
-	var lessons []Lesson
-	if err := s.db.WithContext(ctx).Where("is_published = ?", true).Find(&lessons).Error; err != nil {
+	var viewer uint
+	if userID != nil {
+		viewer = *userID
+	}
+	var row struct{ Latest *time.Time }
+	if err := s.db.WithContext(ctx).Raw(`SELECT GREATEST(
+		(SELECT MAX(l.updated_at) FROM lesson_prerequisites lp JOIN lessons l ON l.id = lp.prerequisite_id WHERE lp.lesson_id = ?),
+		(SELECT MAX(updated_at) FROM lesson_progress WHERE user_id = ? AND (lesson_id = ? OR lesson_id IN (SELECT prerequisite_id FROM lesson_prerequisites WHERE lesson_id = ?))),
+		(SELECT MAX(r.updated_at) FROM exercise_results r JOIN lesson_exercises e ON e.id = r.exercise_id WHERE e.lesson_id = ? AND r.user_id = ?),
+		(SELECT MAX(a.created_at) FROM quiz_attempts a JOIN lesson_quizzes q ON q.id = a.quiz_id WHERE q.lesson_id = ? AND a.user_id = ?)
+	) AS latest`, lesson.ID, viewer, lesson.ID, lesson.ID, lesson.ID, viewer, lesson.ID, viewer).Scan(&row).Error; err != nil {
 		tracing.RecordError(span, err)
-		return nil, err
+		return time.Time{}, err
 	}
-	return lessons, nil
+
+	latest := lesson.UpdatedAt
+	if row.Latest != nil && row.Latest.After(latest) {
+		latest = *row.Latest
+	}
+	return latest, nil
 }
 
-// ListAllLessons returns all lessons (both published and unpublished).
-func (s *Service) ListAllLessons(ctx context.Context) ([]Lesson, error) {
-	ctx, span := tracing.StartSpan(ctx, "study.ListAllLessons",
-		tracing.AttrDBOperation.String("SELECT"),
-		tracing.AttrDBTable.String("lessons"),
-	)
-	defer span.End()
+// touchLessons bumps updated_at of lessons whose exercises, quiz or prerequisites
+// changed, so conditional requests for their detail see the change
+func touchLessons(tx *gorm.DB, ids ...uint) error {
+	return tx.Model(&Lesson{}).Where("id IN ?", ids).Update("updated_at", time.Now()).Error
+}
 
-	var lessons []Lesson
-	if err := s.db.WithContext(ctx).Find(&lessons).Error; err != nil {
-		tracing.RecordError(span, err)
-		return nil, err
+// NormalizeTags lowercases, trims and de-duplicates lesson tags.
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	out := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		out = append(out, t)
 	}
-	return lessons, nil
+	return out
 }
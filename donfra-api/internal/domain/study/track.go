@@ -0,0 +1,270 @@
+package study
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"donfra-api/internal/pkg/tracing"
+)
+
+var (
+	// ErrTrackNotFound is returned when a track does not exist or is not visible to the caller.
+	ErrTrackNotFound = errors.New("track not found")
+	// ErrTrackSlugRequired is returned when creating a track without a slug.
+	ErrTrackSlugRequired = errors.New("slug is required")
+	// ErrTrackTitleRequired is returned when a track has no title.
+	ErrTrackTitleRequired = errors.New("title is required")
+	// ErrTrackSlugTaken is returned when another track already uses the slug.
+	ErrTrackSlugTaken = errors.New("track slug already exists")
+	// ErrUnknownLesson is returned when a track references a lesson that does not exist.
+	ErrUnknownLesson = errors.New("unknown lesson")
+	// ErrDuplicateTrackLesson is returned when a lesson appears twice in a track.
+	ErrDuplicateTrackLesson = errors.New("lesson appears more than once in track")
+)
+
+// ListTracks returns tracks ordered by title. Unpublished tracks are only included when requested.
+func (s *Service) ListTracks(ctx context.Context, includeUnpublished bool) ([]Track, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.ListTracks",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("tracks"),
+	)
+	defer span.End()
+
+	db := s.db.WithContext(ctx)
+	if !includeUnpublished {
+		db = db.Where("is_published = ?", true)
+	}
+	var tracks []Track
+	if err := db.Order("title ASC").Find(&tracks).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return tracks, nil
+}
+
+// GetTrack returns a track with its ordered lessons and previous/next navigation.
+// When includeUnpublished is false, unpublished tracks are hidden and unpublished
+// lessons are skipped (navigation links only point at visible lessons).
+func (s *Service) GetTrack(ctx context.Context, slug string, includeUnpublished bool) (*TrackDetail, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.GetTrack",
+		tracing.AttrDBOperation.String("SELECT"),
+		tracing.AttrDBTable.String("tracks"),
+	)
+	defer span.End()
+
+	track, err := getTrack(s.db.WithContext(ctx), slug)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	if !track.IsPublished && !includeUnpublished {
+		return nil, ErrTrackNotFound
+	}
+
+	db := s.db.WithContext(ctx).
+		Table("track_lessons").
+		Select("track_lessons.position, lessons.slug, lessons.title, lessons.is_published").
+		Joins("JOIN lessons ON lessons.id = track_lessons.lesson_id").
+		Where("track_lessons.track_id = ?", track.ID)
+	if !includeUnpublished {
+		db = wherePublished(db, "lessons", time.Now(), true)
+	}
+
+	var entries []TrackLessonEntry
+	if err := db.Order("track_lessons.position ASC").Scan(&entries).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	linkTrackEntries(entries)
+
+	if entries == nil {
+		entries = []TrackLessonEntry{}
+	}
+	return &TrackDetail{Track: *track, Lessons: entries}, nil
+}
+
+// CreateTrack creates a track with an optional initial ordered list of lessons.
+func (s *Service) CreateTrack(ctx context.Context, req *CreateTrackRequest) (*TrackDetail, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.CreateTrack",
+		tracing.AttrDBOperation.String("INSERT"),
+		tracing.AttrDBTable.String("tracks"),
+	)
+	defer span.End()
+
+	track := &Track{
+		Slug:        strings.TrimSpace(req.Slug),
+		Title:       strings.TrimSpace(req.Title),
+		Description: req.Description,
+		IsPublished: req.IsPublished,
+	}
+	if track.Slug == "" {
+		return nil, ErrTrackSlugRequired
+	}
+	if track.Title == "" {
+		return nil, ErrTrackTitleRequired
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&Track{}).Where("slug = ?", track.Slug).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrTrackSlugTaken
+		}
+		if err := tx.Create(track).Error; err != nil {
+			return err
+		}
+		return replaceTrackLessons(tx, track.ID, req.LessonSlugs)
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return s.GetTrack(ctx, track.Slug, true)
+}
+
+// UpdateTrack updates the metadata of a track.
+func (s *Service) UpdateTrack(ctx context.Context, slug string, req *UpdateTrackRequest) (*Track, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.UpdateTrack",
+		tracing.AttrDBOperation.String("UPDATE"),
+		tracing.AttrDBTable.String("tracks"),
+	)
+	defer span.End()
+
+	track, err := getTrack(s.db.WithContext(ctx), slug)
+	if err != nil {
+		return nil, err
+	}
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" {
+			return nil, ErrTrackTitleRequired
+		}
+		track.Title = title
+	}
+	if req.Description != nil {
+		track.Description = *req.Description
+	}
+	if req.IsPublished != nil {
+		track.IsPublished = *req.IsPublished
+	}
+
+	if err := s.db.WithContext(ctx).Save(track).Error; err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return track, nil
+}
+
+// SetTrackLessons replaces the ordered lessons of a track.
+func (s *Service) SetTrackLessons(ctx context.Context, slug string, lessonSlugs []string) (*TrackDetail, error) {
+	ctx, span := tracing.StartSpan(ctx, "study.SetTrackLessons",
+		tracing.AttrDBOperation.String("UPDATE"),
+		tracing.AttrDBTable.String("track_lessons"),
+	)
+	defer span.End()
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		track, err := getTrack(tx, slug)
+		if err != nil {
+			return err
+		}
+		if err := replaceTrackLessons(tx, track.ID, lessonSlugs); err != nil {
+			return err
+		}
+		return tx.Model(track).Update("updated_at", gorm.Expr("NOW()")).Error
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return s.GetTrack(ctx, slug, true)
+}
+
+// DeleteTrack deletes a track. Its lessons are left untouched.
+func (s *Service) DeleteTrack(ctx context.Context, slug string) error {
+	ctx, span := tracing.StartSpan(ctx, "study.DeleteTrack",
+		tracing.AttrDBOperation.String("DELETE"),
+		tracing.AttrDBTable.String("tracks"),
+	)
+	defer span.End()
+
+	res := s.db.WithContext(ctx).Where("slug = ?", slug).Delete(&Track{})
+	if res.Error != nil {
+		tracing.RecordError(span, res.Error)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrTrackNotFound
+	}
+	return nil
+}
+
+// getTrack loads a track by slug
+func getTrack(db *gorm.DB, slug string) (*Track, error) {
+	var track Track
+	if err := db.Where("slug = ?", slug).First(&track).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTrackNotFound
+		}
+		return nil, err
+	}
+	return &track, nil
+}
+
+// replaceTrackLessons resolves lesson slugs and stores them at positions 1..n
+func replaceTrackLessons(tx *gorm.DB, trackID uint, lessonSlugs []string) error {
+	seen := make(map[string]bool, len(lessonSlugs))
+	for _, slug := range lessonSlugs {
+		if seen[slug] {
+			return fmt.Errorf("%w: %s", ErrDuplicateTrackLesson, slug)
+		}
+		seen[slug] = true
+	}
+
+	var lessons []Lesson
+	if len(lessonSlugs) > 0 {
+		if err := tx.Select("id", "slug").Where("slug IN ?", lessonSlugs).Find(&lessons).Error; err != nil {
+			return err
+		}
+	}
+	ids := make(map[string]uint, len(lessons))
+	for _, l := range lessons {
+		ids[l.Slug] = l.ID
+	}
+
+	rows := make([]TrackLesson, 0, len(lessonSlugs))
+	for i, slug := range lessonSlugs {
+		id, ok := ids[slug]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownLesson, slug)
+		}
+		rows = append(rows, TrackLesson{TrackID: trackID, LessonID: id, Position: i + 1})
+	}
+
+	if err := tx.Where("track_id = ?", trackID).Delete(&TrackLesson{}).Error; err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.Create(&rows).Error
+}
+
+// linkTrackEntries fills in previous/next navigation between consecutive entries
+func linkTrackEntries(entries []TrackLessonEntry) {
+	for i := range entries {
+		if i > 0 {
+			entries[i].PreviousSlug = entries[i-1].Slug
+		}
+		if i < len(entries)-1 {
+			entries[i].NextSlug = entries[i+1].Slug
+		}
+	}
+}
@@ -13,6 +13,8 @@ type Claims struct {
 	UserID uint   `json:"user_id"`
 	Email  string `json:"email"`
 	Role   string `json:"role"`
+	// SessionVersion must match the user's current version; see Service.ValidateToken
+	SessionVersion int `json:"sv,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -27,9 +29,10 @@ func GenerateToken(user *User, secret string, expiryHours int) (string, error) {
 	}
 
 	claims := Claims{
-		UserID: user.ID,
-		Email:  user.Email,
-		Role:   user.Role,
+		UserID:         user.ID,
+		Email:          user.Email,
+		Role:           user.Role,
+		SessionVersion: user.SessionVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   fmt.Sprintf("%d", user.ID),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(expiryHours) * time.Hour)),
@@ -8,15 +8,16 @@ import (
 
 // User represents a user in the system.
 type User struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	Email     string         `gorm:"uniqueIndex;not null" json:"email"`
-	Password  string         `gorm:"not null" json:"-"` // Never expose password in JSON
-	Username  string         `gorm:"index" json:"username"`
-	Role      string         `gorm:"not null;default:'user'" json:"role"` // user, admin, mentor
-	IsActive  bool           `gorm:"not null;default:true" json:"isActive"`
-	CreatedAt time.Time      `json:"createdAt"`
-	UpdatedAt time.Time      `json:"updatedAt"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"` // Soft delete
+	ID             uint           `gorm:"primaryKey" json:"id"`
+	Email          string         `gorm:"uniqueIndex;not null" json:"email"`
+	Password       string         `gorm:"not null" json:"-"` // Never expose password in JSON
+	Username       string         `gorm:"index" json:"username"`
+	Role           string         `gorm:"not null;default:'user'" json:"role"` // user, admin, mentor
+	IsActive       bool           `gorm:"not null;default:true" json:"isActive"`
+	SessionVersion int            `gorm:"not null;default:0" json:"-"` // Embedded in JWTs; bumping it signs the user out everywhere
+	CreatedAt      time.Time      `json:"createdAt"`
+	UpdatedAt      time.Time      `json:"updatedAt"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"` // Soft delete
 }
 
 // TableName specifies the table name for GORM.
@@ -65,6 +66,33 @@ type LoginResponse struct {
 	Token string      `json:"token,omitempty"` // Optional: for clients that need it
 }
 
+// PasswordResetToken is a single-use password reset token. Only the SHA-256 hash of
+// the token sent by email is stored.
+type PasswordResetToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index"`
+	TokenHash string    `gorm:"uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// TableName specifies the table name for GORM.
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
+
+// ForgotPasswordRequest represents a request for a password reset email.
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// ResetPasswordRequest represents a request to set a new password with a reset token.
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
 // ErrorResponse represents an error response.
 type ErrorResponse struct {
 	Error string `json:"error"`
@@ -0,0 +1,147 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"donfra-api/internal/pkg/mailer"
+)
+
+const (
+	// PasswordResetTTL is how long a password reset link stays valid.
+	PasswordResetTTL = time.Hour
+	// PasswordResetRateLimit is the number of reset emails an account may receive per
+	// PasswordResetRateWindow.
+	PasswordResetRateLimit = 3
+	// PasswordResetRateWindow is the sliding window for PasswordResetRateLimit.
+	PasswordResetRateWindow = time.Hour
+)
+
+var (
+	// ErrInvalidResetToken is returned when a reset token is unknown, used or expired.
+	ErrInvalidResetToken = errors.New("invalid or expired reset token")
+	// ErrSessionRevoked is returned for a JWT issued before the user's password was reset.
+	ErrSessionRevoked = errors.New("session has been revoked")
+	// ErrMailerNotConfigured is returned when password reset emails cannot be sent.
+	ErrMailerNotConfigured = errors.New("mailer not configured")
+	// ErrPasswordResetRateLimited is returned when an account already received
+	// PasswordResetRateLimit reset emails within PasswordResetRateWindow.
+	ErrPasswordResetRateLimited = errors.New("too many password reset requests")
+)
+
+// SetMailer configures delivery of password reset emails. resetURL is the page that
+// receives the token as ?token=... and submits it to POST /api/auth/password/reset.
+func (s *Service) SetMailer(m mailer.Mailer, resetURL string) {
+	s.mailer = m
+	s.resetURL = resetURL
+}
+
+// hashResetToken returns the stored form of a reset token.
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// newResetToken returns a random URL-safe token with 256 bits of entropy.
+func newResetToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// resetLink appends token to the configured reset page URL.
+func (s *Service) resetLink(token string) (string, error) {
+	u, err := url.Parse(s.resetURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid password reset URL: %w", err)
+	}
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// RequestPasswordReset emails a single-use reset link to the account with email. Links
+// sent before stay valid until they expire or one of them is used. Unknown and inactive
+// accounts are ignored without an error; callers must still not report other errors,
+// so nobody can find out which emails are registered.
+func (s *Service) RequestPasswordReset(ctx context.Context, email string) error {
+	if s.mailer == nil {
+		return ErrMailerNotConfigured
+	}
+
+	email = strings.TrimSpace(strings.ToLower(email))
+	user, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user == nil || !user.IsActive {
+		return nil
+	}
+	recent, err := s.repo.CountPasswordResetTokens(ctx, user.ID, time.Now().Add(-PasswordResetRateWindow))
+	if err != nil {
+		return err
+	}
+	if recent >= PasswordResetRateLimit {
+		return ErrPasswordResetRateLimited
+	}
+
+	token, err := newResetToken()
+	if err != nil {
+		return err
+	}
+	record := &PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashResetToken(token),
+		ExpiresAt: time.Now().Add(PasswordResetTTL),
+	}
+	if err := s.repo.CreatePasswordResetToken(ctx, record); err != nil {
+		return err
+	}
+
+	link, err := s.resetLink(token)
+	if err != nil {
+		return err
+	}
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your Donfra password",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the link below to choose a new password. It expires in %s and can only be used once.\n\n%s\n\n"+
+			"If you did not ask to reset your password, you can ignore this email.\n",
+			user.Username, PasswordResetTTL, link),
+	})
+}
+
+// ResetPassword sets a new password with a token from RequestPasswordReset. The token
+// is consumed and every existing session of the user is signed out.
+func (s *Service) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if len(newPassword) < 8 {
+		return ErrPasswordTooShort
+	}
+
+	tokenHash := hashResetToken(strings.TrimSpace(token))
+	now := time.Now()
+	record, err := s.repo.FindPasswordResetToken(ctx, tokenHash)
+	if err != nil {
+		return err
+	}
+	if record == nil || record.UsedAt != nil || !record.ExpiresAt.After(now) {
+		return ErrInvalidResetToken
+	}
+
+	hashedPassword, err := HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	return s.repo.ResetPassword(ctx, tokenHash, hashedPassword, now)
+}
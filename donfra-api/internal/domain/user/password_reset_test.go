@@ -0,0 +1,284 @@
+package user_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"donfra-api/internal/domain/user"
+	"donfra-api/internal/pkg/mailer"
+)
+
+// memoryRepo is an in-memory user.Repository for service tests
+type memoryRepo struct {
+	users  map[uint]*user.User
+	tokens map[string]*user.PasswordResetToken
+}
+
+func newMemoryRepo(users ...*user.User) *memoryRepo {
+	r := &memoryRepo{users: map[uint]*user.User{}, tokens: map[string]*user.PasswordResetToken{}}
+	for _, u := range users {
+		r.users[u.ID] = u
+	}
+	return r
+}
+
+func (r *memoryRepo) Create(ctx context.Context, u *user.User) error {
+	u.ID = uint(len(r.users) + 1)
+	r.users[u.ID] = u
+	return nil
+}
+
+func (r *memoryRepo) FindByEmail(ctx context.Context, email string) (*user.User, error) {
+	for _, u := range r.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryRepo) FindByID(ctx context.Context, id uint) (*user.User, error) {
+	return r.users[id], nil
+}
+
+func (r *memoryRepo) FindSessionVersion(ctx context.Context, id uint) (*int, error) {
+	u := r.users[id]
+	if u == nil {
+		return nil, nil
+	}
+	version := u.SessionVersion
+	return &version, nil
+}
+
+func (r *memoryRepo) Update(ctx context.Context, u *user.User) error {
+	r.users[u.ID] = u
+	return nil
+}
+
+func (r *memoryRepo) Delete(ctx context.Context, id uint) error {
+	delete(r.users, id)
+	return nil
+}
+
+func (r *memoryRepo) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	u, _ := r.FindByEmail(ctx, email)
+	return u != nil, nil
+}
+
+func (r *memoryRepo) CreatePasswordResetToken(ctx context.Context, token *user.PasswordResetToken) error {
+	token.CreatedAt = time.Now()
+	r.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (r *memoryRepo) CountPasswordResetTokens(ctx context.Context, userID uint, since time.Time) (int64, error) {
+	var count int64
+	for _, t := range r.tokens {
+		if t.UserID == userID && t.CreatedAt.After(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryRepo) FindPasswordResetToken(ctx context.Context, tokenHash string) (*user.PasswordResetToken, error) {
+	return r.tokens[tokenHash], nil
+}
+
+func (r *memoryRepo) ResetPassword(ctx context.Context, tokenHash, passwordHash string, now time.Time) error {
+	t := r.tokens[tokenHash]
+	if t == nil || t.UsedAt != nil || !t.ExpiresAt.After(now) {
+		return user.ErrInvalidResetToken
+	}
+	u := r.users[t.UserID]
+	u.Password = passwordHash
+	u.SessionVersion++
+	for _, other := range r.tokens {
+		if other.UserID == t.UserID && other.UsedAt == nil {
+			other.UsedAt = &now
+		}
+	}
+	return nil
+}
+
+// recordingMailer keeps sent messages
+type recordingMailer struct {
+	sent []mailer.Message
+}
+
+func (m *recordingMailer) Send(ctx context.Context, msg mailer.Message) error {
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+var linkRegex = regexp.MustCompile(`https?://\S+`)
+
+// resetToken extracts the token from the reset link of a sent message
+func resetToken(t *testing.T, msg mailer.Message) string {
+	t.Helper()
+	u, err := url.Parse(linkRegex.FindString(msg.Body))
+	if err != nil {
+		t.Fatalf("no reset link in %q: %v", msg.Body, err)
+	}
+	token := u.Query().Get("token")
+	if token == "" {
+		t.Fatalf("no token in reset link %s", u)
+	}
+	return token
+}
+
+func newResetService(t *testing.T) (*user.Service, *memoryRepo, *recordingMailer) {
+	t.Helper()
+	hashed, err := user.HashPassword("old-password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := newMemoryRepo(&user.User{ID: 1, Email: "ada@example.com", Username: "ada", Password: hashed, Role: "user", IsActive: true})
+	svc := user.NewService(repo, "test-secret", 1)
+	m := &recordingMailer{}
+	svc.SetMailer(m, "http://localhost:3000/reset-password")
+	return svc, repo, m
+}
+
+func TestRequestPasswordReset_UnknownEmail(t *testing.T) {
+	svc, repo, m := newResetService(t)
+
+	if err := svc.RequestPasswordReset(context.Background(), "nobody@example.com"); err != nil {
+		t.Fatalf("unknown emails should not fail, got %v", err)
+	}
+	if len(m.sent) != 0 || len(repo.tokens) != 0 {
+		t.Errorf("expected no email and no token, got %d emails and %d tokens", len(m.sent), len(repo.tokens))
+	}
+}
+
+func TestRequestPasswordReset_StoresHashedToken(t *testing.T) {
+	svc, repo, m := newResetService(t)
+
+	if err := svc.RequestPasswordReset(context.Background(), " Ada@Example.com "); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.sent) != 1 || m.sent[0].To != "ada@example.com" {
+		t.Fatalf("expected one email to ada@example.com, got %+v", m.sent)
+	}
+	token := resetToken(t, m.sent[0])
+	sum := sha256.Sum256([]byte(token))
+	stored := repo.tokens[hex.EncodeToString(sum[:])]
+	if stored == nil {
+		t.Fatal("expected the token hash to be stored")
+	}
+	if _, ok := repo.tokens[token]; ok {
+		t.Error("the raw token must not be stored")
+	}
+	if stored.ExpiresAt.After(time.Now().Add(user.PasswordResetTTL)) {
+		t.Errorf("token expires too late: %s", stored.ExpiresAt)
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, m := newResetService(t)
+
+	_, session, err := svc.Login(ctx, &user.LoginRequest{Email: "ada@example.com", Password: "old-password"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RequestPasswordReset(ctx, "ada@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	token := resetToken(t, m.sent[0])
+
+	if err := svc.ResetPassword(ctx, token, "short"); !errors.Is(err, user.ErrPasswordTooShort) {
+		t.Errorf("expected ErrPasswordTooShort, got %v", err)
+	}
+	if err := svc.ResetPassword(ctx, "not-a-token", "new-password"); !errors.Is(err, user.ErrInvalidResetToken) {
+		t.Errorf("expected ErrInvalidResetToken for an unknown token, got %v", err)
+	}
+	if err := svc.ResetPassword(ctx, token, "new-password"); err != nil {
+		t.Fatalf("reset failed: %v", err)
+	}
+	if err := svc.ResetPassword(ctx, token, "another-password"); !errors.Is(err, user.ErrInvalidResetToken) {
+		t.Errorf("expected a used token to be rejected, got %v", err)
+	}
+
+	if err := user.VerifyPassword(repo.users[1].Password, "new-password"); err != nil {
+		t.Error("expected the new password to be set")
+	}
+	if _, err := svc.ValidateToken(ctx, session); !errors.Is(err, user.ErrSessionRevoked) {
+		t.Errorf("expected the old session to be revoked, got %v", err)
+	}
+	_, session, err = svc.Login(ctx, &user.LoginRequest{Email: "ada@example.com", Password: "new-password"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.ValidateToken(ctx, session); err != nil {
+		t.Errorf("expected a new session to be valid, got %v", err)
+	}
+}
+
+func TestResetPassword_EarlierTokensStayValid(t *testing.T) {
+	ctx := context.Background()
+	svc, _, m := newResetService(t)
+
+	for i := 0; i < 2; i++ {
+		if err := svc.RequestPasswordReset(ctx, "ada@example.com"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A second request (possibly by someone else) must not void the first link
+	if err := svc.ResetPassword(ctx, resetToken(t, m.sent[0]), "new-password"); err != nil {
+		t.Errorf("expected the earlier token to work, got %v", err)
+	}
+	if err := svc.ResetPassword(ctx, resetToken(t, m.sent[1]), "another-password"); !errors.Is(err, user.ErrInvalidResetToken) {
+		t.Errorf("expected a reset to consume every outstanding token, got %v", err)
+	}
+}
+
+func TestRequestPasswordReset_RateLimited(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, m := newResetService(t)
+
+	for i := 0; i < user.PasswordResetRateLimit; i++ {
+		if err := svc.RequestPasswordReset(ctx, "ada@example.com"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := svc.RequestPasswordReset(ctx, "ada@example.com"); !errors.Is(err, user.ErrPasswordResetRateLimited) {
+		t.Errorf("expected ErrPasswordResetRateLimited, got %v", err)
+	}
+	if len(m.sent) != user.PasswordResetRateLimit || len(repo.tokens) != user.PasswordResetRateLimit {
+		t.Errorf("expected %d emails and tokens, got %d and %d", user.PasswordResetRateLimit, len(m.sent), len(repo.tokens))
+	}
+
+	// Requests older than the window no longer count
+	for _, token := range repo.tokens {
+		token.CreatedAt = token.CreatedAt.Add(-user.PasswordResetRateWindow)
+	}
+	if err := svc.RequestPasswordReset(ctx, "ada@example.com"); err != nil {
+		t.Errorf("expected a request after the window to be sent, got %v", err)
+	}
+}
+
+func TestValidateToken_DeletedUser(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _ := newResetService(t)
+
+	_, session, err := svc.Login(ctx, &user.LoginRequest{Email: "ada@example.com", Password: "old-password"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.ValidateToken(ctx, session); err != nil {
+		t.Fatalf("expected the session to be valid, got %v", err)
+	}
+	if err := repo.Delete(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.ValidateToken(ctx, session); !errors.Is(err, user.ErrSessionRevoked) {
+		t.Errorf("expected the session of a deleted user to be revoked, got %v", err)
+	}
+}
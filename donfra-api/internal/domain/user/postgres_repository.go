@@ -3,8 +3,10 @@ package user
 import (
 	"context"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // PostgresRepository implements the Repository interface using PostgreSQL via GORM.
@@ -48,6 +50,19 @@ func (r *PostgresRepository) FindByID(ctx context.Context, id uint) (*User, erro
 	return &user, nil
 }
 
+// FindSessionVersion returns only the session version of a user, or nil if the
+// user does not exist.
+func (r *PostgresRepository) FindSessionVersion(ctx context.Context, id uint) (*int, error) {
+	var versions []int
+	if err := r.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Limit(1).Pluck("session_version", &versions).Error; err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, nil
+	}
+	return &versions[0], nil
+}
+
 // Update updates an existing user.
 func (r *PostgresRepository) Update(ctx context.Context, user *User) error {
 	return r.db.WithContext(ctx).Save(user).Error
@@ -71,3 +86,66 @@ func (r *PostgresRepository) ExistsByEmail(ctx context.Context, email string) (b
 	err := r.db.WithContext(ctx).Model(&User{}).Where("email = ?", email).Count(&count).Error
 	return count > 0, err
 }
+
+// CreatePasswordResetToken stores a new reset token. Earlier tokens stay valid until
+// they expire or a password reset consumes them all, so a new request by someone else
+// cannot void the link the account owner is about to use.
+func (r *PostgresRepository) CreatePasswordResetToken(ctx context.Context, token *PasswordResetToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+// CountPasswordResetTokens counts the reset tokens issued to a user since the given time.
+func (r *PostgresRepository) CountPasswordResetTokens(ctx context.Context, userID uint, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&PasswordResetToken{}).
+		Where("user_id = ? AND created_at > ?", userID, since).
+		Count(&count).Error
+	return count, err
+}
+
+// FindPasswordResetToken retrieves a reset token by its hash.
+func (r *PostgresRepository) FindPasswordResetToken(ctx context.Context, tokenHash string) (*PasswordResetToken, error) {
+	var token PasswordResetToken
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// ResetPassword atomically consumes an unused, unexpired reset token, sets the
+// user's password hash and bumps their session version. The token row is locked
+// so concurrent requests with the same token cannot both succeed.
+func (r *PostgresRepository) ResetPassword(ctx context.Context, tokenHash, passwordHash string, now time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var token PasswordResetToken
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+			First(&token).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidResetToken
+		}
+		if err != nil {
+			return err
+		}
+
+		result := tx.Model(&User{}).Where("id = ? AND is_active", token.UserID).Updates(map[string]any{
+			"password":        passwordHash,
+			"session_version": gorm.Expr("session_version + 1"),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInvalidResetToken
+		}
+
+		// Consume this token and any others still outstanding for the user
+		return tx.Model(&PasswordResetToken{}).
+			Where("user_id = ? AND used_at IS NULL", token.UserID).
+			Update("used_at", now).Error
+	})
+}
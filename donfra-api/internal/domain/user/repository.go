@@ -1,6 +1,9 @@
 package user
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Repository defines the interface for user data persistence.
 type Repository interface {
@@ -21,4 +24,22 @@ type Repository interface {
 
 	// ExistsByEmail checks if a user with the given email exists.
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
+
+	// FindSessionVersion returns only the session version of a user, or nil if the
+	// user does not exist. It runs on every authenticated request.
+	FindSessionVersion(ctx context.Context, id uint) (*int, error)
+
+	// CreatePasswordResetToken stores a new reset token.
+	CreatePasswordResetToken(ctx context.Context, token *PasswordResetToken) error
+
+	// CountPasswordResetTokens counts the reset tokens issued to a user since the given time.
+	CountPasswordResetTokens(ctx context.Context, userID uint, since time.Time) (int64, error)
+
+	// FindPasswordResetToken retrieves a reset token by its hash.
+	FindPasswordResetToken(ctx context.Context, tokenHash string) (*PasswordResetToken, error)
+
+	// ResetPassword atomically consumes an unused, unexpired reset token, sets the
+	// user's password hash and bumps their session version. Returns
+	// ErrInvalidResetToken if the token cannot be used.
+	ResetPassword(ctx context.Context, tokenHash, passwordHash string, now time.Time) error
 }
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"regexp"
 	"strings"
+
+	"donfra-api/internal/pkg/mailer"
 )
 
 var (
@@ -30,6 +32,8 @@ type Service struct {
 	repo        Repository
 	jwtSecret   string
 	jwtExpiry   int // JWT expiry in hours
+	mailer      mailer.Mailer
+	resetURL    string // Page that receives password reset tokens, see SetMailer
 }
 
 // NewService creates a new user service.
@@ -128,8 +132,23 @@ func (s *Service) Login(ctx context.Context, req *LoginRequest) (*User, string,
 }
 
 // ValidateToken validates a JWT token and returns the claims.
-func (s *Service) ValidateToken(tokenString string) (*Claims, error) {
-	return ValidateToken(tokenString, s.jwtSecret)
+// Tokens of deleted users and tokens issued before the user's last password reset
+// are rejected with ErrSessionRevoked.
+func (s *Service) ValidateToken(ctx context.Context, tokenString string) (*Claims, error) {
+	claims, err := ValidateToken(tokenString, s.jwtSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := s.repo.FindSessionVersion(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if version == nil || *version != claims.SessionVersion {
+		return nil, ErrSessionRevoked
+	}
+
+	return claims, nil
 }
 
 // GetUserByID retrieves a user by their ID.
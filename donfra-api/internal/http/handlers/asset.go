@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/pkg/httputil"
+	"donfra-api/internal/pkg/tracing"
+)
+
+// ListAssetsHandler handles GET /api/assets. Requires AdminOnly middleware.
+func (h *Handlers) ListAssetsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.ListAssets")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	assets, err := h.studySvc.ListAssets(ctx)
+	if err != nil {
+		tracing.RecordError(span, err)
+		httputil.WriteError(w, http.StatusInternalServerError, "failed to load assets")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, assets)
+}
+
+// UploadAssetHandler handles POST /api/assets with the file in the "file" field of a
+// multipart form. Returns 201 for a new asset, or 200 with the existing asset when the
+// same content was uploaded before. Requires RequireLessonAuthor middleware.
+func (h *Handlers) UploadAssetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.UploadAsset")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	// Leave room for the multipart envelope around the file
+	r.Body = http.MaxBytesReader(w, r.Body, study.MaxAssetSize+1<<20)
+	if err := r.ParseMultipartForm(study.MaxAssetSize); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			httputil.WriteError(w, http.StatusRequestEntityTooLarge, study.ErrAssetTooLarge.Error())
+			return
+		}
+		httputil.WriteError(w, http.StatusBadRequest, "expected a multipart form")
+		return
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, `multipart field "file" is required`)
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, study.MaxAssetSize+1))
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "failed to read file")
+		return
+	}
+
+	var uploadedBy *uint
+	if userID, ok := ctx.Value("user_id").(uint); ok {
+		uploadedBy = &userID
+	}
+
+	asset, created, err := h.studySvc.UploadAsset(ctx, header.Filename, content, uploadedBy)
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeAssetError(w, err, "failed to upload asset")
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	httputil.WriteJSON(w, status, asset)
+}
+
+// DeleteAssetHandler handles DELETE /api/assets/{key} (the asset key or its hash). Assets still referenced by a
+// lesson are kept unless ?force=true. Requires AdminOnly middleware.
+func (h *Handlers) DeleteAssetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.DeleteAsset")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	force := false
+	if raw := r.URL.Query().Get("force"); raw != "" {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			httputil.WriteError(w, http.StatusBadRequest, "force must be true or false")
+			return
+		}
+		force = v
+	}
+
+	if err := h.studySvc.DeleteAsset(ctx, chi.URLParam(r, "key"), force); err != nil {
+		tracing.RecordError(span, err)
+		writeAssetError(w, err, "failed to delete asset")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ServeAssetHandler handles GET /api/assets/{key}. Keys are content hashes, so responses
+// are cached forever and revalidated by ETag.
+func (h *Handlers) ServeAssetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.ServeAsset")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	asset, content, err := h.studySvc.OpenAsset(ctx, chi.URLParam(r, "key"))
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeAssetError(w, err, "failed to load asset")
+		return
+	}
+	defer content.Close()
+
+	etag := fmt.Sprintf(`"%s"`, asset.Hash)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", asset.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(asset.Size, 10))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, content)
+}
+
+// writeAssetError maps study asset errors to HTTP responses
+func writeAssetError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, study.ErrAssetNotFound):
+		httputil.WriteError(w, http.StatusNotFound, "asset not found")
+	case errors.Is(err, study.ErrAssetTooLarge):
+		httputil.WriteError(w, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, study.ErrUnsupportedAssetType):
+		httputil.WriteError(w, http.StatusUnsupportedMediaType, err.Error())
+	case errors.Is(err, study.ErrAssetEmpty):
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, study.ErrAssetInUse):
+		httputil.WriteError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, study.ErrAssetStoreUnavailable):
+		httputil.WriteError(w, http.StatusServiceUnavailable, err.Error())
+	default:
+		httputil.WriteError(w, http.StatusInternalServerError, fallback)
+	}
+}
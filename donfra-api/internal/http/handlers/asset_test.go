@@ -0,0 +1,109 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/http/handlers"
+)
+
+// multipartFile builds a multipart upload request with a "file" field
+func multipartFile(t *testing.T, filename string, content []byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw.Write(content)
+	mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/assets", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req
+}
+
+// TestUploadAsset_CreatedAndDeduplicated tests 201 for new content and 200 for known content
+func TestUploadAsset_CreatedAndDeduplicated(t *testing.T) {
+	seen := map[string]bool{}
+	mockStudy := &MockStudyService{
+		UploadAssetFunc: func(ctx context.Context, filename string, content []byte, uploadedBy *uint) (*study.Asset, bool, error) {
+			created := !seen[string(content)]
+			seen[string(content)] = true
+			return &study.Asset{Hash: "abc", Key: "abc.png", Filename: filename, URL: study.AssetURL("abc.png")}, created, nil
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	for _, want := range []int{http.StatusCreated, http.StatusOK} {
+		w := httptest.NewRecorder()
+		h.UploadAssetHandler(w, multipartFile(t, "diagram.png", []byte("pixels")))
+		if w.Code != want {
+			t.Fatalf("expected status %d, got %d", want, w.Code)
+		}
+	}
+}
+
+// TestUploadAsset_UnsupportedType tests that rejected content types return 415
+func TestUploadAsset_UnsupportedType(t *testing.T) {
+	mockStudy := &MockStudyService{
+		UploadAssetFunc: func(ctx context.Context, filename string, content []byte, uploadedBy *uint) (*study.Asset, bool, error) {
+			return nil, false, study.ErrUnsupportedAssetType
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+	w := httptest.NewRecorder()
+
+	h.UploadAssetHandler(w, multipartFile(t, "evil.svg", []byte("<svg/>")))
+
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("expected status 415, got %d", w.Code)
+	}
+}
+
+// TestServeAsset_ETag tests immutable caching and 304 revalidation
+func TestServeAsset_ETag(t *testing.T) {
+	mockStudy := &MockStudyService{
+		OpenAssetFunc: func(ctx context.Context, key string) (*study.Asset, io.ReadCloser, error) {
+			return &study.Asset{Hash: "abc", Key: key, ContentType: "image/png", Size: 6}, io.NopCloser(bytes.NewReader([]byte("pixels"))), nil
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	serve := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/assets/abc.png", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("key", "abc.png")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		w := httptest.NewRecorder()
+		h.ServeAssetHandler(w, req)
+		return w
+	}
+
+	w := serve("")
+	if w.Code != http.StatusOK || w.Body.String() != "pixels" {
+		t.Fatalf("expected asset content, got %d %q", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Content-Type") != "image/png" || w.Header().Get("ETag") != `"abc"` {
+		t.Errorf("unexpected headers %v", w.Header())
+	}
+
+	if w := serve(`"abc"`); w.Code != http.StatusNotModified {
+		t.Errorf("expected status 304, got %d", w.Code)
+	}
+}
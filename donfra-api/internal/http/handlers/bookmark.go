@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+
+	"donfra-api/internal/pkg/httputil"
+	"donfra-api/internal/pkg/tracing"
+)
+
+// ListBookmarksHandler handles GET /api/me/bookmarks. Requires RequireAuth middleware.
+func (h *Handlers) ListBookmarksHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.ListBookmarks")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		httputil.WriteError(w, http.StatusUnauthorized, "user authentication required")
+		return
+	}
+
+	bookmarks, err := h.studySvc.ListBookmarks(ctx, userID, isAdminUser(ctx))
+	if err != nil {
+		tracing.RecordError(span, err)
+		httputil.WriteError(w, http.StatusInternalServerError, "failed to load bookmarks")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, bookmarks)
+}
+
+// AddBookmarkHandler handles PUT /api/me/bookmarks/{slug}. Requires RequireAuth middleware.
+func (h *Handlers) AddBookmarkHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.AddBookmark")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		httputil.WriteError(w, http.StatusUnauthorized, "user authentication required")
+		return
+	}
+
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		httputil.WriteError(w, http.StatusBadRequest, "slug is required")
+		return
+	}
+
+	if err := h.studySvc.AddBookmark(ctx, userID, slug, isAdminUser(ctx)); err != nil {
+		tracing.RecordError(span, err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			httputil.WriteError(w, http.StatusNotFound, "lesson not found")
+			return
+		}
+		httputil.WriteError(w, http.StatusInternalServerError, "failed to save bookmark")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveBookmarkHandler handles DELETE /api/me/bookmarks/{slug}. Requires RequireAuth middleware.
+func (h *Handlers) RemoveBookmarkHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.RemoveBookmark")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		httputil.WriteError(w, http.StatusUnauthorized, "user authentication required")
+		return
+	}
+
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		httputil.WriteError(w, http.StatusBadRequest, "slug is required")
+		return
+	}
+
+	if err := h.studySvc.RemoveBookmark(ctx, userID, slug); err != nil {
+		tracing.RecordError(span, err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			httputil.WriteError(w, http.StatusNotFound, "bookmark not found")
+			return
+		}
+		httputil.WriteError(w, http.StatusInternalServerError, "failed to remove bookmark")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
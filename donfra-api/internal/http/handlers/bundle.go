@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/pkg/httputil"
+	"donfra-api/internal/pkg/tracing"
+)
+
+// ExportLessonsHandler handles GET /api/lessons/export?format=zip|tar and downloads every
+// lesson as Markdown files with YAML front matter plus .excalidraw drawings.
+// Requires AdminOnly middleware.
+func (h *Handlers) ExportLessonsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.ExportLessons")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	format, err := study.ParseBundleFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	bundle, err := h.studySvc.ExportLessons(ctx, format)
+	if err != nil {
+		tracing.RecordError(span, err)
+		httputil.WriteError(w, http.StatusInternalServerError, "failed to export lessons")
+		return
+	}
+
+	filename := fmt.Sprintf("lessons-%s%s", time.Now().UTC().Format("20060102"), format.FileExtension())
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(bundle)
+}
+
+// ImportLessonsHandler handles POST /api/lessons/import. The bundle (zip or tar.gz) is sent
+// as the request body or as the "file" field of a multipart form. ?dryRun=true only reports
+// creates/updates/conflicts; ?force=true overwrites conflicting lessons.
+// Requires AdminOnly middleware.
+func (h *Handlers) ImportLessonsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.ImportLessons")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	var opts study.ImportOptions
+	for name, dst := range map[string]*bool{"dryRun": &opts.DryRun, "force": &opts.Force} {
+		raw := r.URL.Query().Get(name)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			httputil.WriteError(w, http.StatusBadRequest, name+" must be true or false")
+			return
+		}
+		*dst = v
+	}
+
+	data, err := readBundleUpload(w, r)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			httputil.WriteError(w, http.StatusRequestEntityTooLarge, "bundle is too large")
+			return
+		}
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.studySvc.ImportLessons(ctx, data, opts, revisionAuthor(ctx))
+	if err != nil {
+		tracing.RecordError(span, err)
+		if errors.Is(err, study.ErrInvalidBundle) {
+			httputil.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		httputil.WriteError(w, http.StatusInternalServerError, "failed to import lessons")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, report)
+}
+
+// readBundleUpload reads the bundle from a multipart "file" field or the raw request body
+func readBundleUpload(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, study.MaxBundleSize)
+
+	body := io.Reader(r.Body)
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(study.MaxBundleSize); err != nil {
+			return nil, err
+		}
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			return nil, errors.New(`multipart field "file" is required`)
+		}
+		defer file.Close()
+		body = file
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("bundle is required")
+	}
+	return data, nil
+}
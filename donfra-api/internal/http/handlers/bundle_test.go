@@ -0,0 +1,94 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/http/handlers"
+)
+
+// TestExportLessons_TarFormat tests the download headers for a tar bundle
+func TestExportLessons_TarFormat(t *testing.T) {
+	var gotFormat study.BundleFormat
+	mockStudy := &MockStudyService{
+		ExportLessonsFunc: func(ctx context.Context, format study.BundleFormat) ([]byte, error) {
+			gotFormat = format
+			return []byte{0x1f, 0x8b}, nil
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/lessons/export?format=tar", nil)
+	w := httptest.NewRecorder()
+	h.ExportLessonsHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if gotFormat != study.BundleTarGz {
+		t.Errorf("expected tar format, got %q", gotFormat)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/gzip" {
+		t.Errorf("unexpected Content-Type %q", ct)
+	}
+	if cd := w.Header().Get("Content-Disposition"); !bytes.Contains([]byte(cd), []byte(".tar.gz")) {
+		t.Errorf("unexpected Content-Disposition %q", cd)
+	}
+}
+
+// TestImportLessons_DryRun tests that dry-run options reach the service and the report is returned
+func TestImportLessons_DryRun(t *testing.T) {
+	var gotOpts study.ImportOptions
+	mockStudy := &MockStudyService{
+		ImportLessonsFunc: func(ctx context.Context, data []byte, opts study.ImportOptions, author study.RevisionAuthor) (*study.ImportReport, error) {
+			gotOpts = opts
+			return &study.ImportReport{DryRun: opts.DryRun, Conflicts: 1, Items: []study.ImportItem{{Slug: "intro", Action: study.ImportConflict}}}, nil
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/lessons/import?dryRun=true", bytes.NewReader([]byte("PK\x03\x04")))
+	w := httptest.NewRecorder()
+	h.ImportLessonsHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if !gotOpts.DryRun || gotOpts.Force {
+		t.Errorf("unexpected options %+v", gotOpts)
+	}
+	var report study.ImportReport
+	if err := json.NewDecoder(w.Body).Decode(&report); err != nil {
+		t.Fatalf("failed to decode report: %v", err)
+	}
+	if report.Conflicts != 1 || report.Items[0].Action != study.ImportConflict {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
+
+// TestImportLessons_InvalidBundle tests that bundle errors are reported as 400
+func TestImportLessons_InvalidBundle(t *testing.T) {
+	mockStudy := &MockStudyService{
+		ImportLessonsFunc: func(ctx context.Context, data []byte, opts study.ImportOptions, author study.RevisionAuthor) (*study.ImportReport, error) {
+			_, err := study.ReadBundle(data)
+			return nil, err
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/lessons/import", bytes.NewReader([]byte("not an archive")))
+	w := httptest.NewRecorder()
+	h.ImportLessonsHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+}
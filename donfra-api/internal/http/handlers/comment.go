@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/pkg/httputil"
+	"donfra-api/internal/pkg/tracing"
+)
+
+// isCommentModerator reports whether the caller may hide comments and lock threads (admin or mentor)
+func isCommentModerator(ctx context.Context) bool {
+	if isAdminUser(ctx) {
+		return true
+	}
+	role, ok := ctx.Value("user_role").(string)
+	return ok && role == "mentor"
+}
+
+// ListCommentsHandler handles GET /api/lessons/{slug}/comments and returns the comment threads.
+// Moderators also see the body of hidden comments.
+func (h *Handlers) ListCommentsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.ListComments")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	slug := chi.URLParam(r, "slug")
+	threads, err := h.studySvc.ListComments(ctx, slug, lessonViewer(ctx), isCommentModerator(ctx))
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeCommentError(w, err, "failed to load comments")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, threads)
+}
+
+// CreateCommentHandler handles POST /api/lessons/{slug}/comments. Set parentId to reply.
+// Requires RequireAuth middleware.
+func (h *Handlers) CreateCommentHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.CreateComment")
+	defer span.End()
+
+	userID, ok := h.noteUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req study.CreateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	comment, err := h.studySvc.CreateComment(ctx, chi.URLParam(r, "slug"), userID, &req, lessonViewer(ctx))
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeCommentError(w, err, "failed to create comment")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusCreated, comment)
+}
+
+// UpdateCommentHandler handles PATCH /api/lessons/{slug}/comments/{id} (author only).
+// Requires RequireAuth middleware.
+func (h *Handlers) UpdateCommentHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.UpdateComment")
+	defer span.End()
+
+	userID, ok := h.noteUser(w, r)
+	if !ok {
+		return
+	}
+	commentID, ok := parseCommentID(w, r)
+	if !ok {
+		return
+	}
+
+	var req study.UpdateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	comment, err := h.studySvc.UpdateComment(ctx, chi.URLParam(r, "slug"), commentID, userID, &req)
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeCommentError(w, err, "failed to update comment")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, comment)
+}
+
+// DeleteCommentHandler handles DELETE /api/lessons/{slug}/comments/{id} (author or moderator).
+// Requires RequireAuth middleware.
+func (h *Handlers) DeleteCommentHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.DeleteComment")
+	defer span.End()
+
+	userID, ok := h.noteUser(w, r)
+	if !ok {
+		return
+	}
+	commentID, ok := parseCommentID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.studySvc.DeleteComment(ctx, chi.URLParam(r, "slug"), commentID, userID, isCommentModerator(ctx)); err != nil {
+		tracing.RecordError(span, err)
+		writeCommentError(w, err, "failed to delete comment")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ModerateCommentHandler handles POST /api/lessons/{slug}/comments/{id}/moderate
+// with {"action": "hide"|"unhide"|"lock"|"unlock"}. Admins and mentors only.
+// Requires RequireAuth middleware.
+func (h *Handlers) ModerateCommentHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.ModerateComment")
+	defer span.End()
+
+	if _, ok := h.noteUser(w, r); !ok {
+		return
+	}
+	if !isCommentModerator(ctx) {
+		httputil.WriteError(w, http.StatusForbidden, "moderator access required")
+		return
+	}
+	commentID, ok := parseCommentID(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Action study.ModerationAction `json:"action"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	comment, err := h.studySvc.ModerateComment(ctx, chi.URLParam(r, "slug"), commentID, req.Action)
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeCommentError(w, err, "failed to moderate comment")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, comment)
+}
+
+// parseCommentID reads the {id} URL parameter, writing a 400 on failure
+func parseCommentID(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id == 0 {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid comment id")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// writeCommentError maps study comment errors to HTTP responses
+func writeCommentError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, study.ErrCommentNotFound):
+		httputil.WriteError(w, http.StatusNotFound, "comment not found")
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		httputil.WriteError(w, http.StatusNotFound, "lesson not found")
+	case errors.Is(err, study.ErrLessonLocked):
+		writeLessonLocked(w, err)
+	case errors.Is(err, study.ErrCommentForbidden):
+		httputil.WriteError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, study.ErrThreadLocked),
+		errors.Is(err, study.ErrCommentRemoved):
+		httputil.WriteError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, study.ErrCommentRateLimited):
+		w.Header().Set("Retry-After", strconv.Itoa(int(study.CommentRateWindow.Seconds())))
+		httputil.WriteError(w, http.StatusTooManyRequests, err.Error())
+	case errors.Is(err, study.ErrCommentEmpty),
+		errors.Is(err, study.ErrCommentTooLong),
+		errors.Is(err, study.ErrInvalidModerationAction):
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		httputil.WriteError(w, http.StatusInternalServerError, fallback)
+	}
+}
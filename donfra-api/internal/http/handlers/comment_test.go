@@ -0,0 +1,95 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/http/handlers"
+)
+
+// commentRequest builds a request with the lesson slug, comment id and caller in context
+func commentRequest(method, body, id string, userID uint, role string) *http.Request {
+	req := httptest.NewRequest(method, "/api/lessons/intro/comments", strings.NewReader(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "intro")
+	if id != "" {
+		rctx.URLParams.Add("id", id)
+	}
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	ctx = context.WithValue(ctx, "user_id", userID)
+	ctx = context.WithValue(ctx, "user_role", role)
+	return req.WithContext(ctx)
+}
+
+// TestCreateComment_RateLimited tests that rate-limited posts return 429 with Retry-After
+func TestCreateComment_RateLimited(t *testing.T) {
+	mockStudy := &MockStudyService{
+		CreateCommentFunc: func(ctx context.Context, slug string, userID uint, req *study.CreateCommentRequest, viewer study.LessonViewer) (*study.Comment, error) {
+			return nil, study.ErrCommentRateLimited
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+	w := httptest.NewRecorder()
+
+	h.CreateCommentHandler(w, commentRequest(http.MethodPost, `{"body":"hi"}`, "", 3, "user"))
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("expected Retry-After header")
+	}
+}
+
+// TestModerateComment_RequiresModerator tests that regular users cannot moderate
+func TestModerateComment_RequiresModerator(t *testing.T) {
+	called := false
+	mockStudy := &MockStudyService{
+		ModerateCommentFunc: func(ctx context.Context, slug string, commentID uint, action study.ModerationAction) (*study.Comment, error) {
+			called = true
+			return &study.Comment{ID: commentID, IsHidden: true}, nil
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	h.ModerateCommentHandler(w, commentRequest(http.MethodPost, `{"action":"hide"}`, "5", 3, "user"))
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 for regular user, got %d", w.Code)
+	}
+	if called {
+		t.Error("service should not be called for regular users")
+	}
+
+	w = httptest.NewRecorder()
+	h.ModerateCommentHandler(w, commentRequest(http.MethodPost, `{"action":"hide"}`, "5", 4, "mentor"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for mentor, got %d", w.Code)
+	}
+}
+
+// TestUpdateComment_LockedThread tests that edits in a locked thread are rejected
+func TestUpdateComment_LockedThread(t *testing.T) {
+	mockStudy := &MockStudyService{
+		UpdateCommentFunc: func(ctx context.Context, slug string, commentID, userID uint, req *study.UpdateCommentRequest) (*study.Comment, error) {
+			return nil, study.ErrThreadLocked
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+	w := httptest.NewRecorder()
+
+	h.UpdateCommentHandler(w, commentRequest(http.MethodPatch, `{"body":"edit"}`, "5", 3, "user"))
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d", w.Code)
+	}
+}
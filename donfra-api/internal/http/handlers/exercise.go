@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+
+	"donfra-api/internal/domain/run"
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/pkg/httputil"
+	"donfra-api/internal/pkg/tracing"
+)
+
+// ListExercisesHandler handles GET /api/lessons/{slug}/exercises and returns the
+// exercises of a lesson including hidden tests. Requires RequireLessonOwner middleware.
+func (h *Handlers) ListExercisesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.ListExercises")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	exercises, err := h.studySvc.ListExercises(ctx, chi.URLParam(r, "slug"))
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeExerciseError(w, err, "failed to load exercises")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, exercises)
+}
+
+// SetExercisesHandler handles PUT /api/lessons/{slug}/exercises and replaces the
+// exercises of a lesson. Requires RequireLessonAuthor middleware; mentors may only
+// change lessons that pass study.CheckLessonEditable.
+func (h *Handlers) SetExercisesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.SetExercises")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	var req study.SetExercisesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	exercises, err := h.studySvc.SetExercises(ctx, chi.URLParam(r, "slug"), &req, lessonEditor(ctx))
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeExerciseError(w, err, "failed to save exercises")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, exercises)
+}
+
+// SubmitExerciseHandler handles POST /api/lessons/{slug}/exercises/{key}/submit with
+// {"code": "..."}. The code is judged against every test and the caller's best result is kept.
+// Requires RequireAuth middleware.
+func (h *Handlers) SubmitExerciseHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.SubmitExercise")
+	defer span.End()
+
+	userID, ok := h.noteUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req study.SubmitExerciseRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, study.MaxSubmissionLength+1024)).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	submission, err := h.studySvc.SubmitExercise(ctx, chi.URLParam(r, "slug"), chi.URLParam(r, "key"), userID, req.Code, lessonViewer(ctx))
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeExerciseError(w, err, "failed to judge submission")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, submission)
+}
+
+// writeExerciseError maps study exercise errors to HTTP responses
+func writeExerciseError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, study.ErrExerciseNotFound):
+		httputil.WriteError(w, http.StatusNotFound, "exercise not found")
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		httputil.WriteError(w, http.StatusNotFound, "lesson not found")
+	case errors.Is(err, study.ErrLessonLocked):
+		writeLessonLocked(w, err)
+	case errors.Is(err, study.ErrInvalidExercise),
+		errors.Is(err, study.ErrEmptySubmission):
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, study.ErrSubmissionSize):
+		httputil.WriteError(w, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, run.ErrJudgeBusy):
+		w.Header().Set("Retry-After", "5")
+		httputil.WriteError(w, http.StatusServiceUnavailable, err.Error())
+	default:
+		writeReviewError(w, err, fallback)
+	}
+}
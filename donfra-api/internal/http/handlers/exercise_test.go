@@ -0,0 +1,138 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"donfra-api/internal/domain/run"
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/http/handlers"
+)
+
+// TestSubmitExerciseHandler tests that a submission is judged for the signed-in user
+func TestSubmitExerciseHandler(t *testing.T) {
+	mockStudy := &MockStudyService{
+		SubmitExerciseFunc: func(ctx context.Context, slug, key string, userID uint, code string, viewer study.LessonViewer) (*study.ExerciseSubmission, error) {
+			if slug != "loops" || key != "sum" || userID != 7 || code != "print(3)" {
+				t.Errorf("unexpected submission %q %q %d %q", slug, key, userID, code)
+			}
+			return &study.ExerciseSubmission{
+				Passed: true, PassedCount: 1, Total: 1,
+				Cases: []study.ExerciseCaseResult{{Passed: true}},
+				Best:  &study.ExerciseResult{Passed: true, BestPassed: 1, Total: 1, Attempts: 1},
+			}, nil
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := slugRequest(http.MethodPost, "/api/lessons/loops/exercises/sum/submit", "loops", `{"code":"print(3)"}`)
+	chi.RouteContext(req.Context()).URLParams.Add("key", "sum")
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", uint(7)))
+	w := httptest.NewRecorder()
+	h.SubmitExerciseHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp study.ExerciseSubmission
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.Passed || resp.Best == nil || resp.Best.Attempts != 1 {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+// TestSubmitExerciseHandler_Errors tests authentication and error mapping
+func TestSubmitExerciseHandler_Errors(t *testing.T) {
+	var submitErr error
+	mockStudy := &MockStudyService{
+		SubmitExerciseFunc: func(ctx context.Context, slug, key string, userID uint, code string, viewer study.LessonViewer) (*study.ExerciseSubmission, error) {
+			return nil, submitErr
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	h.SubmitExerciseHandler(w, slugRequest(http.MethodPost, "/api/lessons/loops/exercises/sum/submit", "loops", `{"code":"x"}`))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 without user, got %d", w.Code)
+	}
+
+	cases := []struct {
+		err  error
+		want int
+	}{
+		{study.ErrExerciseNotFound, http.StatusNotFound},
+		{study.ErrEmptySubmission, http.StatusBadRequest},
+		{study.ErrSubmissionSize, http.StatusRequestEntityTooLarge},
+		{run.ErrJudgeBusy, http.StatusServiceUnavailable},
+	}
+	for _, tc := range cases {
+		submitErr = tc.err
+		req := slugRequest(http.MethodPost, "/api/lessons/loops/exercises/sum/submit", "loops", `{"code":"x"}`)
+		req = req.WithContext(context.WithValue(req.Context(), "user_id", uint(7)))
+		w := httptest.NewRecorder()
+		h.SubmitExerciseHandler(w, req)
+		if w.Code != tc.want {
+			t.Errorf("%v: expected status %d, got %d", tc.err, tc.want, w.Code)
+		}
+	}
+}
+
+// TestSetExercisesHandler_Invalid tests that validation errors are reported as 400
+func TestSetExercisesHandler_Invalid(t *testing.T) {
+	mockStudy := &MockStudyService{
+		SetExercisesFunc: func(ctx context.Context, slug string, req *study.SetExercisesRequest, editor study.LessonEditor) ([]study.Exercise, error) {
+			_, err := study.ValidateExercises(req.Exercises)
+			return nil, err
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	h.SetExercisesHandler(w, slugRequest(http.MethodPut, "/api/lessons/loops/exercises", "loops", `{"exercises":[{"key":"Bad Key","title":"x"}]}`))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+}
+
+// TestGetLessonBySlug_IncludesExercises tests that the lesson response carries exercises and results
+func TestGetLessonBySlug_IncludesExercises(t *testing.T) {
+	mockStudy := &MockStudyService{
+		GetLessonBySlugFunc: func(ctx context.Context, slug string) (*study.Lesson, error) {
+			return &study.Lesson{ID: 3, Slug: slug, IsPublished: true}, nil
+		},
+		GetExerciseViewsFunc: func(ctx context.Context, lessonID uint, userID *uint) ([]study.ExerciseView, error) {
+			if userID == nil || *userID != 7 {
+				t.Errorf("expected viewer 7, got %v", userID)
+			}
+			return []study.ExerciseView{{Key: "sum", TestCount: 2, Result: &study.ExerciseResult{Passed: true}}}, nil
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := slugRequest(http.MethodGet, "/api/lessons/loops", "loops", "")
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", uint(7)))
+	w := httptest.NewRecorder()
+	h.GetLessonBySlugHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	var resp struct {
+		Exercises []study.ExerciseView `json:"exercises"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Exercises) != 1 || resp.Exercises[0].Result == nil || !resp.Exercises[0].Result.Passed {
+		t.Errorf("unexpected exercises %+v", resp.Exercises)
+	}
+}
@@ -2,9 +2,12 @@ package handlers
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"donfra-api/internal/domain/auth"
 	"donfra-api/internal/domain/interview"
+	"donfra-api/internal/domain/problem"
 	"donfra-api/internal/domain/study"
 	"donfra-api/internal/domain/user"
 )
@@ -23,12 +26,65 @@ type RoomService interface {
 
 // StudyService defines the interface for lesson operations.
 type StudyService interface {
-	ListPublishedLessons(ctx context.Context) ([]study.Lesson, error)
-	ListAllLessons(ctx context.Context) ([]study.Lesson, error)
+	ListLessonSummaries(ctx context.Context, query study.ListLessonsQuery) (*study.LessonPage, error)
 	GetLessonBySlug(ctx context.Context, slug string) (*study.Lesson, error)
-	CreateLesson(ctx context.Context, newLesson *study.Lesson) (*study.Lesson, error)
-	UpdateLessonBySlug(ctx context.Context, slug string, updates map[string]any) error
+	LessonModifiedAt(ctx context.Context, lesson *study.Lesson, userID *uint) (time.Time, error)
+	CreateLesson(ctx context.Context, newLesson *study.Lesson, author study.RevisionAuthor) (*study.Lesson, error)
+	UpdateLessonBySlug(ctx context.Context, slug string, updates map[string]any, editor study.LessonEditor, author study.RevisionAuthor) error
+	ResolveSlugRedirect(ctx context.Context, oldSlug string) (*study.Lesson, error)
+	RenderLesson(ctx context.Context, lesson *study.Lesson) (*study.RenderedLesson, error)
+	GetLessonPrerequisites(ctx context.Context, lessonID uint, userID *uint, includeUnpublished bool) ([]study.PrerequisiteStatus, error)
+	SetLessonPrerequisites(ctx context.Context, slug string, lessonSlugs []string, editor study.LessonEditor) ([]study.PrerequisiteStatus, error)
+	CreateLessonPreview(ctx context.Context, slug string, req *study.CreatePreviewRequest, author study.RevisionAuthor) (*study.PreviewLink, error)
+	ListLessonPreviews(ctx context.Context, slug string) ([]study.LessonPreview, error)
+	RevokeLessonPreview(ctx context.Context, slug string, previewID uint) error
+	CheckLessonPreview(ctx context.Context, lesson *study.Lesson, token string) error
+	SubmitLessonForReview(ctx context.Context, slug string) (*study.Lesson, error)
+	ReviewLesson(ctx context.Context, slug string, req *study.ReviewLessonRequest, reviewer study.RevisionAuthor) (*study.Lesson, error)
 	DeleteLessonBySlug(ctx context.Context, slug string) error
+	ListLessonRevisions(ctx context.Context, slug string) ([]study.LessonRevisionSummary, error)
+	GetLessonRevision(ctx context.Context, slug string, revisionID uint) (*study.LessonRevision, error)
+	DiffLessonRevisions(ctx context.Context, slug string, fromID, toID uint) (*study.RevisionDiff, error)
+	RestoreLessonRevision(ctx context.Context, slug string, revisionID uint, author study.RevisionAuthor) (*study.Lesson, error)
+	SearchLessons(ctx context.Context, query string, viewer study.LessonViewer, includeUnpublished bool, limit int) ([]study.LessonSearchResult, error)
+	ListTracks(ctx context.Context, includeUnpublished bool) ([]study.Track, error)
+	GetTrack(ctx context.Context, slug string, includeUnpublished bool) (*study.TrackDetail, error)
+	CreateTrack(ctx context.Context, req *study.CreateTrackRequest) (*study.TrackDetail, error)
+	UpdateTrack(ctx context.Context, slug string, req *study.UpdateTrackRequest) (*study.Track, error)
+	SetTrackLessons(ctx context.Context, slug string, lessonSlugs []string) (*study.TrackDetail, error)
+	DeleteTrack(ctx context.Context, slug string) error
+	GetLessonProgress(ctx context.Context, userID, lessonID uint) (*study.LessonProgress, error)
+	UpdateLessonProgress(ctx context.Context, userID uint, slug string, req *study.UpdateProgressRequest, viewer study.LessonViewer) (*study.LessonProgress, error)
+	GetProgressSummary(ctx context.Context, userID uint) (*study.ProgressSummary, error)
+	ListBookmarks(ctx context.Context, userID uint, includeUnpublished bool) ([]study.BookmarkEntry, error)
+	AddBookmark(ctx context.Context, userID uint, slug string, includeUnpublished bool) error
+	RemoveBookmark(ctx context.Context, userID uint, slug string) error
+	ListNotes(ctx context.Context, userID uint, lessonSlug string) ([]study.LessonNote, error)
+	GetNote(ctx context.Context, userID, noteID uint) (*study.LessonNote, error)
+	CreateNote(ctx context.Context, userID uint, req *study.CreateNoteRequest, includeUnpublished bool) (*study.LessonNote, error)
+	UpdateNote(ctx context.Context, userID, noteID uint, req *study.UpdateNoteRequest) (*study.LessonNote, error)
+	DeleteNote(ctx context.Context, userID, noteID uint) error
+	ListComments(ctx context.Context, slug string, viewer study.LessonViewer, moderator bool) ([]*study.CommentNode, error)
+	CreateComment(ctx context.Context, slug string, userID uint, req *study.CreateCommentRequest, viewer study.LessonViewer) (*study.Comment, error)
+	UpdateComment(ctx context.Context, slug string, commentID, userID uint, req *study.UpdateCommentRequest) (*study.Comment, error)
+	DeleteComment(ctx context.Context, slug string, commentID, userID uint, moderator bool) error
+	ModerateComment(ctx context.Context, slug string, commentID uint, action study.ModerationAction) (*study.Comment, error)
+	ListExercises(ctx context.Context, slug string) ([]study.Exercise, error)
+	SetExercises(ctx context.Context, slug string, req *study.SetExercisesRequest, editor study.LessonEditor) ([]study.Exercise, error)
+	GetExerciseViews(ctx context.Context, lessonID uint, userID *uint) ([]study.ExerciseView, error)
+	SubmitExercise(ctx context.Context, slug, key string, userID uint, code string, viewer study.LessonViewer) (*study.ExerciseSubmission, error)
+	GetQuiz(ctx context.Context, slug string) (*study.Quiz, error)
+	SetQuiz(ctx context.Context, slug string, req *study.SetQuizRequest, editor study.LessonEditor) (*study.Quiz, error)
+	DeleteQuiz(ctx context.Context, slug string, editor study.LessonEditor) error
+	GetQuizView(ctx context.Context, lessonID uint, userID *uint) (*study.QuizView, error)
+	SubmitQuiz(ctx context.Context, slug string, userID uint, req *study.SubmitQuizRequest, viewer study.LessonViewer) (*study.QuizAttemptResult, error)
+	ListQuizAttempts(ctx context.Context, slug string, userID uint, viewer study.LessonViewer) ([]study.QuizAttempt, error)
+	ExportLessons(ctx context.Context, format study.BundleFormat) ([]byte, error)
+	ImportLessons(ctx context.Context, data []byte, opts study.ImportOptions, author study.RevisionAuthor) (*study.ImportReport, error)
+	UploadAsset(ctx context.Context, filename string, content []byte, uploadedBy *uint) (*study.Asset, bool, error)
+	ListAssets(ctx context.Context) ([]study.Asset, error)
+	OpenAsset(ctx context.Context, key string) (*study.Asset, io.ReadCloser, error)
+	DeleteAsset(ctx context.Context, key string, force bool) error
 }
 
 // AuthService defines the interface for authentication operations.
@@ -41,20 +97,44 @@ type AuthService interface {
 type UserService interface {
 	Register(ctx context.Context, req *user.RegisterRequest) (*user.User, error)
 	Login(ctx context.Context, req *user.LoginRequest) (*user.User, string, error)
-	ValidateToken(tokenString string) (*user.Claims, error)
+	ValidateToken(ctx context.Context, tokenString string) (*user.Claims, error)
 	GetUserByID(ctx context.Context, id uint) (*user.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*user.User, error)
 	GetJWTSecret() string
 	GetJWTExpiry() int
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
 }
 
 // InterviewService defines the interface for interview room operations.
 type InterviewService interface {
-	InitRoom(ctx context.Context, userID uint, isAdmin bool) (*interview.InitRoomResponse, error)
-	JoinRoom(ctx context.Context, inviteToken string) (*interview.JoinRoomResponse, error)
-	CloseRoom(ctx context.Context, roomID string, userID uint) error
+	InitRoom(ctx context.Context, userID uint, isAdmin bool, req *interview.InitRoomRequest) (*interview.InitRoomResponse, error)
+	JoinRoom(ctx context.Context, req *interview.JoinRoomRequest) (*interview.JoinRoomResponse, error)
+	CloseRoom(ctx context.Context, roomID string, userID uint, finalCode *string) error
 	GetRoomByID(ctx context.Context, roomID string) (*interview.InterviewRoom, error)
+	ValidateRoomAccess(ctx context.Context, roomID, token string) error
 	UpdateHeadcount(ctx context.Context, roomID string, headcount int) error
+	GetRoomProblem(ctx context.Context, roomID string) (*problem.ProblemPublic, error)
+	ScheduleRoom(ctx context.Context, userID uint, isAdmin bool, req *interview.ScheduleRoomRequest) (*interview.ScheduleRoomResponse, error)
+	ListScheduledRooms(ctx context.Context, userID uint) ([]interview.InterviewRoom, error)
+	GetRoomCalendar(ctx context.Context, roomID string, userID uint) ([]byte, error)
+	RunCode(ctx context.Context, roomID string, code string) (*interview.InterviewRun, error)
+	SubmitScorecard(ctx context.Context, roomID string, userID uint, req *interview.SubmitScorecardRequest) (*interview.Scorecard, error)
+	GetReport(ctx context.Context, roomID string, userID uint) (*interview.Report, error)
+	StartInterview(ctx context.Context, roomID string, userID uint) (*interview.RoomStateResponse, error)
+	EndInterview(ctx context.Context, roomID string, userID uint) (*interview.RoomStateResponse, error)
+	GetAdmissionStatus(ctx context.Context, ticket string) (*interview.JoinRoomResponse, error)
+	ListLobby(ctx context.Context, roomID string, userID uint) ([]interview.Admission, error)
+	DecideAdmission(ctx context.Context, roomID string, admissionID uint, userID uint, admit bool) (*interview.Admission, error)
+}
+
+// ProblemService defines the interface for question bank operations.
+type ProblemService interface {
+	CreateProblem(ctx context.Context, req *problem.CreateProblemRequest) (*problem.Problem, error)
+	GetProblem(ctx context.Context, id uint) (*problem.Problem, error)
+	ListProblems(ctx context.Context, filter problem.ListProblemsFilter) ([]problem.Problem, error)
+	UpdateProblem(ctx context.Context, id uint, req *problem.UpdateProblemRequest) (*problem.Problem, error)
+	DeleteProblem(ctx context.Context, id uint) error
 }
 
 // Handlers holds all service dependencies for HTTP handlers.
@@ -64,15 +144,17 @@ type Handlers struct {
 	authSvc      AuthService
 	userSvc      UserService
 	interviewSvc InterviewService
+	problemSvc   ProblemService
 }
 
 // New creates a new Handlers instance with the given services.
-func New(roomSvc RoomService, studySvc StudyService, authSvc AuthService, userSvc UserService, interviewSvc InterviewService) *Handlers {
+func New(roomSvc RoomService, studySvc StudyService, authSvc AuthService, userSvc UserService, interviewSvc InterviewService, problemSvc ProblemService) *Handlers {
 	return &Handlers{
 		roomSvc:      roomSvc,
 		studySvc:     studySvc,
 		authSvc:      authSvc,
 		userSvc:      userSvc,
 		interviewSvc: interviewSvc,
+		problemSvc:   problemSvc,
 	}
 }
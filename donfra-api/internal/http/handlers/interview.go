@@ -1,11 +1,18 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
 
 	"donfra-api/internal/domain/interview"
+	"donfra-api/internal/domain/run"
 	"donfra-api/internal/pkg/httputil"
 	"donfra-api/internal/pkg/tracing"
 )
@@ -33,8 +40,15 @@ func (h *Handlers) InitInterviewRoomHandler(w http.ResponseWriter, r *http.Reque
 	userRole, _ := ctx.Value("user_role").(string)
 	isAdmin := userRole == "admin"
 
+	// Parse optional request body (an empty body creates a room without a problem)
+	var req interview.InitRoomRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
 	// Create room (only admin users can create)
-	resp, err := h.interviewSvc.InitRoom(ctx, userID, isAdmin)
+	resp, err := h.interviewSvc.InitRoom(ctx, userID, isAdmin, &req)
 	if err != nil {
 		tracing.RecordError(span, err)
 		switch {
@@ -42,6 +56,8 @@ func (h *Handlers) InitInterviewRoomHandler(w http.ResponseWriter, r *http.Reque
 			httputil.WriteError(w, http.StatusForbidden, "only admin users can create interview rooms")
 		case errors.Is(err, interview.ErrRoomAlreadyExists):
 			httputil.WriteError(w, http.StatusConflict, "user already has an active room")
+		case errors.Is(err, interview.ErrProblemNotFound):
+			httputil.WriteError(w, http.StatusBadRequest, "problem not found")
 		default:
 			httputil.WriteError(w, http.StatusInternalServerError, "failed to create room")
 		}
@@ -52,7 +68,9 @@ func (h *Handlers) InitInterviewRoomHandler(w http.ResponseWriter, r *http.Reque
 }
 
 // JoinInterviewRoomHandler handles POST /api/interview/join
-// Allows users to join a room via invite token
+// Allows users to join a room via invite token.
+// For rooms with a lobby the joiner is put on the pending list (202) and receives
+// a lobby_ticket cookie; room_access is only issued once the owner admits them.
 func (h *Handlers) JoinInterviewRoomHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, span := tracing.StartSpan(r.Context(), "handler.JoinInterviewRoom")
 	defer span.End()
@@ -75,32 +93,206 @@ func (h *Handlers) JoinInterviewRoomHandler(w http.ResponseWriter, r *http.Reque
 	}
 
 	// Join room
-	resp, err := h.interviewSvc.JoinRoom(ctx, req.InviteToken)
+	resp, err := h.interviewSvc.JoinRoom(ctx, &req)
 	if err != nil {
 		tracing.RecordError(span, err)
 		switch {
 		case errors.Is(err, interview.ErrInvalidToken):
 			httputil.WriteError(w, http.StatusUnauthorized, "invalid or expired invite token")
+		case errors.Is(err, interview.ErrInviteNotYetValid):
+			httputil.WriteError(w, http.StatusForbidden, "interview has not started yet")
+		case errors.Is(err, interview.ErrRoomNotActive):
+			httputil.WriteError(w, http.StatusConflict, "interview has already ended")
 		case errors.Is(err, interview.ErrRoomNotFound):
 			httputil.WriteError(w, http.StatusNotFound, "room not found or has been closed")
+		case errors.Is(err, interview.ErrDisplayNameRequired):
+			httputil.WriteError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, interview.ErrLobbyFull):
+			httputil.WriteError(w, http.StatusTooManyRequests, err.Error())
 		default:
 			httputil.WriteError(w, http.StatusInternalServerError, "failed to join room")
 		}
 		return
 	}
 
-	// Set room_access cookie for subsequent requests
+	if resp.Status == interview.AdmissionPending {
+		// Lobby ticket lets the joiner poll GET /api/interview/join/status
+		http.SetCookie(w, &http.Cookie{
+			Name:     "lobby_ticket",
+			Value:    resp.Ticket,
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   false, // Set to true in production with HTTPS
+			SameSite: http.SameSiteLaxMode,
+			MaxAge:   86400, // 24 hours
+		})
+		httputil.WriteJSON(w, http.StatusAccepted, resp)
+		return
+	}
+
+	setRoomAccessCookie(w, resp.AccessToken)
+	httputil.WriteJSON(w, http.StatusOK, resp)
+}
+
+// GetJoinStatusHandler handles GET /api/interview/join/status
+// Lets a joiner waiting in the lobby poll for the owner's decision using the
+// lobby_ticket cookie. Issues the room_access cookie once admitted.
+func (h *Handlers) GetJoinStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.GetJoinStatus")
+	defer span.End()
+
+	if h.interviewSvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "interview service unavailable")
+		return
+	}
+
+	cookie, err := r.Cookie("lobby_ticket")
+	if err != nil || cookie.Value == "" {
+		httputil.WriteError(w, http.StatusUnauthorized, "lobby ticket required")
+		return
+	}
+
+	resp, err := h.interviewSvc.GetAdmissionStatus(ctx, cookie.Value)
+	if err != nil {
+		tracing.RecordError(span, err)
+		switch {
+		case errors.Is(err, interview.ErrAdmissionNotFound):
+			httputil.WriteError(w, http.StatusUnauthorized, "invalid lobby ticket")
+		case errors.Is(err, interview.ErrAdmissionRejected):
+			clearCookie(w, "lobby_ticket")
+			httputil.WriteError(w, http.StatusForbidden, "the interviewer declined your request to join")
+		case errors.Is(err, interview.ErrRoomNotFound), errors.Is(err, interview.ErrRoomNotActive):
+			clearCookie(w, "lobby_ticket")
+			httputil.WriteError(w, http.StatusNotFound, "room not found or has been closed")
+		default:
+			httputil.WriteError(w, http.StatusInternalServerError, "failed to get lobby status")
+		}
+		return
+	}
+
+	if resp.Status == interview.AdmissionAdmitted {
+		clearCookie(w, "lobby_ticket")
+		setRoomAccessCookie(w, resp.AccessToken)
+	}
+	httputil.WriteJSON(w, http.StatusOK, resp)
+}
+
+// ListLobbyHandler handles GET /api/interview/{room_id}/lobby
+// Returns the joiners waiting for admission (owner only)
+func (h *Handlers) ListLobbyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.ListLobby")
+	defer span.End()
+
+	if h.interviewSvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "interview service unavailable")
+		return
+	}
+
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		httputil.WriteError(w, http.StatusUnauthorized, "user authentication required")
+		return
+	}
+
+	roomID := chi.URLParam(r, "room_id")
+	if roomID == "" {
+		httputil.WriteError(w, http.StatusBadRequest, "room_id is required")
+		return
+	}
+
+	admissions, err := h.interviewSvc.ListLobby(ctx, roomID, userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		switch {
+		case errors.Is(err, interview.ErrRoomNotFound):
+			httputil.WriteError(w, http.StatusNotFound, "room not found")
+		case errors.Is(err, interview.ErrUnauthorized):
+			httputil.WriteError(w, http.StatusForbidden, "only room owner can view the lobby")
+		default:
+			httputil.WriteError(w, http.StatusInternalServerError, "failed to list lobby")
+		}
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, admissions)
+}
+
+// AdmitLobbyEntryHandler handles POST /api/interview/{room_id}/lobby/{admission_id}/admit
+func (h *Handlers) AdmitLobbyEntryHandler(w http.ResponseWriter, r *http.Request) {
+	h.decideLobbyEntry(w, r, true)
+}
+
+// RejectLobbyEntryHandler handles POST /api/interview/{room_id}/lobby/{admission_id}/reject
+func (h *Handlers) RejectLobbyEntryHandler(w http.ResponseWriter, r *http.Request) {
+	h.decideLobbyEntry(w, r, false)
+}
+
+// decideLobbyEntry is the shared implementation of the admit/reject handlers (owner only)
+func (h *Handlers) decideLobbyEntry(w http.ResponseWriter, r *http.Request, admit bool) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.DecideLobbyEntry")
+	defer span.End()
+
+	if h.interviewSvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "interview service unavailable")
+		return
+	}
+
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		httputil.WriteError(w, http.StatusUnauthorized, "user authentication required")
+		return
+	}
+
+	roomID := chi.URLParam(r, "room_id")
+	admissionID, err := strconv.ParseUint(chi.URLParam(r, "admission_id"), 10, 64)
+	if roomID == "" || err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "room_id and a numeric admission_id are required")
+		return
+	}
+
+	admission, err := h.interviewSvc.DecideAdmission(ctx, roomID, uint(admissionID), userID, admit)
+	if err != nil {
+		tracing.RecordError(span, err)
+		switch {
+		case errors.Is(err, interview.ErrRoomNotFound):
+			httputil.WriteError(w, http.StatusNotFound, "room not found")
+		case errors.Is(err, interview.ErrAdmissionNotFound):
+			httputil.WriteError(w, http.StatusNotFound, "lobby entry not found")
+		case errors.Is(err, interview.ErrUnauthorized):
+			httputil.WriteError(w, http.StatusForbidden, "only room owner can admit or reject joiners")
+		case errors.Is(err, interview.ErrAdmissionDecided):
+			httputil.WriteError(w, http.StatusConflict, err.Error())
+		default:
+			httputil.WriteError(w, http.StatusInternalServerError, "failed to save lobby decision")
+		}
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, admission)
+}
+
+// setRoomAccessCookie issues the signed room_access cookie for subsequent requests
+func setRoomAccessCookie(w http.ResponseWriter, accessToken string) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     "room_access",
-		Value:    resp.RoomID,
+		Value:    accessToken,
 		Path:     "/",
 		HttpOnly: true,
 		Secure:   false, // Set to true in production with HTTPS
 		SameSite: http.SameSiteLaxMode,
 		MaxAge:   86400, // 24 hours
 	})
+}
 
-	httputil.WriteJSON(w, http.StatusOK, resp)
+// clearCookie deletes a cookie on the client
+func clearCookie(w http.ResponseWriter, name string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		MaxAge:   -1,
+	})
 }
 
 // CloseInterviewRoomHandler handles POST /api/interview/close
@@ -134,7 +326,7 @@ func (h *Handlers) CloseInterviewRoomHandler(w http.ResponseWriter, r *http.Requ
 	}
 
 	// Close room
-	err := h.interviewSvc.CloseRoom(ctx, req.RoomID, userID)
+	err := h.interviewSvc.CloseRoom(ctx, req.RoomID, userID, req.CodeSnapshot)
 	if err != nil {
 		tracing.RecordError(span, err)
 		switch {
@@ -142,6 +334,8 @@ func (h *Handlers) CloseInterviewRoomHandler(w http.ResponseWriter, r *http.Requ
 			httputil.WriteError(w, http.StatusNotFound, "room not found")
 		case errors.Is(err, interview.ErrUnauthorized):
 			httputil.WriteError(w, http.StatusForbidden, "only room owner can close the room")
+		case errors.Is(err, interview.ErrInvalidTransition):
+			httputil.WriteError(w, http.StatusConflict, err.Error())
 		default:
 			httputil.WriteError(w, http.StatusInternalServerError, "failed to close room")
 		}
@@ -149,16 +343,425 @@ func (h *Handlers) CloseInterviewRoomHandler(w http.ResponseWriter, r *http.Requ
 	}
 
 	// Clear room_access cookie
-	http.SetCookie(w, &http.Cookie{
-		Name:     "room_access",
-		Value:    "",
-		Path:     "/",
-		HttpOnly: true,
-		MaxAge:   -1,
-	})
+	clearCookie(w, "room_access")
 
 	httputil.WriteJSON(w, http.StatusOK, interview.CloseRoomResponse{
 		RoomID:  req.RoomID,
 		Message: "Room closed successfully",
 	})
 }
+
+// GetInterviewRoomProblemHandler handles GET /api/interview/{room_id}/problem
+// Returns the statement and starter code of the problem attached to the room.
+// Accessible to the room owner and to participants holding the room_access cookie.
+// Requires OptionalAuth middleware to set context.
+func (h *Handlers) GetInterviewRoomProblemHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.GetInterviewRoomProblem")
+	defer span.End()
+
+	if h.interviewSvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "interview service unavailable")
+		return
+	}
+
+	roomID := chi.URLParam(r, "room_id")
+	if roomID == "" {
+		httputil.WriteError(w, http.StatusBadRequest, "room_id is required")
+		return
+	}
+	span.SetAttributes(tracing.AttrRoomID.String(roomID))
+
+	room, err := h.interviewSvc.GetRoomByID(ctx, roomID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		if errors.Is(err, interview.ErrRoomNotFound) {
+			httputil.WriteError(w, http.StatusNotFound, "room not found or has been closed")
+			return
+		}
+		httputil.WriteError(w, http.StatusInternalServerError, "failed to get room")
+		return
+	}
+
+	if !h.hasRoomAccess(r, room) {
+		httputil.WriteError(w, http.StatusForbidden, "room access required")
+		return
+	}
+
+	p, err := h.interviewSvc.GetRoomProblem(ctx, roomID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		switch {
+		case errors.Is(err, interview.ErrNoProblemAttached), errors.Is(err, interview.ErrProblemNotFound):
+			httputil.WriteError(w, http.StatusNotFound, "no problem attached to this room")
+		default:
+			httputil.WriteError(w, http.StatusInternalServerError, "failed to load problem")
+		}
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, p)
+}
+
+// hasRoomAccess reports whether the request comes from the room owner
+// or from a participant whose signed room_access cookie was issued for the room.
+func (h *Handlers) hasRoomAccess(r *http.Request, room *interview.InterviewRoom) bool {
+	if userID, ok := r.Context().Value("user_id").(uint); ok && userID == room.OwnerID {
+		return true
+	}
+	cookie, err := r.Cookie("room_access")
+	return err == nil && h.interviewSvc.ValidateRoomAccess(r.Context(), room.RoomID, cookie.Value) == nil
+}
+
+// ScheduleInterviewRoomHandler handles POST /api/interview/schedule
+// Schedules an interview room for a future time slot (admin users only)
+func (h *Handlers) ScheduleInterviewRoomHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.ScheduleInterviewRoom")
+	defer span.End()
+
+	if h.interviewSvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "interview service unavailable")
+		return
+	}
+
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		httputil.WriteError(w, http.StatusUnauthorized, "user authentication required")
+		return
+	}
+	userRole, _ := ctx.Value("user_role").(string)
+	isAdmin := userRole == "admin"
+
+	var req interview.ScheduleRoomRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	resp, err := h.interviewSvc.ScheduleRoom(ctx, userID, isAdmin, &req)
+	if err != nil {
+		tracing.RecordError(span, err)
+		switch {
+		case errors.Is(err, interview.ErrAdminRequired):
+			httputil.WriteError(w, http.StatusForbidden, "only admin users can schedule interview rooms")
+		case errors.Is(err, interview.ErrInvalidSchedule):
+			httputil.WriteError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, interview.ErrProblemNotFound):
+			httputil.WriteError(w, http.StatusBadRequest, "problem not found")
+		case errors.Is(err, interview.ErrScheduleConflict):
+			httputil.WriteError(w, http.StatusConflict, err.Error())
+		default:
+			httputil.WriteError(w, http.StatusInternalServerError, "failed to schedule room")
+		}
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusCreated, resp)
+}
+
+// ListScheduledInterviewsHandler handles GET /api/interview/scheduled
+// Returns the authenticated user's upcoming scheduled interviews
+func (h *Handlers) ListScheduledInterviewsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.ListScheduledInterviews")
+	defer span.End()
+
+	if h.interviewSvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "interview service unavailable")
+		return
+	}
+
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		httputil.WriteError(w, http.StatusUnauthorized, "user authentication required")
+		return
+	}
+
+	rooms, err := h.interviewSvc.ListScheduledRooms(ctx, userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		httputil.WriteError(w, http.StatusInternalServerError, "failed to list scheduled interviews")
+		return
+	}
+
+	span.SetAttributes(tracing.AttrResponseCount.Int(len(rooms)))
+	httputil.WriteJSON(w, http.StatusOK, rooms)
+}
+
+// GetInterviewCalendarHandler handles GET /api/interview/{room_id}/calendar.ics
+// Serves the iCalendar file of a scheduled interview (owner only)
+func (h *Handlers) GetInterviewCalendarHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.GetInterviewCalendar")
+	defer span.End()
+
+	if h.interviewSvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "interview service unavailable")
+		return
+	}
+
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		httputil.WriteError(w, http.StatusUnauthorized, "user authentication required")
+		return
+	}
+
+	roomID := chi.URLParam(r, "room_id")
+	if roomID == "" {
+		httputil.WriteError(w, http.StatusBadRequest, "room_id is required")
+		return
+	}
+	span.SetAttributes(tracing.AttrRoomID.String(roomID))
+
+	ics, err := h.interviewSvc.GetRoomCalendar(ctx, roomID, userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		switch {
+		case errors.Is(err, interview.ErrRoomNotFound):
+			httputil.WriteError(w, http.StatusNotFound, "room not found")
+		case errors.Is(err, interview.ErrUnauthorized):
+			httputil.WriteError(w, http.StatusForbidden, "only room owner can export the calendar")
+		case errors.Is(err, interview.ErrNotScheduled):
+			httputil.WriteError(w, http.StatusNotFound, "room is not a scheduled interview")
+		default:
+			httputil.WriteError(w, http.StatusInternalServerError, "failed to export calendar")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="interview-%s.ics"`, roomID))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(ics)
+}
+
+// RunInterviewCodeHandler handles POST /api/interview/{room_id}/run
+// Executes code inside the room and records it in the run history.
+// Accessible to the room owner and to participants holding the room_access cookie.
+// Requires OptionalAuth middleware to set context.
+func (h *Handlers) RunInterviewCodeHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.RunInterviewCode")
+	defer span.End()
+
+	if h.interviewSvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "interview service unavailable")
+		return
+	}
+
+	roomID := chi.URLParam(r, "room_id")
+	if roomID == "" {
+		httputil.WriteError(w, http.StatusBadRequest, "room_id is required")
+		return
+	}
+	span.SetAttributes(tracing.AttrRoomID.String(roomID))
+
+	room, err := h.interviewSvc.GetRoomByID(ctx, roomID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		if errors.Is(err, interview.ErrRoomNotFound) {
+			httputil.WriteError(w, http.StatusNotFound, "room not found or has been closed")
+			return
+		}
+		httputil.WriteError(w, http.StatusInternalServerError, "failed to get room")
+		return
+	}
+	if !h.hasRoomAccess(r, room) {
+		httputil.WriteError(w, http.StatusForbidden, "room access required")
+		return
+	}
+
+	var req interview.RunCodeRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, interview.MaxRunCodeLength+1024)).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	result, err := h.interviewSvc.RunCode(ctx, roomID, req.Code)
+	if err != nil {
+		tracing.RecordError(span, err)
+		switch {
+		case errors.Is(err, interview.ErrEmptyCode):
+			httputil.WriteError(w, http.StatusBadRequest, "code cannot be empty")
+		case errors.Is(err, interview.ErrCodeTooLong):
+			httputil.WriteError(w, http.StatusRequestEntityTooLarge, err.Error())
+		case errors.Is(err, interview.ErrRoomNotFound):
+			httputil.WriteError(w, http.StatusNotFound, "room not found or has been closed")
+		case errors.Is(err, interview.ErrRoomNotActive):
+			httputil.WriteError(w, http.StatusConflict, "interview has already ended")
+		case errors.Is(err, run.ErrJudgeBusy):
+			w.Header().Set("Retry-After", "5")
+			httputil.WriteError(w, http.StatusServiceUnavailable, err.Error())
+		default:
+			httputil.WriteError(w, http.StatusInternalServerError, "failed to run code")
+		}
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, result)
+}
+
+// SubmitScorecardHandler handles POST /api/interview/{room_id}/scorecard
+// Creates or replaces the owner's scorecard (allowed after the room is closed)
+func (h *Handlers) SubmitScorecardHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.SubmitScorecard")
+	defer span.End()
+
+	if h.interviewSvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "interview service unavailable")
+		return
+	}
+
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		httputil.WriteError(w, http.StatusUnauthorized, "user authentication required")
+		return
+	}
+
+	roomID := chi.URLParam(r, "room_id")
+	if roomID == "" {
+		httputil.WriteError(w, http.StatusBadRequest, "room_id is required")
+		return
+	}
+
+	var req interview.SubmitScorecardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	scorecard, err := h.interviewSvc.SubmitScorecard(ctx, roomID, userID, &req)
+	if err != nil {
+		tracing.RecordError(span, err)
+		switch {
+		case errors.Is(err, interview.ErrRoomNotFound):
+			httputil.WriteError(w, http.StatusNotFound, "room not found")
+		case errors.Is(err, interview.ErrUnauthorized):
+			httputil.WriteError(w, http.StatusForbidden, "only room owner can submit a scorecard")
+		case errors.Is(err, interview.ErrInvalidScorecard):
+			httputil.WriteError(w, http.StatusBadRequest, err.Error())
+		default:
+			httputil.WriteError(w, http.StatusInternalServerError, "failed to save scorecard")
+		}
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, scorecard)
+}
+
+// GetInterviewReportHandler handles GET /api/interview/{room_id}/report
+// Returns the post-interview report as JSON (default), Markdown (?format=markdown)
+// or HTML (?format=html). Owner only; available after the room is closed.
+func (h *Handlers) GetInterviewReportHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.GetInterviewReport")
+	defer span.End()
+
+	if h.interviewSvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "interview service unavailable")
+		return
+	}
+
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		httputil.WriteError(w, http.StatusUnauthorized, "user authentication required")
+		return
+	}
+
+	roomID := chi.URLParam(r, "room_id")
+	if roomID == "" {
+		httputil.WriteError(w, http.StatusBadRequest, "room_id is required")
+		return
+	}
+	span.SetAttributes(tracing.AttrRoomID.String(roomID))
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "markdown" && format != "md" && format != "html" {
+		httputil.WriteError(w, http.StatusBadRequest, "format must be one of json, markdown, html")
+		return
+	}
+
+	report, err := h.interviewSvc.GetReport(ctx, roomID, userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		switch {
+		case errors.Is(err, interview.ErrRoomNotFound):
+			httputil.WriteError(w, http.StatusNotFound, "room not found")
+		case errors.Is(err, interview.ErrUnauthorized):
+			httputil.WriteError(w, http.StatusForbidden, "only room owner can view the report")
+		default:
+			httputil.WriteError(w, http.StatusInternalServerError, "failed to build report")
+		}
+		return
+	}
+
+	switch format {
+	case "markdown", "md":
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="interview-%s.md"`, roomID))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(report.RenderMarkdown()))
+	case "html":
+		page, err := report.RenderHTML()
+		if err != nil {
+			tracing.RecordError(span, err)
+			httputil.WriteError(w, http.StatusInternalServerError, "failed to render report")
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(page)
+	default:
+		httputil.WriteJSON(w, http.StatusOK, report)
+	}
+}
+
+// StartInterviewHandler handles POST /api/interview/{room_id}/start
+// Moves the room to in_progress (owner only)
+func (h *Handlers) StartInterviewHandler(w http.ResponseWriter, r *http.Request) {
+	h.transitionInterview(w, r, "handler.StartInterview", InterviewService.StartInterview)
+}
+
+// EndInterviewHandler handles POST /api/interview/{room_id}/end
+// Moves the room to ended (owner only); ending a room never started records a no-show
+func (h *Handlers) EndInterviewHandler(w http.ResponseWriter, r *http.Request) {
+	h.transitionInterview(w, r, "handler.EndInterview", InterviewService.EndInterview)
+}
+
+// transitionInterview is the shared implementation of the start/end handlers.
+// transition is a method expression so it is only bound once the service is known to be set.
+func (h *Handlers) transitionInterview(w http.ResponseWriter, r *http.Request, spanName string, transition func(svc InterviewService, ctx context.Context, roomID string, userID uint) (*interview.RoomStateResponse, error)) {
+	ctx, span := tracing.StartSpan(r.Context(), spanName)
+	defer span.End()
+
+	if h.interviewSvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "interview service unavailable")
+		return
+	}
+
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		httputil.WriteError(w, http.StatusUnauthorized, "user authentication required")
+		return
+	}
+
+	roomID := chi.URLParam(r, "room_id")
+	if roomID == "" {
+		httputil.WriteError(w, http.StatusBadRequest, "room_id is required")
+		return
+	}
+	span.SetAttributes(tracing.AttrRoomID.String(roomID))
+
+	resp, err := transition(h.interviewSvc, ctx, roomID, userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		switch {
+		case errors.Is(err, interview.ErrRoomNotFound):
+			httputil.WriteError(w, http.StatusNotFound, "room not found")
+		case errors.Is(err, interview.ErrUnauthorized):
+			httputil.WriteError(w, http.StatusForbidden, "only room owner can change the interview state")
+		case errors.Is(err, interview.ErrInvalidTransition):
+			httputil.WriteError(w, http.StatusConflict, err.Error())
+		default:
+			httputil.WriteError(w, http.StatusInternalServerError, "failed to update interview state")
+		}
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, resp)
+}
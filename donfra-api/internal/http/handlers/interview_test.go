@@ -0,0 +1,106 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"donfra-api/internal/domain/interview"
+	"donfra-api/internal/domain/problem"
+	"donfra-api/internal/http/handlers"
+)
+
+// MockInterviewService implements the room access methods of handlers.InterviewService.
+// Calling any other method panics.
+type MockInterviewService struct {
+	handlers.InterviewService
+	GetRoomByIDFunc        func(ctx context.Context, roomID string) (*interview.InterviewRoom, error)
+	ValidateRoomAccessFunc func(ctx context.Context, roomID, token string) error
+	GetRoomProblemFunc     func(ctx context.Context, roomID string) (*problem.ProblemPublic, error)
+}
+
+func (m *MockInterviewService) GetRoomByID(ctx context.Context, roomID string) (*interview.InterviewRoom, error) {
+	return m.GetRoomByIDFunc(ctx, roomID)
+}
+
+func (m *MockInterviewService) ValidateRoomAccess(ctx context.Context, roomID, token string) error {
+	return m.ValidateRoomAccessFunc(ctx, roomID, token)
+}
+
+func (m *MockInterviewService) GetRoomProblem(ctx context.Context, roomID string) (*problem.ProblemPublic, error) {
+	return m.GetRoomProblemFunc(ctx, roomID)
+}
+
+// roomRequest builds a request with the {room_id} URL parameter set
+func roomRequest(method, target, roomID string) *http.Request {
+	req := httptest.NewRequest(method, target, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("room_id", roomID)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+// TestGetInterviewRoomProblem_RequiresSignedAccess tests that only a valid signed
+// room_access cookie or the owner can read the problem
+func TestGetInterviewRoomProblem_RequiresSignedAccess(t *testing.T) {
+	mockInterview := &MockInterviewService{
+		GetRoomByIDFunc: func(ctx context.Context, roomID string) (*interview.InterviewRoom, error) {
+			return &interview.InterviewRoom{RoomID: roomID, OwnerID: 1}, nil
+		},
+		ValidateRoomAccessFunc: func(ctx context.Context, roomID, token string) error {
+			if token != "signed-token" {
+				return interview.ErrUnauthorized
+			}
+			return nil
+		},
+		GetRoomProblemFunc: func(ctx context.Context, roomID string) (*problem.ProblemPublic, error) {
+			return &problem.ProblemPublic{ID: 1, Title: "Two Sum"}, nil
+		},
+	}
+	h := handlers.New(nil, nil, nil, nil, mockInterview, nil)
+
+	for name, tc := range map[string]struct {
+		cookie string
+		userID uint
+		want   int
+	}{
+		"no cookie":       {want: http.StatusForbidden},
+		"forged room id":  {cookie: "abc123", want: http.StatusForbidden},
+		"signed token":    {cookie: "signed-token", want: http.StatusOK},
+		"owner":           {userID: 1, want: http.StatusOK},
+		"other signed-in": {userID: 2, want: http.StatusForbidden},
+	} {
+		req := roomRequest(http.MethodGet, "/api/interview/abc123/problem", "abc123")
+		if tc.cookie != "" {
+			req.AddCookie(&http.Cookie{Name: "room_access", Value: tc.cookie})
+		}
+		if tc.userID != 0 {
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", tc.userID))
+		}
+
+		w := httptest.NewRecorder()
+		h.GetInterviewRoomProblemHandler(w, req)
+		if w.Code != tc.want {
+			t.Errorf("%s: expected status %d, got %d", name, tc.want, w.Code)
+		}
+	}
+}
+
+// TestTransitionInterview_NoService tests that the start/end handlers fail cleanly
+// instead of panicking when the interview service is not configured
+func TestTransitionInterview_NoService(t *testing.T) {
+	h := handlers.New(nil, nil, nil, nil, nil, nil)
+
+	for name, handler := range map[string]http.HandlerFunc{
+		"start": h.StartInterviewHandler,
+		"end":   h.EndInterviewHandler,
+	} {
+		w := httptest.NewRecorder()
+		handler(w, roomRequest(http.MethodPost, "/api/interview/abc123/"+name, "abc123"))
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("%s: expected status 500, got %d", name, w.Code)
+		}
+	}
+}
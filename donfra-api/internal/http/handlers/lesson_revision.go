@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/pkg/httputil"
+	"donfra-api/internal/pkg/tracing"
+)
+
+// ListLessonRevisionsHandler handles GET /api/lessons/{slug}/revisions. Requires AdminOnly middleware.
+func (h *Handlers) ListLessonRevisionsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.ListLessonRevisions")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		httputil.WriteError(w, http.StatusBadRequest, "slug is required")
+		return
+	}
+
+	revisions, err := h.studySvc.ListLessonRevisions(ctx, slug)
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeRevisionError(w, err, "failed to load revisions")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, revisions)
+}
+
+// GetLessonRevisionHandler handles GET /api/lessons/{slug}/revisions/{revision_id}. Requires AdminOnly middleware.
+func (h *Handlers) GetLessonRevisionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.GetLessonRevision")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+	slug := chi.URLParam(r, "slug")
+	revisionID, ok := parseRevisionID(chi.URLParam(r, "revision_id"))
+	if slug == "" || !ok {
+		httputil.WriteError(w, http.StatusBadRequest, "slug and a numeric revision_id are required")
+		return
+	}
+
+	revision, err := h.studySvc.GetLessonRevision(ctx, slug, revisionID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeRevisionError(w, err, "failed to load revision")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, revision)
+}
+
+// DiffLessonRevisionsHandler handles GET /api/lessons/{slug}/revisions/diff?from={id}&to={id}.
+// Requires AdminOnly middleware.
+func (h *Handlers) DiffLessonRevisionsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.DiffLessonRevisions")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+	slug := chi.URLParam(r, "slug")
+	fromID, okFrom := parseRevisionID(r.URL.Query().Get("from"))
+	toID, okTo := parseRevisionID(r.URL.Query().Get("to"))
+	if slug == "" || !okFrom || !okTo {
+		httputil.WriteError(w, http.StatusBadRequest, "numeric from and to revision ids are required")
+		return
+	}
+
+	diff, err := h.studySvc.DiffLessonRevisions(ctx, slug, fromID, toID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeRevisionError(w, err, "failed to diff revisions")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, diff)
+}
+
+// RestoreLessonRevisionHandler handles POST /api/lessons/{slug}/revisions/{revision_id}/restore.
+// Requires AdminOnly middleware.
+func (h *Handlers) RestoreLessonRevisionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.RestoreLessonRevision")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+	slug := chi.URLParam(r, "slug")
+	revisionID, ok := parseRevisionID(chi.URLParam(r, "revision_id"))
+	if slug == "" || !ok {
+		httputil.WriteError(w, http.StatusBadRequest, "slug and a numeric revision_id are required")
+		return
+	}
+
+	lesson, err := h.studySvc.RestoreLessonRevision(ctx, slug, revisionID, revisionAuthor(ctx))
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeRevisionError(w, err, "failed to restore revision")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, lesson)
+}
+
+// revisionAuthor builds the revision author from the user set by OptionalAuth.
+// Requests made with the legacy admin token have no user and yield an empty author.
+func revisionAuthor(ctx context.Context) study.RevisionAuthor {
+	var author study.RevisionAuthor
+	if userID, ok := ctx.Value("user_id").(uint); ok {
+		author.UserID = &userID
+	}
+	if email, ok := ctx.Value("user_email").(string); ok {
+		author.Email = email
+	}
+	return author
+}
+
+// parseRevisionID parses a positive numeric revision ID
+func parseRevisionID(raw string) (uint, bool) {
+	id, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil || id == 0 {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// writeRevisionError maps study errors to HTTP responses for the revision endpoints
+func writeRevisionError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		httputil.WriteError(w, http.StatusNotFound, "lesson not found")
+	case errors.Is(err, study.ErrRevisionNotFound):
+		httputil.WriteError(w, http.StatusNotFound, "revision not found")
+	default:
+		httputil.WriteError(w, http.StatusInternalServerError, fallback)
+	}
+}
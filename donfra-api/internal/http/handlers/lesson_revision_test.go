@@ -0,0 +1,91 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/http/handlers"
+)
+
+// TestRestoreLessonRevision_RecordsAuthor tests that the restoring user is passed as revision author
+func TestRestoreLessonRevision_RecordsAuthor(t *testing.T) {
+	var gotAuthor study.RevisionAuthor
+	var gotRevision uint
+	mockStudy := &MockStudyService{
+		RestoreLessonRevisionFunc: func(ctx context.Context, slug string, revisionID uint, author study.RevisionAuthor) (*study.Lesson, error) {
+			gotAuthor = author
+			gotRevision = revisionID
+			return &study.Lesson{Slug: slug}, nil
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/lessons/intro/revisions/3/restore", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "intro")
+	rctx.URLParams.Add("revision_id", "3")
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	ctx = context.WithValue(ctx, "user_id", uint(42))
+	ctx = context.WithValue(ctx, "user_email", "admin@example.com")
+	req = req.WithContext(ctx)
+
+	w := httptest.NewRecorder()
+	h.RestoreLessonRevisionHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if gotRevision != 3 {
+		t.Errorf("expected revision 3, got %d", gotRevision)
+	}
+	if gotAuthor.UserID == nil || *gotAuthor.UserID != 42 || gotAuthor.Email != "admin@example.com" {
+		t.Errorf("unexpected author %+v", gotAuthor)
+	}
+}
+
+// TestRestoreLessonRevision_NotFound tests 404 for a revision of another lesson
+func TestRestoreLessonRevision_NotFound(t *testing.T) {
+	mockStudy := &MockStudyService{
+		RestoreLessonRevisionFunc: func(ctx context.Context, slug string, revisionID uint, author study.RevisionAuthor) (*study.Lesson, error) {
+			return nil, study.ErrRevisionNotFound
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/lessons/intro/revisions/99/restore", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "intro")
+	rctx.URLParams.Add("revision_id", "99")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	w := httptest.NewRecorder()
+	h.RestoreLessonRevisionHandler(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+}
+
+// TestDiffLessonRevisions_RequiresIDs tests validation of the from/to query parameters
+func TestDiffLessonRevisions_RequiresIDs(t *testing.T) {
+	h := handlers.New(nil, &MockStudyService{}, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/lessons/intro/revisions/diff?from=1", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "intro")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	w := httptest.NewRecorder()
+	h.DiffLessonRevisionsHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/pkg/httputil"
+	"donfra-api/internal/pkg/tracing"
+)
+
+// ListNotesHandler handles GET /api/me/notes with an optional ?lesson={slug} filter.
+// Requires RequireAuth middleware.
+func (h *Handlers) ListNotesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.ListNotes")
+	defer span.End()
+
+	userID, ok := h.noteUser(w, r)
+	if !ok {
+		return
+	}
+
+	notes, err := h.studySvc.ListNotes(ctx, userID, r.URL.Query().Get("lesson"))
+	if err != nil {
+		tracing.RecordError(span, err)
+		httputil.WriteError(w, http.StatusInternalServerError, "failed to load notes")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, notes)
+}
+
+// GetNoteHandler handles GET /api/me/notes/{id}. Requires RequireAuth middleware.
+func (h *Handlers) GetNoteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.GetNote")
+	defer span.End()
+
+	userID, ok := h.noteUser(w, r)
+	if !ok {
+		return
+	}
+	noteID, ok := parseNoteID(w, r)
+	if !ok {
+		return
+	}
+
+	note, err := h.studySvc.GetNote(ctx, userID, noteID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeNoteError(w, err, "failed to load note")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, note)
+}
+
+// CreateNoteHandler handles POST /api/me/notes. Requires RequireAuth middleware.
+func (h *Handlers) CreateNoteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.CreateNote")
+	defer span.End()
+
+	userID, ok := h.noteUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req study.CreateNoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	if req.LessonSlug == "" {
+		httputil.WriteError(w, http.StatusBadRequest, "lessonSlug is required")
+		return
+	}
+
+	note, err := h.studySvc.CreateNote(ctx, userID, &req, isAdminUser(ctx))
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeNoteError(w, err, "failed to create note")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusCreated, note)
+}
+
+// UpdateNoteHandler handles PATCH /api/me/notes/{id}. Requires RequireAuth middleware.
+func (h *Handlers) UpdateNoteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.UpdateNote")
+	defer span.End()
+
+	userID, ok := h.noteUser(w, r)
+	if !ok {
+		return
+	}
+	noteID, ok := parseNoteID(w, r)
+	if !ok {
+		return
+	}
+
+	var req study.UpdateNoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	note, err := h.studySvc.UpdateNote(ctx, userID, noteID, &req)
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeNoteError(w, err, "failed to update note")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, note)
+}
+
+// DeleteNoteHandler handles DELETE /api/me/notes/{id}. Requires RequireAuth middleware.
+func (h *Handlers) DeleteNoteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.DeleteNote")
+	defer span.End()
+
+	userID, ok := h.noteUser(w, r)
+	if !ok {
+		return
+	}
+	noteID, ok := parseNoteID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.studySvc.DeleteNote(ctx, userID, noteID); err != nil {
+		tracing.RecordError(span, err)
+		writeNoteError(w, err, "failed to delete note")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// noteUser checks the study service and returns the authenticated user ID
+func (h *Handlers) noteUser(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return 0, false
+	}
+	userID, ok := r.Context().Value("user_id").(uint)
+	if !ok {
+		httputil.WriteError(w, http.StatusUnauthorized, "user authentication required")
+		return 0, false
+	}
+	return userID, true
+}
+
+// parseNoteID reads the {id} URL parameter, writing a 400 on failure
+func parseNoteID(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id == 0 {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid note id")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// writeNoteError maps study note errors to HTTP responses
+func writeNoteError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, study.ErrNoteNotFound):
+		httputil.WriteError(w, http.StatusNotFound, "note not found")
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		httputil.WriteError(w, http.StatusNotFound, "lesson not found")
+	case errors.Is(err, study.ErrNoteEmpty),
+		errors.Is(err, study.ErrNoteTooLong),
+		errors.Is(err, study.ErrInvalidAnchor):
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		httputil.WriteError(w, http.StatusInternalServerError, fallback)
+	}
+}
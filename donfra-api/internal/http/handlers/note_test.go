@@ -0,0 +1,80 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/http/handlers"
+)
+
+// TestCreateNote_ScopedToCaller tests that notes are created for the authenticated user
+func TestCreateNote_ScopedToCaller(t *testing.T) {
+	var gotUser uint
+	mockStudy := &MockStudyService{
+		CreateNoteFunc: func(ctx context.Context, userID uint, req *study.CreateNoteRequest, includeUnpublished bool) (*study.LessonNote, error) {
+			gotUser = userID
+			return &study.LessonNote{ID: 1, LessonSlug: req.LessonSlug, Anchor: req.Anchor, Markdown: req.Markdown}, nil
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	body := `{"lessonSlug":"intro","anchor":"#setup","markdown":"remember this"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/me/notes", strings.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", uint(3)))
+	w := httptest.NewRecorder()
+
+	h.CreateNoteHandler(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", w.Code)
+	}
+	if gotUser != 3 {
+		t.Errorf("expected note for user 3, got %d", gotUser)
+	}
+}
+
+// TestGetNote_OtherUsersNoteNotFound tests that another user's note is reported as missing
+func TestGetNote_OtherUsersNoteNotFound(t *testing.T) {
+	mockStudy := &MockStudyService{
+		GetNoteFunc: func(ctx context.Context, userID, noteID uint) (*study.LessonNote, error) {
+			return nil, study.ErrNoteNotFound
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/me/notes/7", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "7")
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	ctx = context.WithValue(ctx, "user_id", uint(3))
+	req = req.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	h.GetNoteHandler(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+}
+
+// TestAddBookmark_RequiresAuth tests that bookmarks require a signed-in user
+func TestAddBookmark_RequiresAuth(t *testing.T) {
+	h := handlers.New(nil, &MockStudyService{}, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/me/bookmarks/intro", nil)
+	w := httptest.NewRecorder()
+
+	h.AddBookmarkHandler(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", w.Code)
+	}
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"donfra-api/internal/domain/user"
+	"donfra-api/internal/pkg/httputil"
+)
+
+// passwordResetTimeout bounds the background work of a forgot password request.
+const passwordResetTimeout = time.Minute
+
+// ForgotPassword emails a password reset link.
+// POST /api/auth/password/forgot
+// Always responds 202 so the endpoint does not reveal which emails are registered;
+// the router additionally limits requests per client IP.
+func (h *Handlers) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req user.ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	if req.Email == "" {
+		httputil.WriteError(w, http.StatusBadRequest, "email is required")
+		return
+	}
+
+	// The lookup and the email are handled in the background so the response takes the
+	// same time whether or not the account exists
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), passwordResetTimeout)
+	go func() {
+		defer cancel()
+		if err := h.userSvc.RequestPasswordReset(ctx, req.Email); err != nil {
+			log.Printf("[auth] password reset request failed: %v", err)
+		}
+	}()
+
+	httputil.WriteJSON(w, http.StatusAccepted, map[string]interface{}{
+		"message": "if an account exists for this email, a reset link has been sent",
+	})
+}
+
+// ResetPassword sets a new password with a token from the reset email and signs the
+// user out of every session, including the caller's.
+// POST /api/auth/password/reset
+func (h *Handlers) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req user.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	if err := h.userSvc.ResetPassword(ctx, req.Token, req.Password); err != nil {
+		switch {
+		case errors.Is(err, user.ErrPasswordTooShort), errors.Is(err, user.ErrInvalidResetToken):
+			httputil.WriteError(w, http.StatusBadRequest, err.Error())
+		default:
+			httputil.WriteError(w, http.StatusInternalServerError, "failed to reset password")
+		}
+		return
+	}
+
+	// Existing tokens are no longer valid; clear the cookie so the client signs in again
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   false,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	httputil.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "password has been reset",
+	})
+}
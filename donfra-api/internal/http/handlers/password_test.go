@@ -0,0 +1,111 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"donfra-api/internal/domain/user"
+	"donfra-api/internal/http/handlers"
+)
+
+// MockUserService implements the password reset methods of handlers.UserService.
+// Calling any other method panics.
+type MockUserService struct {
+	handlers.UserService
+	RequestPasswordResetFunc func(ctx context.Context, email string) error
+	ResetPasswordFunc        func(ctx context.Context, token, newPassword string) error
+}
+
+func (m *MockUserService) RequestPasswordReset(ctx context.Context, email string) error {
+	return m.RequestPasswordResetFunc(ctx, email)
+}
+
+func (m *MockUserService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	return m.ResetPasswordFunc(ctx, token, newPassword)
+}
+
+// TestForgotPassword_DoesNotRevealAccounts tests that the response does not depend on the outcome
+func TestForgotPassword_DoesNotRevealAccounts(t *testing.T) {
+	for _, err := range []error{nil, errors.New("smtp down")} {
+		mockUser := &MockUserService{
+			RequestPasswordResetFunc: func(ctx context.Context, email string) error { return err },
+		}
+		h := handlers.New(nil, nil, nil, mockUser, nil, nil)
+
+		w := httptest.NewRecorder()
+		h.ForgotPassword(w, httptest.NewRequest(http.MethodPost, "/api/auth/password/forgot",
+			strings.NewReader(`{"email":"ada@example.com"}`)))
+		if w.Code != http.StatusAccepted {
+			t.Errorf("%v: expected status 202, got %d", err, w.Code)
+		}
+	}
+}
+
+// TestForgotPassword_RespondsBeforeSending tests that the response does not wait for
+// the lookup and the email, so its timing does not depend on the account existing
+func TestForgotPassword_RespondsBeforeSending(t *testing.T) {
+	release := make(chan struct{})
+	got := make(chan string, 1)
+	mockUser := &MockUserService{
+		RequestPasswordResetFunc: func(ctx context.Context, email string) error {
+			<-release
+			got <- email
+			return ctx.Err()
+		},
+	}
+	h := handlers.New(nil, nil, nil, mockUser, nil, nil)
+
+	w := httptest.NewRecorder()
+	h.ForgotPassword(w, httptest.NewRequest(http.MethodPost, "/api/auth/password/forgot",
+		strings.NewReader(`{"email":"ada@example.com"}`)))
+	if w.Code != http.StatusAccepted {
+		t.Errorf("expected status 202, got %d", w.Code)
+	}
+
+	close(release)
+	select {
+	case email := <-got:
+		if email != "ada@example.com" {
+			t.Errorf("expected ada@example.com, got %q", email)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the reset to be requested in the background")
+	}
+}
+
+// TestResetPassword tests the responses and that the auth cookie is cleared
+func TestResetPassword(t *testing.T) {
+	for err, want := range map[error]int{
+		nil:                       http.StatusOK,
+		user.ErrInvalidResetToken: http.StatusBadRequest,
+		user.ErrPasswordTooShort:  http.StatusBadRequest,
+		errors.New("db down"):     http.StatusInternalServerError,
+	} {
+		var gotToken string
+		mockUser := &MockUserService{
+			ResetPasswordFunc: func(ctx context.Context, token, newPassword string) error {
+				gotToken = token
+				return err
+			},
+		}
+		h := handlers.New(nil, nil, nil, mockUser, nil, nil)
+
+		w := httptest.NewRecorder()
+		h.ResetPassword(w, httptest.NewRequest(http.MethodPost, "/api/auth/password/reset",
+			strings.NewReader(`{"token":"abc","password":"new-password"}`)))
+		if w.Code != want {
+			t.Errorf("%v: expected status %d, got %d", err, want, w.Code)
+		}
+		if gotToken != "abc" {
+			t.Errorf("%v: expected token abc, got %q", err, gotToken)
+		}
+		if err == nil && !strings.Contains(w.Header().Get("Set-Cookie"), "auth_token=;") {
+			t.Errorf("expected the auth cookie to be cleared, got %q", w.Header().Get("Set-Cookie"))
+		}
+	}
+}
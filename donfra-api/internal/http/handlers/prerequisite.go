@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/pkg/httputil"
+	"donfra-api/internal/pkg/tracing"
+)
+
+// lessonViewer describes the caller for lesson access checks
+func lessonViewer(ctx context.Context) study.LessonViewer {
+	viewer := study.LessonViewer{IsAdmin: isAdminUser(ctx)}
+	if userID, ok := ctx.Value("user_id").(uint); ok {
+		viewer.UserID = &userID
+	}
+	viewer.Role, _ = ctx.Value("user_role").(string)
+	return viewer
+}
+
+// lessonLockedResponse explains why a gated lesson cannot be read
+type lessonLockedResponse struct {
+	Error                string                     `json:"error"`
+	Reason               string                     `json:"reason"`
+	RequiredRole         string                     `json:"requiredRole,omitempty"`
+	MissingPrerequisites []study.PrerequisiteStatus `json:"missingPrerequisites,omitempty"`
+}
+
+// writeLessonLocked answers with 401 when signing in may unlock the lesson, otherwise 403
+func writeLessonLocked(w http.ResponseWriter, err error) {
+	var locked *study.LessonAccessError
+	if !errors.As(err, &locked) {
+		httputil.WriteError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	code := http.StatusForbidden
+	if locked.Reason == study.AccessLoginRequired {
+		code = http.StatusUnauthorized
+	}
+	httputil.WriteJSON(w, code, lessonLockedResponse{
+		Error:                locked.Error(),
+		Reason:               locked.Reason,
+		RequiredRole:         locked.RequiredRole,
+		MissingPrerequisites: locked.Missing,
+	})
+}
+
+// SetPrerequisitesHandler handles PUT /api/lessons/{slug}/prerequisites with
+// {"lessonSlugs": [...]} and replaces the ordered prerequisites of a lesson.
+// Requires RequireLessonAuthor middleware; mentors may only change lessons that pass
+// study.CheckLessonEditable.
+func (h *Handlers) SetPrerequisitesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.SetPrerequisites")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	var req study.SetPrerequisitesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	prerequisites, err := h.studySvc.SetLessonPrerequisites(ctx, chi.URLParam(r, "slug"), req.LessonSlugs, lessonEditor(ctx))
+	if err != nil {
+		tracing.RecordError(span, err)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			httputil.WriteError(w, http.StatusNotFound, "lesson not found")
+		case errors.Is(err, study.ErrInvalidPrerequisites), errors.Is(err, study.ErrUnknownLesson):
+			httputil.WriteError(w, http.StatusBadRequest, err.Error())
+		default:
+			writeReviewError(w, err, "failed to save prerequisites")
+		}
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, prerequisites)
+}
@@ -0,0 +1,201 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/http/handlers"
+)
+
+// gatedLessonMock serves a published lesson gated on a single prerequisite
+func gatedLessonMock(completed bool) *MockStudyService {
+	return &MockStudyService{
+		GetLessonBySlugFunc: func(ctx context.Context, slug string) (*study.Lesson, error) {
+			return &study.Lesson{ID: 2, Slug: slug, IsPublished: true, RequirePrerequisites: true}, nil
+		},
+		GetLessonPrerequisitesFunc: func(ctx context.Context, lessonID uint, userID *uint, includeUnpublished bool) ([]study.PrerequisiteStatus, error) {
+			return []study.PrerequisiteStatus{{Slug: "basics", Title: "Basics", Completed: completed && userID != nil}}, nil
+		},
+	}
+}
+
+// TestGetLessonBySlug_PrerequisitesLocked tests the 401/403 responses for gated lessons
+func TestGetLessonBySlug_PrerequisitesLocked(t *testing.T) {
+	h := handlers.New(nil, gatedLessonMock(false), nil, nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	h.GetLessonBySlugHandler(w, slugRequest(http.MethodGet, "/api/lessons/advanced", "advanced", ""))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 for anonymous reader, got %d", w.Code)
+	}
+
+	req := slugRequest(http.MethodGet, "/api/lessons/advanced", "advanced", "")
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", uint(7)))
+	w = httptest.NewRecorder()
+	h.GetLessonBySlugHandler(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", w.Code)
+	}
+	var resp struct {
+		Reason  string                     `json:"reason"`
+		Missing []study.PrerequisiteStatus `json:"missingPrerequisites"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Reason != study.AccessPrerequisitesIncomplete || len(resp.Missing) != 1 || resp.Missing[0].Slug != "basics" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+// TestGetLessonBySlug_PrerequisitesCompleted tests that completing prerequisites unlocks the lesson
+func TestGetLessonBySlug_PrerequisitesCompleted(t *testing.T) {
+	h := handlers.New(nil, gatedLessonMock(true), nil, nil, nil, nil)
+
+	req := slugRequest(http.MethodGet, "/api/lessons/advanced", "advanced", "")
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", uint(7)))
+	w := httptest.NewRecorder()
+	h.GetLessonBySlugHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	var resp struct {
+		Prerequisites []study.PrerequisiteStatus `json:"prerequisites"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Prerequisites) != 1 || !resp.Prerequisites[0].Completed {
+		t.Errorf("unexpected prerequisites %+v", resp.Prerequisites)
+	}
+}
+
+// TestGetLessonBySlug_RoleRequired tests that mentor content is hidden from regular users
+func TestGetLessonBySlug_RoleRequired(t *testing.T) {
+	mockStudy := &MockStudyService{
+		GetLessonBySlugFunc: func(ctx context.Context, slug string) (*study.Lesson, error) {
+			return &study.Lesson{ID: 3, Slug: slug, IsPublished: true, RequiredRole: "mentor"}, nil
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	for role, want := range map[string]int{"user": http.StatusForbidden, "mentor": http.StatusOK, "admin": http.StatusOK} {
+		req := slugRequest(http.MethodGet, "/api/lessons/mentoring", "mentoring", "")
+		ctx := context.WithValue(req.Context(), "user_id", uint(7))
+		ctx = context.WithValue(ctx, "user_role", role)
+		w := httptest.NewRecorder()
+		h.GetLessonBySlugHandler(w, req.WithContext(ctx))
+		if w.Code != want {
+			t.Errorf("role %s: expected status %d, got %d", role, want, w.Code)
+		}
+	}
+}
+
+// TestUpdateLessonProgress_CompletingLockedLesson tests that a prerequisite cannot be
+// marked completed by a user who may not access it, which would unlock what requires it
+func TestUpdateLessonProgress_CompletingLockedLesson(t *testing.T) {
+	lesson := &study.Lesson{ID: 1, Slug: "basics", IsPublished: true, RequiredRole: "mentor"}
+	saved := false
+	mockStudy := &MockStudyService{
+		UpdateLessonProgressFunc: func(ctx context.Context, userID uint, slug string, req *study.UpdateProgressRequest, viewer study.LessonViewer) (*study.LessonProgress, error) {
+			if req.Status == study.ProgressCompleted {
+				if err := study.CheckLessonAccess(lesson, viewer, nil); err != nil {
+					return nil, err
+				}
+			}
+			saved = true
+			return &study.LessonProgress{UserID: userID, LessonID: lesson.ID, Status: req.Status}, nil
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := slugRequest(http.MethodPut, "/api/me/progress/basics", "basics", `{"status":"completed"}`)
+	ctx := context.WithValue(req.Context(), "user_id", uint(7))
+	w := httptest.NewRecorder()
+	h.UpdateLessonProgressHandler(w, req.WithContext(context.WithValue(ctx, "user_role", "user")))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 when completing a locked lesson, got %d", w.Code)
+	}
+	if saved {
+		t.Error("completing a locked lesson should not be saved")
+	}
+
+	w = httptest.NewRecorder()
+	h.UpdateLessonProgressHandler(w, asMentor(slugRequest(http.MethodPut, "/api/me/progress/basics", "basics", `{"status":"completed"}`), 7))
+	if w.Code != http.StatusOK || !saved {
+		t.Errorf("expected a mentor to complete the lesson, got %d", w.Code)
+	}
+}
+
+// TestLessonFeatures_Locked tests that quizzes, exercises and comments of a gated
+// lesson are refused like the lesson itself
+func TestLessonFeatures_Locked(t *testing.T) {
+	lesson := &study.Lesson{ID: 2, Slug: "advanced", IsPublished: true, RequiredRole: "mentor"}
+	check := func(viewer study.LessonViewer) error {
+		return study.CheckLessonAccess(lesson, viewer, nil)
+	}
+	mockStudy := &MockStudyService{
+		SubmitQuizFunc: func(ctx context.Context, slug string, userID uint, req *study.SubmitQuizRequest, viewer study.LessonViewer) (*study.QuizAttemptResult, error) {
+			return &study.QuizAttemptResult{}, check(viewer)
+		},
+		ListQuizAttemptsFunc: func(ctx context.Context, slug string, userID uint, viewer study.LessonViewer) ([]study.QuizAttempt, error) {
+			return nil, check(viewer)
+		},
+		SubmitExerciseFunc: func(ctx context.Context, slug, key string, userID uint, code string, viewer study.LessonViewer) (*study.ExerciseSubmission, error) {
+			return &study.ExerciseSubmission{}, check(viewer)
+		},
+		ListCommentsFunc: func(ctx context.Context, slug string, viewer study.LessonViewer, moderator bool) ([]*study.CommentNode, error) {
+			return nil, check(viewer)
+		},
+		CreateCommentFunc: func(ctx context.Context, slug string, userID uint, req *study.CreateCommentRequest, viewer study.LessonViewer) (*study.Comment, error) {
+			return &study.Comment{}, check(viewer)
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	cases := []struct {
+		name    string
+		handler http.HandlerFunc
+		method  string
+		body    string
+	}{
+		{"submit quiz", h.SubmitQuizHandler, http.MethodPost, `{"answers":{}}`},
+		{"list quiz attempts", h.ListQuizAttemptsHandler, http.MethodGet, ""},
+		{"submit exercise", h.SubmitExerciseHandler, http.MethodPost, `{"code":"print(1)"}`},
+		{"list comments", h.ListCommentsHandler, http.MethodGet, ""},
+		{"create comment", h.CreateCommentHandler, http.MethodPost, `{"body":"hi"}`},
+	}
+	for _, tc := range cases {
+		req := slugRequest(tc.method, "/api/lessons/advanced", "advanced", tc.body)
+		ctx := context.WithValue(req.Context(), "user_id", uint(7))
+		w := httptest.NewRecorder()
+		tc.handler(w, req.WithContext(context.WithValue(ctx, "user_role", "user")))
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s: expected status 403 for a regular user, got %d", tc.name, w.Code)
+			continue
+		}
+		var resp struct {
+			Reason string `json:"reason"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil || resp.Reason != study.AccessRoleRequired {
+			t.Errorf("%s: unexpected response %+v (%v)", tc.name, resp, err)
+		}
+
+		w = httptest.NewRecorder()
+		tc.handler(w, asMentor(slugRequest(tc.method, "/api/lessons/advanced", "advanced", tc.body), 7))
+		if w.Code >= http.StatusBadRequest {
+			t.Errorf("%s: expected a mentor to pass, got %d", tc.name, w.Code)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	h.ListCommentsHandler(w, slugRequest(http.MethodGet, "/api/lessons/advanced/comments", "advanced", ""))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 for anonymous comment readers, got %d", w.Code)
+	}
+}
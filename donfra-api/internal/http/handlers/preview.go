@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/pkg/httputil"
+	"donfra-api/internal/pkg/tracing"
+)
+
+// writePreviewError maps study service errors of preview links to HTTP responses
+func writePreviewError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		httputil.WriteError(w, http.StatusNotFound, "lesson not found")
+	case errors.Is(err, study.ErrPreviewNotFound):
+		httputil.WriteError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, study.ErrInvalidPreviewRequest):
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		httputil.WriteError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// CreateLessonPreviewHandler handles POST /api/lessons/{slug}/previews with an optional
+// {"label": "...", "expiresInHours": 72} and returns a preview link token that lets
+// reviewers read the lesson while it is unpublished. Requires RequireLessonOwner middleware.
+func (h *Handlers) CreateLessonPreviewHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.CreateLessonPreview")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	var req study.CreatePreviewRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+			return
+		}
+	}
+
+	link, err := h.studySvc.CreateLessonPreview(ctx, chi.URLParam(r, "slug"), &req, revisionAuthor(ctx))
+	if err != nil {
+		tracing.RecordError(span, err)
+		writePreviewError(w, err, "failed to create preview link")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusCreated, link)
+}
+
+// ListLessonPreviewsHandler handles GET /api/lessons/{slug}/previews. Tokens are not
+// returned again. Requires RequireLessonOwner middleware.
+func (h *Handlers) ListLessonPreviewsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.ListLessonPreviews")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	previews, err := h.studySvc.ListLessonPreviews(ctx, chi.URLParam(r, "slug"))
+	if err != nil {
+		tracing.RecordError(span, err)
+		writePreviewError(w, err, "failed to load preview links")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, previews)
+}
+
+// RevokeLessonPreviewHandler handles DELETE /api/lessons/{slug}/previews/{id}. The link
+// stops working immediately. Requires RequireLessonOwner middleware.
+func (h *Handlers) RevokeLessonPreviewHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.RevokeLessonPreview")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id == 0 {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid preview id")
+		return
+	}
+
+	if err := h.studySvc.RevokeLessonPreview(ctx, chi.URLParam(r, "slug"), uint(id)); err != nil {
+		tracing.RecordError(span, err)
+		writePreviewError(w, err, "failed to revoke preview link")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
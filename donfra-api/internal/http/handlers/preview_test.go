@@ -0,0 +1,110 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/http/handlers"
+)
+
+// draftLessonMock serves an unpublished, role-gated lesson that accepts the preview token "good"
+func draftLessonMock() *MockStudyService {
+	return &MockStudyService{
+		GetLessonBySlugFunc: func(ctx context.Context, slug string) (*study.Lesson, error) {
+			return &study.Lesson{ID: 3, Slug: slug, RequiredRole: "mentor"}, nil
+		},
+		CheckLessonPreviewFunc: func(ctx context.Context, lesson *study.Lesson, token string) error {
+			if token == "good" {
+				return nil
+			}
+			return study.ErrInvalidPreview
+		},
+	}
+}
+
+// TestGetLessonBySlug_PreviewToken tests that a valid preview token shows a draft to
+// anonymous reviewers and an invalid one does not
+func TestGetLessonBySlug_PreviewToken(t *testing.T) {
+	h := handlers.New(nil, draftLessonMock(), nil, nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	h.GetLessonBySlugHandler(w, slugRequest(http.MethodGet, "/api/lessons/draft?preview=good", "draft", ""))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	var resp struct {
+		Slug    string `json:"slug"`
+		Preview bool   `json:"preview"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Slug != "draft" || !resp.Preview {
+		t.Errorf("unexpected response %+v", resp)
+	}
+
+	for _, target := range []string{"/api/lessons/draft", "/api/lessons/draft?preview=bad"} {
+		w = httptest.NewRecorder()
+		h.GetLessonBySlugHandler(w, slugRequest(http.MethodGet, target, "draft", ""))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s: expected status 404, got %d", target, w.Code)
+		}
+	}
+}
+
+// TestCreateLessonPreview tests that the author and requested expiry reach the service
+func TestCreateLessonPreview(t *testing.T) {
+	var gotReq *study.CreatePreviewRequest
+	var gotAuthor study.RevisionAuthor
+	mockStudy := &MockStudyService{
+		CreateLessonPreviewFunc: func(ctx context.Context, slug string, req *study.CreatePreviewRequest, author study.RevisionAuthor) (*study.PreviewLink, error) {
+			gotReq, gotAuthor = req, author
+			return &study.PreviewLink{LessonPreview: study.LessonPreview{ID: 1}, Token: "tok"}, nil
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := slugRequest(http.MethodPost, "/api/lessons/draft/previews", "draft", `{"label":"review","expiresInHours":24}`)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", uint(7)))
+	w := httptest.NewRecorder()
+	h.CreateLessonPreviewHandler(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", w.Code)
+	}
+	if gotReq.Label != "review" || gotReq.ExpiresInHours != 24 {
+		t.Errorf("unexpected request %+v", gotReq)
+	}
+	if gotAuthor.UserID == nil || *gotAuthor.UserID != 7 {
+		t.Errorf("expected author 7, got %+v", gotAuthor)
+	}
+}
+
+// TestRevokeLessonPreview tests revoking and the 404 for unknown previews
+func TestRevokeLessonPreview(t *testing.T) {
+	mockStudy := &MockStudyService{
+		RevokeLessonPreviewFunc: func(ctx context.Context, slug string, previewID uint) error {
+			if previewID == 1 {
+				return nil
+			}
+			return study.ErrPreviewNotFound
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	for id, want := range map[string]int{"1": http.StatusNoContent, "2": http.StatusNotFound, "x": http.StatusBadRequest} {
+		req := slugRequest(http.MethodDelete, "/api/lessons/draft/previews/"+id, "draft", "")
+		chi.RouteContext(req.Context()).URLParams.Add("id", id)
+		w := httptest.NewRecorder()
+		h.RevokeLessonPreviewHandler(w, req)
+		if w.Code != want {
+			t.Errorf("id %s: expected status %d, got %d", id, want, w.Code)
+		}
+	}
+}
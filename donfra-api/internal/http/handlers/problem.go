@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"donfra-api/internal/domain/problem"
+	"donfra-api/internal/pkg/httputil"
+	"donfra-api/internal/pkg/tracing"
+)
+
+// ListProblemsHandler handles GET /api/problems. Requires RequireAdminUser middleware.
+// Supports optional ?difficulty= and ?tag= filters.
+func (h *Handlers) ListProblemsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.ListProblems")
+	defer span.End()
+
+	if h.problemSvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "problem service unavailable")
+		return
+	}
+
+	problems, err := h.problemSvc.ListProblems(ctx, problem.ListProblemsFilter{
+		Difficulty: r.URL.Query().Get("difficulty"),
+		Tag:        r.URL.Query().Get("tag"),
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		httputil.WriteError(w, http.StatusInternalServerError, "failed to load problems")
+		return
+	}
+
+	span.SetAttributes(tracing.AttrResponseCount.Int(len(problems)))
+	httputil.WriteJSON(w, http.StatusOK, problems)
+}
+
+// GetProblemHandler handles GET /api/problems/{id}. Requires RequireAdminUser middleware.
+func (h *Handlers) GetProblemHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.GetProblem")
+	defer span.End()
+
+	if h.problemSvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "problem service unavailable")
+		return
+	}
+
+	id, ok := parseProblemID(w, r)
+	if !ok {
+		return
+	}
+
+	p, err := h.problemSvc.GetProblem(ctx, id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeProblemError(w, err, "failed to load problem")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, p)
+}
+
+// CreateProblemHandler handles POST /api/problems. Requires RequireAdminUser middleware.
+func (h *Handlers) CreateProblemHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.CreateProblem")
+	defer span.End()
+
+	if h.problemSvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "problem service unavailable")
+		return
+	}
+
+	var req problem.CreateProblemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	created, err := h.problemSvc.CreateProblem(ctx, &req)
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeProblemError(w, err, "failed to create problem")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusCreated, created)
+}
+
+// UpdateProblemHandler handles PATCH /api/problems/{id}. Requires RequireAdminUser middleware.
+func (h *Handlers) UpdateProblemHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.UpdateProblem")
+	defer span.End()
+
+	if h.problemSvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "problem service unavailable")
+		return
+	}
+
+	id, ok := parseProblemID(w, r)
+	if !ok {
+		return
+	}
+
+	var req problem.UpdateProblemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	updated, err := h.problemSvc.UpdateProblem(ctx, id, &req)
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeProblemError(w, err, "failed to update problem")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, updated)
+}
+
+// DeleteProblemHandler handles DELETE /api/problems/{id}. Requires RequireAdminUser middleware.
+func (h *Handlers) DeleteProblemHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.DeleteProblem")
+	defer span.End()
+
+	if h.problemSvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "problem service unavailable")
+		return
+	}
+
+	id, ok := parseProblemID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.problemSvc.DeleteProblem(ctx, id); err != nil {
+		tracing.RecordError(span, err)
+		writeProblemError(w, err, "failed to delete problem")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseProblemID reads the {id} URL parameter, writing a 400 response if it is invalid.
+func parseProblemID(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id == 0 {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid problem id")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// writeProblemError maps problem service errors to HTTP responses.
+func writeProblemError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, problem.ErrProblemNotFound):
+		httputil.WriteError(w, http.StatusNotFound, "problem not found")
+	case errors.Is(err, problem.ErrTitleRequired),
+		errors.Is(err, problem.ErrStatementRequired),
+		errors.Is(err, problem.ErrInvalidDifficulty):
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		httputil.WriteError(w, http.StatusInternalServerError, fallback)
+	}
+}
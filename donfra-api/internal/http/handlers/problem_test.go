@@ -0,0 +1,182 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"donfra-api/internal/domain/problem"
+	"donfra-api/internal/http/handlers"
+)
+
+// MockProblemService is a mock implementation of handlers.ProblemService
+type MockProblemService struct {
+	CreateProblemFunc func(ctx context.Context, req *problem.CreateProblemRequest) (*problem.Problem, error)
+	GetProblemFunc    func(ctx context.Context, id uint) (*problem.Problem, error)
+	ListProblemsFunc  func(ctx context.Context, filter problem.ListProblemsFilter) ([]problem.Problem, error)
+	UpdateProblemFunc func(ctx context.Context, id uint, req *problem.UpdateProblemRequest) (*problem.Problem, error)
+	DeleteProblemFunc func(ctx context.Context, id uint) error
+}
+
+func (m *MockProblemService) CreateProblem(ctx context.Context, req *problem.CreateProblemRequest) (*problem.Problem, error) {
+	return m.CreateProblemFunc(ctx, req)
+}
+
+func (m *MockProblemService) GetProblem(ctx context.Context, id uint) (*problem.Problem, error) {
+	return m.GetProblemFunc(ctx, id)
+}
+
+func (m *MockProblemService) ListProblems(ctx context.Context, filter problem.ListProblemsFilter) ([]problem.Problem, error) {
+	return m.ListProblemsFunc(ctx, filter)
+}
+
+func (m *MockProblemService) UpdateProblem(ctx context.Context, id uint, req *problem.UpdateProblemRequest) (*problem.Problem, error) {
+	return m.UpdateProblemFunc(ctx, id, req)
+}
+
+func (m *MockProblemService) DeleteProblem(ctx context.Context, id uint) error {
+	return m.DeleteProblemFunc(ctx, id)
+}
+
+// idRequest builds a request with the {id} URL parameter set
+func idRequest(method, target, id, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+// TestListProblems_Filters tests that query parameters are passed as filters
+func TestListProblems_Filters(t *testing.T) {
+	var got problem.ListProblemsFilter
+	mockProblem := &MockProblemService{
+		ListProblemsFunc: func(ctx context.Context, filter problem.ListProblemsFilter) ([]problem.Problem, error) {
+			got = filter
+			return []problem.Problem{{ID: 1, Title: "Two Sum"}}, nil
+		},
+	}
+	h := handlers.New(nil, nil, nil, nil, nil, mockProblem)
+
+	w := httptest.NewRecorder()
+	h.ListProblemsHandler(w, httptest.NewRequest(http.MethodGet, "/api/problems?difficulty=easy&tag=array", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if got.Difficulty != "easy" || got.Tag != "array" {
+		t.Errorf("unexpected filter %+v", got)
+	}
+}
+
+// TestGetProblem tests lookups by id
+func TestGetProblem(t *testing.T) {
+	mockProblem := &MockProblemService{
+		GetProblemFunc: func(ctx context.Context, id uint) (*problem.Problem, error) {
+			if id != 1 {
+				return nil, problem.ErrProblemNotFound
+			}
+			return &problem.Problem{ID: 1, Title: "Two Sum"}, nil
+		},
+	}
+	h := handlers.New(nil, nil, nil, nil, nil, mockProblem)
+
+	for id, want := range map[string]int{
+		"1":   http.StatusOK,
+		"2":   http.StatusNotFound,
+		"abc": http.StatusBadRequest,
+		"0":   http.StatusBadRequest,
+	} {
+		w := httptest.NewRecorder()
+		h.GetProblemHandler(w, idRequest(http.MethodGet, "/api/problems/"+id, id, ""))
+		if w.Code != want {
+			t.Errorf("id %s: expected status %d, got %d", id, want, w.Code)
+		}
+	}
+}
+
+// TestCreateProblem tests creation and validation errors
+func TestCreateProblem(t *testing.T) {
+	mockProblem := &MockProblemService{
+		CreateProblemFunc: func(ctx context.Context, req *problem.CreateProblemRequest) (*problem.Problem, error) {
+			if req.Title == "" {
+				return nil, problem.ErrTitleRequired
+			}
+			return &problem.Problem{ID: 1, Title: req.Title, Statement: req.Statement}, nil
+		},
+	}
+	h := handlers.New(nil, nil, nil, nil, nil, mockProblem)
+
+	w := httptest.NewRecorder()
+	h.CreateProblemHandler(w, httptest.NewRequest(http.MethodPost, "/api/problems",
+		strings.NewReader(`{"title":"Two Sum","statement":"Find two numbers."}`)))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", w.Code)
+	}
+	var created problem.Problem
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil || created.Title != "Two Sum" {
+		t.Errorf("unexpected response %+v (%v)", created, err)
+	}
+
+	for body, want := range map[string]int{
+		`{"statement":"Find two numbers."}`: http.StatusBadRequest,
+		`{invalid`:                          http.StatusBadRequest,
+	} {
+		w := httptest.NewRecorder()
+		h.CreateProblemHandler(w, httptest.NewRequest(http.MethodPost, "/api/problems", strings.NewReader(body)))
+		if w.Code != want {
+			t.Errorf("%s: expected status %d, got %d", body, want, w.Code)
+		}
+	}
+}
+
+// TestUpdateProblem tests partial updates
+func TestUpdateProblem(t *testing.T) {
+	var gotID uint
+	mockProblem := &MockProblemService{
+		UpdateProblemFunc: func(ctx context.Context, id uint, req *problem.UpdateProblemRequest) (*problem.Problem, error) {
+			gotID = id
+			if id != 1 {
+				return nil, problem.ErrProblemNotFound
+			}
+			return &problem.Problem{ID: id, Title: "Two Sum", Difficulty: problem.DifficultyHard}, nil
+		},
+	}
+	h := handlers.New(nil, nil, nil, nil, nil, mockProblem)
+
+	w := httptest.NewRecorder()
+	h.UpdateProblemHandler(w, idRequest(http.MethodPatch, "/api/problems/1", "1", `{"difficulty":"hard"}`))
+	if w.Code != http.StatusOK || gotID != 1 {
+		t.Errorf("expected status 200 for problem 1, got %d for %d", w.Code, gotID)
+	}
+
+	w = httptest.NewRecorder()
+	h.UpdateProblemHandler(w, idRequest(http.MethodPatch, "/api/problems/2", "2", `{"difficulty":"hard"}`))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+}
+
+// TestDeleteProblem tests deletion
+func TestDeleteProblem(t *testing.T) {
+	mockProblem := &MockProblemService{
+		DeleteProblemFunc: func(ctx context.Context, id uint) error {
+			if id != 1 {
+				return problem.ErrProblemNotFound
+			}
+			return nil
+		},
+	}
+	h := handlers.New(nil, nil, nil, nil, nil, mockProblem)
+
+	for id, want := range map[string]int{"1": http.StatusNoContent, "2": http.StatusNotFound} {
+		w := httptest.NewRecorder()
+		h.DeleteProblemHandler(w, idRequest(http.MethodDelete, "/api/problems/"+id, id, ""))
+		if w.Code != want {
+			t.Errorf("id %s: expected status %d, got %d", id, want, w.Code)
+		}
+	}
+}
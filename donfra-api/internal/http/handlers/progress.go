@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/pkg/httputil"
+	"donfra-api/internal/pkg/tracing"
+)
+
+// UpdateLessonProgressHandler handles PUT /api/me/progress/{slug} and records the
+// caller's status and reading position in a lesson. Marking a gated lesson completed
+// needs the same access as reading it. Requires RequireAuth middleware.
+func (h *Handlers) UpdateLessonProgressHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.UpdateLessonProgress")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		httputil.WriteError(w, http.StatusUnauthorized, "user authentication required")
+		return
+	}
+
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		httputil.WriteError(w, http.StatusBadRequest, "slug is required")
+		return
+	}
+
+	var req study.UpdateProgressRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	progress, err := h.studySvc.UpdateLessonProgress(ctx, userID, slug, &req, lessonViewer(ctx))
+	if err != nil {
+		tracing.RecordError(span, err)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			httputil.WriteError(w, http.StatusNotFound, "lesson not found")
+		case errors.Is(err, study.ErrLessonLocked):
+			writeLessonLocked(w, err)
+		case errors.Is(err, study.ErrInvalidProgressStatus), errors.Is(err, study.ErrLastPositionTooLong):
+			httputil.WriteError(w, http.StatusBadRequest, err.Error())
+		default:
+			httputil.WriteError(w, http.StatusInternalServerError, "failed to save progress")
+		}
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, progress)
+}
+
+// GetMyProgressHandler handles GET /api/me/progress and returns the caller's lesson
+// progress with completion per track. Requires RequireAuth middleware.
+func (h *Handlers) GetMyProgressHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.GetMyProgress")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	userID, ok := ctx.Value("user_id").(uint)
+	if !ok {
+		httputil.WriteError(w, http.StatusUnauthorized, "user authentication required")
+		return
+	}
+
+	summary, err := h.studySvc.GetProgressSummary(ctx, userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		httputil.WriteError(w, http.StatusInternalServerError, "failed to load progress")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, summary)
+}
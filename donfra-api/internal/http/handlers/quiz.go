@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/pkg/httputil"
+	"donfra-api/internal/pkg/tracing"
+)
+
+// GetQuizHandler handles GET /api/lessons/{slug}/quiz and returns the quiz including
+// its answers. Requires RequireLessonOwner middleware.
+func (h *Handlers) GetQuizHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.GetQuiz")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	quiz, err := h.studySvc.GetQuiz(ctx, chi.URLParam(r, "slug"))
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeQuizError(w, err, "failed to load quiz")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, quiz)
+}
+
+// SetQuizHandler handles PUT /api/lessons/{slug}/quiz and creates or replaces the
+// quiz of a lesson. Requires RequireLessonAuthor middleware; mentors may only change
+// lessons that pass study.CheckLessonEditable.
+func (h *Handlers) SetQuizHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.SetQuiz")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	var req study.SetQuizRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	quiz, err := h.studySvc.SetQuiz(ctx, chi.URLParam(r, "slug"), &req, lessonEditor(ctx))
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeQuizError(w, err, "failed to save quiz")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, quiz)
+}
+
+// DeleteQuizHandler handles DELETE /api/lessons/{slug}/quiz. Requires RequireLessonAuthor
+// middleware; mentors may only change lessons that pass study.CheckLessonEditable.
+func (h *Handlers) DeleteQuizHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.DeleteQuiz")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	if err := h.studySvc.DeleteQuiz(ctx, chi.URLParam(r, "slug"), lessonEditor(ctx)); err != nil {
+		tracing.RecordError(span, err)
+		writeQuizError(w, err, "failed to delete quiz")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SubmitQuizHandler handles POST /api/lessons/{slug}/quiz/attempts with
+// {"answers": {"q1": {"choices": [0]}, "q2": {"text": "..."}}} and returns the graded attempt.
+// Requires RequireAuth middleware.
+func (h *Handlers) SubmitQuizHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.SubmitQuiz")
+	defer span.End()
+
+	userID, ok := h.noteUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req study.SubmitQuizRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, study.MaxQuizSubmissionSize)).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	result, err := h.studySvc.SubmitQuiz(ctx, chi.URLParam(r, "slug"), userID, &req, lessonViewer(ctx))
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeQuizError(w, err, "failed to grade quiz")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusCreated, result)
+}
+
+// ListQuizAttemptsHandler handles GET /api/lessons/{slug}/quiz/attempts and returns the
+// caller's attempt history, newest first. Requires RequireAuth middleware.
+func (h *Handlers) ListQuizAttemptsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.ListQuizAttempts")
+	defer span.End()
+
+	userID, ok := h.noteUser(w, r)
+	if !ok {
+		return
+	}
+
+	attempts, err := h.studySvc.ListQuizAttempts(ctx, chi.URLParam(r, "slug"), userID, lessonViewer(ctx))
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeQuizError(w, err, "failed to load quiz attempts")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, attempts)
+}
+
+// writeQuizError maps study quiz errors to HTTP responses
+func writeQuizError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, study.ErrQuizNotFound):
+		httputil.WriteError(w, http.StatusNotFound, "quiz not found")
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		httputil.WriteError(w, http.StatusNotFound, "lesson not found")
+	case errors.Is(err, study.ErrLessonLocked):
+		writeLessonLocked(w, err)
+	case errors.Is(err, study.ErrInvalidQuiz):
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, study.ErrQuizRateLimited):
+		w.Header().Set("Retry-After", strconv.Itoa(int(study.QuizAttemptRateWindow.Seconds())))
+		httputil.WriteError(w, http.StatusTooManyRequests, err.Error())
+	default:
+		writeReviewError(w, err, fallback)
+	}
+}
@@ -0,0 +1,139 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/http/handlers"
+)
+
+// TestSubmitQuizHandler tests that answers are forwarded and the graded attempt returned
+func TestSubmitQuizHandler(t *testing.T) {
+	mockStudy := &MockStudyService{
+		SubmitQuizFunc: func(ctx context.Context, slug string, userID uint, req *study.SubmitQuizRequest, viewer study.LessonViewer) (*study.QuizAttemptResult, error) {
+			if slug != "loops" || userID != 7 || req.Answers["q1"].Text != "paris" {
+				t.Errorf("unexpected submission %q %d %+v", slug, userID, req.Answers)
+			}
+			return &study.QuizAttemptResult{
+				QuizAttempt:  &study.QuizAttempt{Score: 100, Correct: 1, Total: 1, Passed: true},
+				PassingScore: 70,
+				Questions:    []study.QuestionResult{{ID: "q1", Correct: true}},
+			}, nil
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := slugRequest(http.MethodPost, "/api/lessons/loops/quiz/attempts", "loops", `{"answers":{"q1":{"text":"paris"}}}`)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", uint(7)))
+	w := httptest.NewRecorder()
+	h.SubmitQuizHandler(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp map[string]any
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp["score"] != float64(100) || resp["passed"] != true || resp["passingScore"] != float64(70) {
+		t.Errorf("unexpected response %v", resp)
+	}
+}
+
+// TestSubmitQuizHandler_NoQuiz tests the 404 for lessons without a quiz
+func TestSubmitQuizHandler_NoQuiz(t *testing.T) {
+	mockStudy := &MockStudyService{
+		SubmitQuizFunc: func(ctx context.Context, slug string, userID uint, req *study.SubmitQuizRequest, viewer study.LessonViewer) (*study.QuizAttemptResult, error) {
+			return nil, study.ErrQuizNotFound
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := slugRequest(http.MethodPost, "/api/lessons/loops/quiz/attempts", "loops", `{"answers":{}}`)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", uint(7)))
+	w := httptest.NewRecorder()
+	h.SubmitQuizHandler(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", w.Code)
+	}
+}
+
+// TestSubmitQuizHandler_RateLimited tests the 429 once a user submits too many attempts
+func TestSubmitQuizHandler_RateLimited(t *testing.T) {
+	mockStudy := &MockStudyService{
+		SubmitQuizFunc: func(ctx context.Context, slug string, userID uint, req *study.SubmitQuizRequest, viewer study.LessonViewer) (*study.QuizAttemptResult, error) {
+			return nil, study.ErrQuizRateLimited
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := slugRequest(http.MethodPost, "/api/lessons/loops/quiz/attempts", "loops", `{"answers":{}}`)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", uint(7)))
+	w := httptest.NewRecorder()
+	h.SubmitQuizHandler(w, req)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("expected a Retry-After header")
+	}
+}
+
+// TestSubmitQuizHandler_BodyTooLarge tests that oversized attempts are rejected before grading
+func TestSubmitQuizHandler_BodyTooLarge(t *testing.T) {
+	called := false
+	mockStudy := &MockStudyService{
+		SubmitQuizFunc: func(ctx context.Context, slug string, userID uint, req *study.SubmitQuizRequest, viewer study.LessonViewer) (*study.QuizAttemptResult, error) {
+			called = true
+			return nil, nil
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	body := `{"answers":{"q1":{"text":"` + strings.Repeat("x", study.MaxQuizSubmissionSize) + `"}}}`
+	req := slugRequest(http.MethodPost, "/api/lessons/loops/quiz/attempts", "loops", body)
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", uint(7)))
+	w := httptest.NewRecorder()
+	h.SubmitQuizHandler(w, req)
+
+	if w.Code != http.StatusBadRequest || called {
+		t.Fatalf("expected status 400 without grading, got %d", w.Code)
+	}
+}
+
+// TestGetLessonBySlug_QuizOmitsAnswers tests that the lesson response embeds the quiz without answers
+func TestGetLessonBySlug_QuizOmitsAnswers(t *testing.T) {
+	mockStudy := &MockStudyService{
+		GetLessonBySlugFunc: func(ctx context.Context, slug string) (*study.Lesson, error) {
+			return &study.Lesson{ID: 3, Slug: slug, IsPublished: true}, nil
+		},
+		GetQuizViewFunc: func(ctx context.Context, lessonID uint, userID *uint) (*study.QuizView, error) {
+			quiz := &study.Quiz{PassingScore: 70, Questions: []study.QuizQuestion{
+				{ID: "q1", Type: study.QuestionShortAnswer, Prompt: "Capital?", Answers: []string{"Paris"}},
+			}}
+			return study.NewQuizView(quiz, nil), nil
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	h.GetLessonBySlugHandler(w, slugRequest(http.MethodGet, "/api/lessons/loops", "loops", ""))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, `"quiz"`) || !strings.Contains(body, "Capital?") {
+		t.Errorf("expected quiz in response: %s", body)
+	}
+	if strings.Contains(body, "Paris") {
+		t.Errorf("response leaked the answer: %s", body)
+	}
+}
@@ -0,0 +1,113 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/http/handlers"
+)
+
+// slugRequest builds a request with the {slug} URL parameter set
+func slugRequest(method, target, slug, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", slug)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+// TestGetLessonBySlug_RedirectsRenamedSlug tests that a former slug answers with a 301
+func TestGetLessonBySlug_RedirectsRenamedSlug(t *testing.T) {
+	mockStudy := &MockStudyService{
+		GetLessonBySlugFunc: func(ctx context.Context, slug string) (*study.Lesson, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+		ResolveSlugRedirectFunc: func(ctx context.Context, oldSlug string) (*study.Lesson, error) {
+			if oldSlug != "old-intro" {
+				return nil, gorm.ErrRecordNotFound
+			}
+			return &study.Lesson{Slug: "intro", IsPublished: true}, nil
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	h.GetLessonBySlugHandler(w, slugRequest(http.MethodGet, "/api/v1/lessons/old-intro?preview=1", "old-intro", ""))
+
+	if w.Code != http.StatusMovedPermanently {
+		t.Fatalf("expected status 301, got %d", w.Code)
+	}
+	if loc := w.Header().Get("Location"); loc != "/api/v1/lessons/intro?preview=1" {
+		t.Errorf("unexpected Location %q", loc)
+	}
+
+	w = httptest.NewRecorder()
+	h.GetLessonBySlugHandler(w, slugRequest(http.MethodGet, "/api/v1/lessons/never", "never", ""))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown slug, got %d", w.Code)
+	}
+}
+
+// TestGetLessonBySlug_RedirectHidesUnpublishedTarget tests that redirects don't leak unpublished lessons
+func TestGetLessonBySlug_RedirectHidesUnpublishedTarget(t *testing.T) {
+	mockStudy := &MockStudyService{
+		GetLessonBySlugFunc: func(ctx context.Context, slug string) (*study.Lesson, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+		ResolveSlugRedirectFunc: func(ctx context.Context, oldSlug string) (*study.Lesson, error) {
+			return &study.Lesson{Slug: "secret-draft"}, nil
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+	w := httptest.NewRecorder()
+
+	h.GetLessonBySlugHandler(w, slugRequest(http.MethodGet, "/api/lessons/old", "old", ""))
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", w.Code)
+	}
+}
+
+// TestUpdateLesson_RenameSlug tests renaming through PATCH and the conflict response
+func TestUpdateLesson_RenameSlug(t *testing.T) {
+	var gotUpdates map[string]any
+	mockStudy := &MockStudyService{
+		UpdateLessonBySlugFunc: func(ctx context.Context, slug string, updates map[string]any, editor study.LessonEditor, author study.RevisionAuthor) error {
+			gotUpdates = updates
+			if updates["slug"] == "taken" {
+				return study.ErrSlugTaken
+			}
+			return nil
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	h.UpdateLessonHandler(w, slugRequest(http.MethodPatch, "/api/lessons/intro", "intro", `{"slug":" intro-to-go "}`))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if gotUpdates["slug"] != "intro-to-go" {
+		t.Errorf("expected trimmed slug update, got %v", gotUpdates)
+	}
+	var resp study.UpdateLessonResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil || resp.Slug != "intro-to-go" {
+		t.Errorf("expected response with the new slug, got %+v (%v)", resp, err)
+	}
+
+	w = httptest.NewRecorder()
+	h.UpdateLessonHandler(w, slugRequest(http.MethodPatch, "/api/lessons/intro", "intro", `{"slug":"taken"}`))
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d", w.Code)
+	}
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/pkg/httputil"
+	"donfra-api/internal/pkg/tracing"
+)
+
+// lessonEditor describes the caller for lesson ownership checks
+func lessonEditor(ctx context.Context) study.LessonEditor {
+	editor := study.LessonEditor{IsAdmin: isAdminUser(ctx)}
+	if userID, ok := ctx.Value("user_id").(uint); ok {
+		editor.UserID = &userID
+	}
+	return editor
+}
+
+// writeReviewError maps ownership and review errors to HTTP responses
+func writeReviewError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		httputil.WriteError(w, http.StatusNotFound, "lesson not found")
+	case errors.Is(err, study.ErrNotLessonOwner), errors.Is(err, study.ErrLessonPublishedLocked),
+		errors.Is(err, study.ErrPublishRequiresReview):
+		httputil.WriteError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, study.ErrReviewState), errors.Is(err, study.ErrLessonInReview):
+		httputil.WriteError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, study.ErrInvalidReview), errors.Is(err, study.ErrInvalidSchedule):
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		httputil.WriteError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// RequireLessonOwner lets admins through and mentors only for their own unpublished
+// lesson ({slug}). Must be used after middleware.RequireLessonAuthor.
+// Routes that change a lesson's content do not use it: the service checks
+// study.CheckLessonEditable on the row it updates, within the same transaction.
+func (h *Handlers) RequireLessonOwner(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		editor := lessonEditor(ctx)
+		if editor.IsAdmin {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if h.studySvc == nil {
+			httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+			return
+		}
+
+		lesson, err := h.studySvc.GetLessonBySlug(ctx, chi.URLParam(r, "slug"))
+		if err == nil {
+			err = study.CheckLessonOwner(lesson, editor)
+		}
+		if err != nil {
+			writeReviewError(w, err, "failed to load lesson")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// SubmitLessonForReviewHandler handles POST /api/lessons/{slug}/submit and asks admins
+// to publish the lesson. Requires RequireLessonAuthor and RequireLessonOwner middleware.
+func (h *Handlers) SubmitLessonForReviewHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.SubmitLessonForReview")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	lesson, err := h.studySvc.SubmitLessonForReview(ctx, chi.URLParam(r, "slug"))
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeReviewError(w, err, "failed to submit lesson for review")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, lesson)
+}
+
+// ReviewLessonHandler handles POST /api/lessons/{slug}/review with
+// {"action": "approve"|"request_changes", "note": "...", "publishAt": optional}.
+// Approving publishes the lesson. Requires AdminOnly middleware.
+func (h *Handlers) ReviewLessonHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.ReviewLesson")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	var req study.ReviewLessonRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	lesson, err := h.studySvc.ReviewLesson(ctx, chi.URLParam(r, "slug"), &req, revisionAuthor(ctx))
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeReviewError(w, err, "failed to review lesson")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, lesson)
+}
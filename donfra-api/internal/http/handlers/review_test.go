@@ -0,0 +1,254 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/http/handlers"
+)
+
+// asMentor puts a signed-in mentor in the request context
+func asMentor(req *http.Request, userID uint) *http.Request {
+	ctx := context.WithValue(req.Context(), "user_id", userID)
+	ctx = context.WithValue(ctx, "user_role", "mentor")
+	return req.WithContext(ctx)
+}
+
+// TestCreateLesson_MentorOwnsDraft tests that mentors create drafts they own but cannot publish
+func TestCreateLesson_MentorOwnsDraft(t *testing.T) {
+	var created *study.Lesson
+	mockStudy := &MockStudyService{
+		CreateLessonFunc: func(ctx context.Context, newLesson *study.Lesson, author study.RevisionAuthor) (*study.Lesson, error) {
+			created = newLesson
+			return newLesson, nil
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	h.CreateLessonHandler(w, asMentor(httptest.NewRequest(http.MethodPost, "/api/lessons",
+		strings.NewReader(`{"slug":"graphs","title":"Graphs","isPublished":true}`)), 7))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 when a mentor publishes, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	h.CreateLessonHandler(w, asMentor(httptest.NewRequest(http.MethodPost, "/api/lessons",
+		strings.NewReader(`{"slug":"graphs","title":"Graphs"}`)), 7))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", w.Code)
+	}
+	if created.OwnerID == nil || *created.OwnerID != 7 {
+		t.Errorf("expected owner 7, got %v", created.OwnerID)
+	}
+}
+
+// TestRequireLessonOwner tests that mentors only reach their own unpublished lessons
+func TestRequireLessonOwner(t *testing.T) {
+	owner := uint(7)
+	lessons := map[string]*study.Lesson{
+		"mine":      {Slug: "mine", OwnerID: &owner},
+		"published": {Slug: "published", OwnerID: &owner, IsPublished: true},
+		"theirs":    {Slug: "theirs"},
+	}
+	mockStudy := &MockStudyService{
+		GetLessonBySlugFunc: func(ctx context.Context, slug string) (*study.Lesson, error) {
+			return lessons[slug], nil
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+	next := h.RequireLessonOwner(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	for slug, want := range map[string]int{
+		"mine":      http.StatusNoContent,
+		"published": http.StatusForbidden,
+		"theirs":    http.StatusForbidden,
+	} {
+		w := httptest.NewRecorder()
+		next.ServeHTTP(w, asMentor(slugRequest(http.MethodPatch, "/api/lessons/"+slug, slug, ""), owner))
+		if w.Code != want {
+			t.Errorf("%s: expected status %d, got %d", slug, want, w.Code)
+		}
+	}
+}
+
+// TestUpdateLesson_EditAfterSubmit tests that a mentor cannot change a lesson after
+// submitting it for review, so approving it publishes what was submitted. The check
+// runs on the stored lesson, not on a possibly stale cached copy.
+func TestUpdateLesson_EditAfterSubmit(t *testing.T) {
+	owner := uint(7)
+	stored := &study.Lesson{Slug: "mine", OwnerID: &owner}
+	cached := *stored
+	updated := false
+	mockStudy := &MockStudyService{
+		GetLessonBySlugFunc: func(ctx context.Context, slug string) (*study.Lesson, error) {
+			copied := cached
+			return &copied, nil
+		},
+		SubmitLessonForReviewFunc: func(ctx context.Context, slug string) (*study.Lesson, error) {
+			stored.ReviewStatus = study.ReviewPending
+			return stored, nil
+		},
+		UpdateLessonBySlugFunc: func(ctx context.Context, slug string, updates map[string]any, editor study.LessonEditor, author study.RevisionAuthor) error {
+			if err := study.CheckLessonEditable(stored, editor); err != nil {
+				return err
+			}
+			updated = true
+			return nil
+		},
+		SetQuizFunc: func(ctx context.Context, slug string, req *study.SetQuizRequest, editor study.LessonEditor) (*study.Quiz, error) {
+			if err := study.CheckLessonEditable(stored, editor); err != nil {
+				return nil, err
+			}
+			return &study.Quiz{}, nil
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+	submit := h.RequireLessonOwner(http.HandlerFunc(h.SubmitLessonForReviewHandler))
+	read := h.RequireLessonOwner(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	w := httptest.NewRecorder()
+	submit.ServeHTTP(w, asMentor(slugRequest(http.MethodPost, "/api/lessons/mine/submit", "mine", ""), owner))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 on submit, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	h.UpdateLessonHandler(w, asMentor(slugRequest(http.MethodPatch, "/api/lessons/mine", "mine", `{"markdown":"changed"}`), owner))
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected status 409 for an edit after submit, got %d", w.Code)
+	}
+	if updated {
+		t.Error("edit after submit should not be written")
+	}
+
+	w = httptest.NewRecorder()
+	h.SetQuizHandler(w, asMentor(slugRequest(http.MethodPut, "/api/lessons/mine/quiz", "mine", `{"questions":[]}`), owner))
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected status 409 for a quiz change after submit, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	h.UpdateLessonHandler(w, asMentor(slugRequest(http.MethodPatch, "/api/lessons/mine", "mine", `{"markdown":"changed"}`), 8))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for another mentor, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	read.ServeHTTP(w, asMentor(slugRequest(http.MethodGet, "/api/lessons/mine/quiz", "mine", ""), owner))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected the owner to still read the lesson, got %d", w.Code)
+	}
+
+	req := slugRequest(http.MethodPatch, "/api/lessons/mine", "mine", `{"markdown":"fixed typo"}`)
+	w = httptest.NewRecorder()
+	h.UpdateLessonHandler(w, req.WithContext(context.WithValue(req.Context(), "user_role", "admin")))
+	if w.Code != http.StatusOK || !updated {
+		t.Errorf("expected admins to edit a lesson in review, got %d", w.Code)
+	}
+}
+
+// TestUpdateLesson_MentorCannotPublish tests that mentors cannot change the published state
+func TestUpdateLesson_MentorCannotPublish(t *testing.T) {
+	called := false
+	mockStudy := &MockStudyService{
+		UpdateLessonBySlugFunc: func(ctx context.Context, slug string, updates map[string]any, editor study.LessonEditor, author study.RevisionAuthor) error {
+			called = true
+			return nil
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	for _, body := range []string{`{"isPublished":true}`, `{"publishAt":"2030-01-01T00:00:00Z"}`} {
+		w := httptest.NewRecorder()
+		h.UpdateLessonHandler(w, asMentor(slugRequest(http.MethodPatch, "/api/lessons/mine", "mine", body), 7))
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s: expected status 403, got %d", body, w.Code)
+		}
+	}
+	if called {
+		t.Error("update should not reach the service")
+	}
+}
+
+// TestGetLessonBySlug_OwnerSeesDraft tests that authors can read their unpublished lessons
+func TestGetLessonBySlug_OwnerSeesDraft(t *testing.T) {
+	owner := uint(7)
+	mockStudy := &MockStudyService{
+		GetLessonBySlugFunc: func(ctx context.Context, slug string) (*study.Lesson, error) {
+			return &study.Lesson{Slug: slug, OwnerID: &owner}, nil
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	h.GetLessonBySlugHandler(w, asMentor(slugRequest(http.MethodGet, "/api/lessons/mine", "mine", ""), owner))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200 for the owner, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	h.GetLessonBySlugHandler(w, asMentor(slugRequest(http.MethodGet, "/api/lessons/mine", "mine", ""), 8))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for another mentor, got %d", w.Code)
+	}
+}
+
+// TestListLessons_Mine tests that ?mine=true lists the caller's lessons including drafts
+func TestListLessons_Mine(t *testing.T) {
+	var gotQuery study.ListLessonsQuery
+	mockStudy := &MockStudyService{
+		ListLessonSummariesFunc: func(ctx context.Context, query study.ListLessonsQuery) (*study.LessonPage, error) {
+			gotQuery = query
+			return &study.LessonPage{}, nil
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	h.ListLessonsHandler(w, httptest.NewRequest(http.MethodGet, "/api/lessons?mine=true", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 when signed out, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	h.ListLessonsHandler(w, asMentor(httptest.NewRequest(http.MethodGet, "/api/lessons?mine=true&review=pending", nil), 7))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if gotQuery.OwnerID == nil || *gotQuery.OwnerID != 7 || gotQuery.Published != nil {
+		t.Errorf("unexpected query %+v", gotQuery)
+	}
+	if gotQuery.ReviewStatus != "" {
+		t.Errorf("only admins filter by review status, got %q", gotQuery.ReviewStatus)
+	}
+}
+
+// TestReviewLesson_Errors tests the responses for invalid reviews
+func TestReviewLesson_Errors(t *testing.T) {
+	for err, want := range map[error]int{
+		study.ErrInvalidReview: http.StatusBadRequest,
+		study.ErrReviewState:   http.StatusConflict,
+	} {
+		mockStudy := &MockStudyService{
+			ReviewLessonFunc: func(ctx context.Context, slug string, req *study.ReviewLessonRequest, reviewer study.RevisionAuthor) (*study.Lesson, error) {
+				return nil, err
+			},
+		}
+		h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+		w := httptest.NewRecorder()
+		h.ReviewLessonHandler(w, slugRequest(http.MethodPost, "/api/lessons/draft/review", "draft", `{"action":"approve"}`))
+		if w.Code != want {
+			t.Errorf("%v: expected status %d, got %d", err, want, w.Code)
+		}
+	}
+}
@@ -4,10 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 
 	"donfra-api/internal/domain/study"
@@ -30,8 +36,22 @@ func isAdminUser(ctx context.Context) bool {
 	return ok && role == "admin"
 }
 
-// ListLessonsHandler handles GET /api/lessons and returns lessons based on auth status.
-// Admin users see all lessons (published + unpublished), regular users see only published.
+// ownsLesson reports whether the signed-in user created the lesson
+func ownsLesson(ctx context.Context, lesson *study.Lesson) bool {
+	userID, ok := ctx.Value("user_id").(uint)
+	return ok && lesson.OwnerID != nil && *lesson.OwnerID == userID
+}
+
+// ListLessonsHandler handles GET /api/lessons and returns a page of lesson summaries.
+// Admin users see all lessons (published + unpublished) and may filter with ?published=,
+// regular users see only published. Supports ?tag= and ?category= filters,
+// ?sort=created|updated|title, ?order=asc|desc, ?limit= and ?cursor=. The body stays a JSON array; the cursor for the next page is
+// returned in the X-Next-Cursor header (plus a Link rel="next" header). Authors list their own
+// lessons, drafts included, with ?mine=true; admins find lessons awaiting review with
+// ?review=pending. Responses carry an
+// ETag, and conditional requests are answered with 304 Not Modified. No Last-Modified is
+// sent: deleting a lesson or a change in which excerpts the viewer may see alters the page
+// without moving its newest updated_at, so If-Modified-Since could answer 304 for a stale page.
 // Requires OptionalAuth middleware to set context.
 func (h *Handlers) ListLessonsHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, span := tracing.StartSpan(r.Context(), "handler.ListLessons")
@@ -48,30 +68,124 @@ func (h *Handlers) ListLessonsHandler(w http.ResponseWriter, r *http.Request) {
 	authSpan.SetAttributes(tracing.AttrIsAdmin.Bool(isAdmin))
 	authSpan.End()
 
-	var lessons []study.Lesson
-	var err error
+	params := r.URL.Query()
+	query := study.ListLessonsQuery{
+		Sort:     params.Get("sort"),
+		Order:    params.Get("order"),
+		Cursor:   params.Get("cursor"),
+		Tag:      params.Get("tag"),
+		Category: params.Get("category"),
+		Viewer:   lessonViewer(ctx),
+	}
+	if raw := params.Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			httputil.WriteError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		query.Limit = n
+	}
+	userID, signedIn := ctx.Value("user_id").(uint)
+	mine := params.Get("mine") == "true"
+	if mine {
+		if !signedIn {
+			httputil.WriteError(w, http.StatusUnauthorized, "sign in to list your lessons")
+			return
+		}
+		query.OwnerID = &userID
+	}
 	if isAdmin {
-		lessons, err = h.studySvc.ListAllLessons(ctx)
+		query.ReviewStatus = params.Get("review")
+	}
+	if isAdmin || mine {
+		if raw := params.Get("published"); raw != "" {
+			published, err := strconv.ParseBool(raw)
+			if err != nil {
+				httputil.WriteError(w, http.StatusBadRequest, "published must be true or false")
+				return
+			}
+			query.Published = &published
+		}
 	} else {
-		lessons, err = h.studySvc.ListPublishedLessons(ctx)
+		published := true
+		query.Published = &published
 	}
 
+	page, err := h.studySvc.ListLessonSummaries(ctx, query)
 	if err != nil {
 		tracing.RecordError(span, err)
-		httputil.WriteError(w, http.StatusInternalServerError, "failed to load lessons")
+		switch {
+		case errors.Is(err, study.ErrInvalidSort), errors.Is(err, study.ErrInvalidCursor):
+			httputil.WriteError(w, http.StatusBadRequest, err.Error())
+		default:
+			httputil.WriteError(w, http.StatusInternalServerError, "failed to load lessons")
+		}
 		return
 	}
 
+	if page.NextCursor != "" {
+		next := *r.URL
+		nextParams := next.Query()
+		nextParams.Set("cursor", page.NextCursor)
+		next.RawQuery = nextParams.Encode()
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+		w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"next\"", next.RequestURI()))
+	}
+
 	// Serialize response
 	_, jsonSpan := tracing.StartSpan(ctx, "handler.SerializeJSON",
-		tracing.AttrResponseCount.Int(len(lessons)),
+		tracing.AttrResponseCount.Int(len(page.Items)),
 	)
-	httputil.WriteJSON(w, http.StatusOK, lessons)
+	httputil.WriteJSONCached(w, r, page.Items)
 	jsonSpan.End()
 }
 
+// SearchLessonsHandler handles GET /api/lessons/search?q=&limit= and returns ranked hits
+// with highlighted snippets. Admin users also search unpublished lessons.
+// Requires OptionalAuth middleware to set context.
+func (h *Handlers) SearchLessonsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.SearchLessons")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	query := r.URL.Query().Get("q")
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			httputil.WriteError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = n
+	}
+
+	isAdmin := isAdminUser(ctx)
+	span.SetAttributes(tracing.AttrIsAdmin.Bool(isAdmin))
+
+	results, err := h.studySvc.SearchLessons(ctx, query, lessonViewer(ctx), isAdmin, limit)
+	if err != nil {
+		tracing.RecordError(span, err)
+		switch {
+		case errors.Is(err, study.ErrSearchQueryRequired), errors.Is(err, study.ErrSearchQueryTooLong):
+			httputil.WriteError(w, http.StatusBadRequest, err.Error())
+		default:
+			httputil.WriteError(w, http.StatusInternalServerError, "failed to search lessons")
+		}
+		return
+	}
+	if results == nil {
+		results = []study.LessonSearchResult{}
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, results)
+}
+
 // GetLessonBySlugHandler handles GET /api/lessons/{slug} and returns the lesson with full content.
-// Unpublished lessons can only be accessed by admin users.
+// Unpublished lessons can only be accessed by admin users, their owner or with a preview token (?preview=).
 // Requires OptionalAuth middleware to set context.
 func (h *Handlers) GetLessonBySlugHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, span := tracing.StartSpan(r.Context(), "handler.GetLessonBySlug")
@@ -97,15 +211,18 @@ func (h *Handlers) GetLessonBySlugHandler(w http.ResponseWriter, r *http.Request
 	if err != nil {
 		tracing.RecordError(span, err)
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			httputil.WriteError(w, http.StatusNotFound, "lesson not found")
+			h.redirectRenamedLesson(w, r, slug)
 			return
 		}
 		httputil.WriteError(w, http.StatusInternalServerError, "failed to load lesson")
 		return
 	}
 
-	// If lesson is unpublished, verify admin access (either admin token OR user with role=admin)
-	if !lesson.IsPublished {
+	// If lesson is not published (now, taking its schedule into account), verify admin access
+	// (either admin token OR user with role=admin), ownership or a preview link (?preview=)
+	isOwner := ownsLesson(ctx, lesson)
+	isPreview := false
+	if !lesson.IsPublishedAt(time.Now()) {
 		_, authSpan := tracing.StartSpan(ctx, "handler.CheckUnpublishedAccess")
 		isAdmin := isAdminUser(ctx)
 		authSpan.SetAttributes(
@@ -114,18 +231,111 @@ func (h *Handlers) GetLessonBySlugHandler(w http.ResponseWriter, r *http.Request
 		)
 		authSpan.End()
 
-		if !isAdmin {
-			httputil.WriteError(w, http.StatusNotFound, "lesson not found")
+		if !isAdmin && !isOwner {
+			token := r.URL.Query().Get("preview")
+			if token == "" {
+				httputil.WriteError(w, http.StatusNotFound, "lesson not found")
+				return
+			}
+			if err := h.studySvc.CheckLessonPreview(ctx, lesson, token); err != nil {
+				tracing.RecordError(span, err)
+				if errors.Is(err, study.ErrInvalidPreview) {
+					httputil.WriteError(w, http.StatusNotFound, err.Error())
+					return
+				}
+				httputil.WriteError(w, http.StatusInternalServerError, "failed to load lesson")
+				return
+			}
+			isPreview = true
+		}
+	}
+
+	// Gated lessons: required role and completed prerequisites. Authors and reviewers on
+	// a preview link read the whole draft.
+	viewer := lessonViewer(ctx)
+	prerequisites, err := h.studySvc.GetLessonPrerequisites(ctx, lesson.ID, viewer.UserID, viewer.IsAdmin)
+	if err != nil {
+		tracing.RecordError(span, err)
+		httputil.WriteError(w, http.StatusInternalServerError, "failed to load lesson")
+		return
+	}
+	if !isPreview && !isOwner {
+		if err := study.CheckLessonAccess(lesson, viewer, prerequisites); err != nil {
+			writeLessonLocked(w, err)
 			return
 		}
 	}
 
+	// Validate conditional requests before building the response. The detail changes with
+	// the lesson and the viewer's own activity (LessonModifiedAt) and with who is asking.
+	if modified, err := h.studySvc.LessonModifiedAt(ctx, lesson, viewer.UserID); err != nil {
+		tracing.RecordError(span, err)
+	} else if httputil.CheckNotModified(w, r, modified, lessonDetailVariant(viewer, isPreview, isOwner, prerequisites)) {
+		return
+	}
+
+	resp := lessonDetailResponse{Lesson: lesson, Prerequisites: prerequisites, Preview: isPreview}
+
+	// Sanitized HTML is returned alongside the markdown; clients fall back to the source on failure
+	if rendered, err := h.studySvc.RenderLesson(ctx, lesson); err != nil {
+		tracing.RecordError(span, err)
+	} else {
+		resp.HTML = rendered.HTML
+		resp.TOC = rendered.TOC
+	}
+
+	// Include the caller's progress when signed in; a lookup failure should not hide the lesson
+	if userID, ok := ctx.Value("user_id").(uint); ok {
+		progress, err := h.studySvc.GetLessonProgress(ctx, userID, lesson.ID)
+		if err != nil {
+			tracing.RecordError(span, err)
+		}
+		resp.Progress = progress
+	}
+
+	exercises, err := h.studySvc.GetExerciseViews(ctx, lesson.ID, viewer.UserID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+	resp.Exercises = exercises
+
+	quiz, err := h.studySvc.GetQuizView(ctx, lesson.ID, viewer.UserID)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+	resp.Quiz = quiz
+
 	_, jsonSpan := tracing.StartSpan(ctx, "handler.SerializeJSON")
-	httputil.WriteJSON(w, http.StatusOK, lesson)
+	httputil.WriteJSON(w, http.StatusOK, resp)
 	jsonSpan.End()
 }
 
-// CreateLessonHandler handles POST /api/lesson. Requires AdminOnly middleware.
+// lessonDetailVariant identifies what a lesson detail depends on besides its
+// modification time: who is asking, how they got access and their prerequisites
+func lessonDetailVariant(viewer study.LessonViewer, isPreview, isOwner bool, prerequisites []study.PrerequisiteStatus) string {
+	var userID uint
+	if viewer.UserID != nil {
+		userID = *viewer.UserID
+	}
+	return fmt.Sprintf("%d|%s|%t|%t|%t|%v", userID, viewer.Role, viewer.IsAdmin, isPreview, isOwner, prerequisites)
+}
+
+// lessonDetailResponse is the lesson returned by GetLessonBySlugHandler together
+// with its rendered HTML, prerequisites, exercises, quiz and the caller's progress when authenticated.
+type lessonDetailResponse struct {
+	*study.Lesson
+	HTML          string                     `json:"html,omitempty"`
+	TOC           []study.TOCEntry           `json:"toc,omitempty"`
+	Prerequisites []study.PrerequisiteStatus `json:"prerequisites,omitempty"`
+	Progress      *study.LessonProgress      `json:"progress,omitempty"`
+	Exercises     []study.ExerciseView       `json:"exercises,omitempty"`
+	Quiz          *study.QuizView            `json:"quiz,omitempty"`
+	Preview       bool                       `json:"preview,omitempty"`
+}
+
+// CreateLessonHandler handles POST /api/lesson. The caller becomes the lesson's owner.
+// Mentors may create lessons but not publish or schedule them.
+// Requires RequireLessonAuthor middleware.
 func (h *Handlers) CreateLessonHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, span := tracing.StartSpan(r.Context(), "handler.CreateLesson")
 	defer span.End()
@@ -147,21 +357,40 @@ func (h *Handlers) CreateLessonHandler(w http.ResponseWriter, r *http.Request) {
 	parseSpan.SetAttributes(tracing.AttrLessonSlug.String(req.Slug))
 	parseSpan.End()
 
+	editor := lessonEditor(ctx)
+	if !editor.IsAdmin && (req.IsPublished || req.PublishAt != nil || req.UnpublishAt != nil) {
+		httputil.WriteError(w, http.StatusForbidden, study.ErrPublishRequiresReview.Error())
+		return
+	}
+
 	newLesson := &study.Lesson{
 		Slug:        req.Slug,
 		Title:       req.Title,
 		Markdown:    req.Markdown,
 		Excalidraw:  req.Excalidraw,
 		IsPublished: req.IsPublished,
+		PublishAt:   req.PublishAt,
+		UnpublishAt: req.UnpublishAt,
+		Category:    req.Category,
+		Tags:        req.Tags,
+
+		RequiredRole:         req.RequiredRole,
+		RequirePrerequisites: req.RequirePrerequisites,
+		OwnerID:              editor.UserID,
 	}
-	
-	created, err := h.studySvc.CreateLesson(ctx, newLesson)
+
+	created, err := h.studySvc.CreateLesson(ctx, newLesson, revisionAuthor(ctx))
 	if err != nil {
 		tracing.RecordError(span, err)
 		if errors.Is(err, gorm.ErrDuplicatedKey) {
 			httputil.WriteError(w, http.StatusConflict, "slug already exists")
 			return
 		}
+		if errors.Is(err, study.ErrInvalidSlug) || errors.Is(err, study.ErrInvalidSchedule) ||
+			errors.Is(err, study.ErrInvalidExcalidraw) || errors.Is(err, study.ErrInvalidRequiredRole) {
+			httputil.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		httputil.WriteError(w, http.StatusInternalServerError, "failed to create lesson")
 		return
 	}
@@ -171,7 +400,9 @@ func (h *Handlers) CreateLessonHandler(w http.ResponseWriter, r *http.Request) {
 	jsonSpan.End()
 }
 
-// UpdateLessonHandler handles PATCH /api/lessons/{slug}. Requires AdminOnly middleware.
+// UpdateLessonHandler handles PATCH /api/lessons/{slug}. Mentors may not change the
+// published state or schedule, and may only change lessons that pass study.CheckLessonEditable.
+// Requires RequireLessonAuthor middleware.
 func (h *Handlers) UpdateLessonHandler(w http.ResponseWriter, r *http.Request) {
 	if h.studySvc == nil {
 		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
@@ -199,30 +430,92 @@ func (h *Handlers) UpdateLessonHandler(w http.ResponseWriter, r *http.Request) {
 	if len(req.Excalidraw) > 0 {
 		updates["excalidraw"] = req.Excalidraw
 	}
+	if req.Slug != nil {
+		updates["slug"] = strings.TrimSpace(*req.Slug)
+	}
 	if req.IsPublished != nil {
 		updates["is_published"] = *req.IsPublished
 	}
+	if req.PublishAt.Set {
+		updates["publish_at"] = req.PublishAt.Value
+	}
+	if req.UnpublishAt.Set {
+		updates["unpublish_at"] = req.UnpublishAt.Value
+	}
+	if req.Category != nil {
+		updates["category"] = strings.TrimSpace(*req.Category)
+	}
+	if req.Tags != nil {
+		updates["tags"] = datatypes.JSONSlice[string](study.NormalizeTags(*req.Tags))
+	}
+	if req.RequiredRole != nil {
+		updates["required_role"] = *req.RequiredRole
+	}
+	if req.RequirePrerequisites != nil {
+		updates["require_prerequisites"] = *req.RequirePrerequisites
+	}
 
 	if len(updates) == 0 {
 		httputil.WriteError(w, http.StatusBadRequest, "no fields to update")
 		return
 	}
+	if !isAdminUser(r.Context()) && (req.IsPublished != nil || req.PublishAt.Set || req.UnpublishAt.Set) {
+		httputil.WriteError(w, http.StatusForbidden, study.ErrPublishRequiresReview.Error())
+		return
+	}
 
-	if err := h.studySvc.UpdateLessonBySlug(r.Context(), slug, updates); err != nil {
+	if err := h.studySvc.UpdateLessonBySlug(r.Context(), slug, updates, lessonEditor(r.Context()), revisionAuthor(r.Context())); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			httputil.WriteError(w, http.StatusNotFound, "lesson not found")
 			return
 		}
-		httputil.WriteError(w, http.StatusInternalServerError, "failed to update lesson")
+		if errors.Is(err, study.ErrInvalidSchedule) || errors.Is(err, study.ErrInvalidSlug) ||
+			errors.Is(err, study.ErrInvalidExcalidraw) || errors.Is(err, study.ErrInvalidRequiredRole) {
+			httputil.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, study.ErrSlugTaken) || errors.Is(err, gorm.ErrDuplicatedKey) {
+			httputil.WriteError(w, http.StatusConflict, "slug already exists")
+			return
+		}
+		writeReviewError(w, err, "failed to update lesson")
 		return
 	}
 
+	if newSlug, ok := updates["slug"].(string); ok {
+		slug = newSlug
+	}
 	httputil.WriteJSON(w, http.StatusOK, study.UpdateLessonResponse{
 		Slug:    slug,
 		Updated: true,
 	})
 }
 
+// redirectRenamedLesson answers a request for a former slug with a 301 to the lesson's
+// current slug, or a 404 when the slug was never used (or the lesson is hidden from the caller).
+func (h *Handlers) redirectRenamedLesson(w http.ResponseWriter, r *http.Request, oldSlug string) {
+	lesson, err := h.studySvc.ResolveSlugRedirect(r.Context(), oldSlug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			httputil.WriteError(w, http.StatusNotFound, "lesson not found")
+			return
+		}
+		httputil.WriteError(w, http.StatusInternalServerError, "failed to load lesson")
+		return
+	}
+	if !lesson.IsPublishedAt(time.Now()) && !isAdminUser(r.Context()) {
+		httputil.WriteError(w, http.StatusNotFound, "lesson not found")
+		return
+	}
+
+	// Keep the mount prefix (/api or /api/v1) and query string of the original request
+	location := *r.URL
+	location.Path = path.Join(path.Dir(r.URL.Path), lesson.Slug)
+	location.RawPath = ""
+	w.Header().Set("Location", location.RequestURI())
+	httputil.WriteJSON(w, http.StatusMovedPermanently, map[string]string{"slug": lesson.Slug})
+}
+
 // DeleteLessonHandler handles DELETE /api/lessons/{slug}. Requires AdminOnly middleware.
 func (h *Handlers) DeleteLessonHandler(w http.ResponseWriter, r *http.Request) {
 	if h.studySvc == nil {
@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"gorm.io/gorm"
@@ -18,28 +21,421 @@ import (
 
 // MockStudyService for testing
 type MockStudyService struct {
-	ListPublishedLessonsFunc func(ctx context.Context) ([]study.Lesson, error)
-	ListAllLessonsFunc       func(ctx context.Context) ([]study.Lesson, error)
-	GetLessonBySlugFunc      func(ctx context.Context, slug string) (*study.Lesson, error)
-	CreateLessonFunc         func(ctx context.Context, lesson *study.Lesson) (*study.Lesson, error)
-	UpdateLessonBySlugFunc   func(ctx context.Context, slug string, updates map[string]any) error
-	DeleteLessonBySlugFunc   func(ctx context.Context, slug string) error
+	ListLessonSummariesFunc    func(ctx context.Context, query study.ListLessonsQuery) (*study.LessonPage, error)
+	GetLessonBySlugFunc        func(ctx context.Context, slug string) (*study.Lesson, error)
+	LessonModifiedAtFunc       func(ctx context.Context, lesson *study.Lesson, userID *uint) (time.Time, error)
+	CreateLessonFunc           func(ctx context.Context, lesson *study.Lesson, author study.RevisionAuthor) (*study.Lesson, error)
+	UpdateLessonBySlugFunc     func(ctx context.Context, slug string, updates map[string]any, editor study.LessonEditor, author study.RevisionAuthor) error
+	ResolveSlugRedirectFunc    func(ctx context.Context, oldSlug string) (*study.Lesson, error)
+	DeleteLessonBySlugFunc     func(ctx context.Context, slug string) error
+	ListLessonRevisionsFunc    func(ctx context.Context, slug string) ([]study.LessonRevisionSummary, error)
+	GetLessonRevisionFunc      func(ctx context.Context, slug string, revisionID uint) (*study.LessonRevision, error)
+	DiffLessonRevisionsFunc    func(ctx context.Context, slug string, fromID, toID uint) (*study.RevisionDiff, error)
+	RestoreLessonRevisionFunc  func(ctx context.Context, slug string, revisionID uint, author study.RevisionAuthor) (*study.Lesson, error)
+	SearchLessonsFunc          func(ctx context.Context, query string, viewer study.LessonViewer, includeUnpublished bool, limit int) ([]study.LessonSearchResult, error)
+	ListTracksFunc             func(ctx context.Context, includeUnpublished bool) ([]study.Track, error)
+	GetTrackFunc               func(ctx context.Context, slug string, includeUnpublished bool) (*study.TrackDetail, error)
+	CreateTrackFunc            func(ctx context.Context, req *study.CreateTrackRequest) (*study.TrackDetail, error)
+	UpdateTrackFunc            func(ctx context.Context, slug string, req *study.UpdateTrackRequest) (*study.Track, error)
+	SetTrackLessonsFunc        func(ctx context.Context, slug string, lessonSlugs []string) (*study.TrackDetail, error)
+	DeleteTrackFunc            func(ctx context.Context, slug string) error
+	GetLessonProgressFunc      func(ctx context.Context, userID, lessonID uint) (*study.LessonProgress, error)
+	UpdateLessonProgressFunc   func(ctx context.Context, userID uint, slug string, req *study.UpdateProgressRequest, viewer study.LessonViewer) (*study.LessonProgress, error)
+	GetProgressSummaryFunc     func(ctx context.Context, userID uint) (*study.ProgressSummary, error)
+	ListBookmarksFunc          func(ctx context.Context, userID uint, includeUnpublished bool) ([]study.BookmarkEntry, error)
+	AddBookmarkFunc            func(ctx context.Context, userID uint, slug string, includeUnpublished bool) error
+	RemoveBookmarkFunc         func(ctx context.Context, userID uint, slug string) error
+	ListNotesFunc              func(ctx context.Context, userID uint, lessonSlug string) ([]study.LessonNote, error)
+	GetNoteFunc                func(ctx context.Context, userID, noteID uint) (*study.LessonNote, error)
+	CreateNoteFunc             func(ctx context.Context, userID uint, req *study.CreateNoteRequest, includeUnpublished bool) (*study.LessonNote, error)
+	UpdateNoteFunc             func(ctx context.Context, userID, noteID uint, req *study.UpdateNoteRequest) (*study.LessonNote, error)
+	DeleteNoteFunc             func(ctx context.Context, userID, noteID uint) error
+	ListCommentsFunc           func(ctx context.Context, slug string, viewer study.LessonViewer, moderator bool) ([]*study.CommentNode, error)
+	CreateCommentFunc          func(ctx context.Context, slug string, userID uint, req *study.CreateCommentRequest, viewer study.LessonViewer) (*study.Comment, error)
+	UpdateCommentFunc          func(ctx context.Context, slug string, commentID, userID uint, req *study.UpdateCommentRequest) (*study.Comment, error)
+	DeleteCommentFunc          func(ctx context.Context, slug string, commentID, userID uint, moderator bool) error
+	ModerateCommentFunc        func(ctx context.Context, slug string, commentID uint, action study.ModerationAction) (*study.Comment, error)
+	ExportLessonsFunc          func(ctx context.Context, format study.BundleFormat) ([]byte, error)
+	ImportLessonsFunc          func(ctx context.Context, data []byte, opts study.ImportOptions, author study.RevisionAuthor) (*study.ImportReport, error)
+	UploadAssetFunc            func(ctx context.Context, filename string, content []byte, uploadedBy *uint) (*study.Asset, bool, error)
+	ListAssetsFunc             func(ctx context.Context) ([]study.Asset, error)
+	OpenAssetFunc              func(ctx context.Context, key string) (*study.Asset, io.ReadCloser, error)
+	DeleteAssetFunc            func(ctx context.Context, key string, force bool) error
+	ListExercisesFunc          func(ctx context.Context, slug string) ([]study.Exercise, error)
+	SetExercisesFunc           func(ctx context.Context, slug string, req *study.SetExercisesRequest, editor study.LessonEditor) ([]study.Exercise, error)
+	GetExerciseViewsFunc       func(ctx context.Context, lessonID uint, userID *uint) ([]study.ExerciseView, error)
+	GetQuizFunc                func(ctx context.Context, slug string) (*study.Quiz, error)
+	SetQuizFunc                func(ctx context.Context, slug string, req *study.SetQuizRequest, editor study.LessonEditor) (*study.Quiz, error)
+	DeleteQuizFunc             func(ctx context.Context, slug string, editor study.LessonEditor) error
+	GetQuizViewFunc            func(ctx context.Context, lessonID uint, userID *uint) (*study.QuizView, error)
+	SubmitQuizFunc             func(ctx context.Context, slug string, userID uint, req *study.SubmitQuizRequest, viewer study.LessonViewer) (*study.QuizAttemptResult, error)
+	ListQuizAttemptsFunc       func(ctx context.Context, slug string, userID uint, viewer study.LessonViewer) ([]study.QuizAttempt, error)
+	RenderLessonFunc           func(ctx context.Context, lesson *study.Lesson) (*study.RenderedLesson, error)
+	GetLessonPrerequisitesFunc func(ctx context.Context, lessonID uint, userID *uint, includeUnpublished bool) ([]study.PrerequisiteStatus, error)
+	SetLessonPrerequisitesFunc func(ctx context.Context, slug string, lessonSlugs []string, editor study.LessonEditor) ([]study.PrerequisiteStatus, error)
+	CreateLessonPreviewFunc    func(ctx context.Context, slug string, req *study.CreatePreviewRequest, author study.RevisionAuthor) (*study.PreviewLink, error)
+	ListLessonPreviewsFunc     func(ctx context.Context, slug string) ([]study.LessonPreview, error)
+	RevokeLessonPreviewFunc    func(ctx context.Context, slug string, previewID uint) error
+	CheckLessonPreviewFunc     func(ctx context.Context, lesson *study.Lesson, token string) error
+	SubmitLessonForReviewFunc  func(ctx context.Context, slug string) (*study.Lesson, error)
+	ReviewLessonFunc           func(ctx context.Context, slug string, req *study.ReviewLessonRequest, reviewer study.RevisionAuthor) (*study.Lesson, error)
+	SubmitExerciseFunc         func(ctx context.Context, slug, key string, userID uint, code string, viewer study.LessonViewer) (*study.ExerciseSubmission, error)
 }
 
-func (m *MockStudyService) ListPublishedLessons(ctx context.Context) ([]study.Lesson, error) {
-	if m.ListPublishedLessonsFunc != nil {
-		return m.ListPublishedLessonsFunc(ctx)
+func (m *MockStudyService) ListLessonSummaries(ctx context.Context, query study.ListLessonsQuery) (*study.LessonPage, error) {
+	if m.ListLessonSummariesFunc != nil {
+		return m.ListLessonSummariesFunc(ctx, query)
+	}
+	return &study.LessonPage{}, nil
+}
+
+func (m *MockStudyService) ListTracks(ctx context.Context, includeUnpublished bool) ([]study.Track, error) {
+	if m.ListTracksFunc != nil {
+		return m.ListTracksFunc(ctx, includeUnpublished)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) GetTrack(ctx context.Context, slug string, includeUnpublished bool) (*study.TrackDetail, error) {
+	if m.GetTrackFunc != nil {
+		return m.GetTrackFunc(ctx, slug, includeUnpublished)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) CreateTrack(ctx context.Context, req *study.CreateTrackRequest) (*study.TrackDetail, error) {
+	if m.CreateTrackFunc != nil {
+		return m.CreateTrackFunc(ctx, req)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) UpdateTrack(ctx context.Context, slug string, req *study.UpdateTrackRequest) (*study.Track, error) {
+	if m.UpdateTrackFunc != nil {
+		return m.UpdateTrackFunc(ctx, slug, req)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) SetTrackLessons(ctx context.Context, slug string, lessonSlugs []string) (*study.TrackDetail, error) {
+	if m.SetTrackLessonsFunc != nil {
+		return m.SetTrackLessonsFunc(ctx, slug, lessonSlugs)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) DeleteTrack(ctx context.Context, slug string) error {
+	if m.DeleteTrackFunc != nil {
+		return m.DeleteTrackFunc(ctx, slug)
+	}
+	return nil
+}
+
+func (m *MockStudyService) GetLessonProgress(ctx context.Context, userID, lessonID uint) (*study.LessonProgress, error) {
+	if m.GetLessonProgressFunc != nil {
+		return m.GetLessonProgressFunc(ctx, userID, lessonID)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) UpdateLessonProgress(ctx context.Context, userID uint, slug string, req *study.UpdateProgressRequest, viewer study.LessonViewer) (*study.LessonProgress, error) {
+	if m.UpdateLessonProgressFunc != nil {
+		return m.UpdateLessonProgressFunc(ctx, userID, slug, req, viewer)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) GetProgressSummary(ctx context.Context, userID uint) (*study.ProgressSummary, error) {
+	if m.GetProgressSummaryFunc != nil {
+		return m.GetProgressSummaryFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) ListBookmarks(ctx context.Context, userID uint, includeUnpublished bool) ([]study.BookmarkEntry, error) {
+	if m.ListBookmarksFunc != nil {
+		return m.ListBookmarksFunc(ctx, userID, includeUnpublished)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) AddBookmark(ctx context.Context, userID uint, slug string, includeUnpublished bool) error {
+	if m.AddBookmarkFunc != nil {
+		return m.AddBookmarkFunc(ctx, userID, slug, includeUnpublished)
+	}
+	return nil
+}
+
+func (m *MockStudyService) RemoveBookmark(ctx context.Context, userID uint, slug string) error {
+	if m.RemoveBookmarkFunc != nil {
+		return m.RemoveBookmarkFunc(ctx, userID, slug)
+	}
+	return nil
+}
+
+func (m *MockStudyService) ListNotes(ctx context.Context, userID uint, lessonSlug string) ([]study.LessonNote, error) {
+	if m.ListNotesFunc != nil {
+		return m.ListNotesFunc(ctx, userID, lessonSlug)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) GetNote(ctx context.Context, userID, noteID uint) (*study.LessonNote, error) {
+	if m.GetNoteFunc != nil {
+		return m.GetNoteFunc(ctx, userID, noteID)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) CreateNote(ctx context.Context, userID uint, req *study.CreateNoteRequest, includeUnpublished bool) (*study.LessonNote, error) {
+	if m.CreateNoteFunc != nil {
+		return m.CreateNoteFunc(ctx, userID, req, includeUnpublished)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) UpdateNote(ctx context.Context, userID, noteID uint, req *study.UpdateNoteRequest) (*study.LessonNote, error) {
+	if m.UpdateNoteFunc != nil {
+		return m.UpdateNoteFunc(ctx, userID, noteID, req)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) DeleteNote(ctx context.Context, userID, noteID uint) error {
+	if m.DeleteNoteFunc != nil {
+		return m.DeleteNoteFunc(ctx, userID, noteID)
+	}
+	return nil
+}
+
+func (m *MockStudyService) ListComments(ctx context.Context, slug string, viewer study.LessonViewer, moderator bool) ([]*study.CommentNode, error) {
+	if m.ListCommentsFunc != nil {
+		return m.ListCommentsFunc(ctx, slug, viewer, moderator)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) CreateComment(ctx context.Context, slug string, userID uint, req *study.CreateCommentRequest, viewer study.LessonViewer) (*study.Comment, error) {
+	if m.CreateCommentFunc != nil {
+		return m.CreateCommentFunc(ctx, slug, userID, req, viewer)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) UpdateComment(ctx context.Context, slug string, commentID, userID uint, req *study.UpdateCommentRequest) (*study.Comment, error) {
+	if m.UpdateCommentFunc != nil {
+		return m.UpdateCommentFunc(ctx, slug, commentID, userID, req)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) DeleteComment(ctx context.Context, slug string, commentID, userID uint, moderator bool) error {
+	if m.DeleteCommentFunc != nil {
+		return m.DeleteCommentFunc(ctx, slug, commentID, userID, moderator)
+	}
+	return nil
+}
+
+func (m *MockStudyService) ModerateComment(ctx context.Context, slug string, commentID uint, action study.ModerationAction) (*study.Comment, error) {
+	if m.ModerateCommentFunc != nil {
+		return m.ModerateCommentFunc(ctx, slug, commentID, action)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) ExportLessons(ctx context.Context, format study.BundleFormat) ([]byte, error) {
+	if m.ExportLessonsFunc != nil {
+		return m.ExportLessonsFunc(ctx, format)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) ImportLessons(ctx context.Context, data []byte, opts study.ImportOptions, author study.RevisionAuthor) (*study.ImportReport, error) {
+	if m.ImportLessonsFunc != nil {
+		return m.ImportLessonsFunc(ctx, data, opts, author)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) UploadAsset(ctx context.Context, filename string, content []byte, uploadedBy *uint) (*study.Asset, bool, error) {
+	if m.UploadAssetFunc != nil {
+		return m.UploadAssetFunc(ctx, filename, content, uploadedBy)
+	}
+	return nil, false, nil
+}
+
+func (m *MockStudyService) ListAssets(ctx context.Context) ([]study.Asset, error) {
+	if m.ListAssetsFunc != nil {
+		return m.ListAssetsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) OpenAsset(ctx context.Context, key string) (*study.Asset, io.ReadCloser, error) {
+	if m.OpenAssetFunc != nil {
+		return m.OpenAssetFunc(ctx, key)
+	}
+	return nil, nil, study.ErrAssetNotFound
+}
+
+func (m *MockStudyService) DeleteAsset(ctx context.Context, key string, force bool) error {
+	if m.DeleteAssetFunc != nil {
+		return m.DeleteAssetFunc(ctx, key, force)
+	}
+	return nil
+}
+
+func (m *MockStudyService) ResolveSlugRedirect(ctx context.Context, oldSlug string) (*study.Lesson, error) {
+	if m.ResolveSlugRedirectFunc != nil {
+		return m.ResolveSlugRedirectFunc(ctx, oldSlug)
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockStudyService) ListExercises(ctx context.Context, slug string) ([]study.Exercise, error) {
+	if m.ListExercisesFunc != nil {
+		return m.ListExercisesFunc(ctx, slug)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) SetExercises(ctx context.Context, slug string, req *study.SetExercisesRequest, editor study.LessonEditor) ([]study.Exercise, error) {
+	if m.SetExercisesFunc != nil {
+		return m.SetExercisesFunc(ctx, slug, req, editor)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) GetExerciseViews(ctx context.Context, lessonID uint, userID *uint) ([]study.ExerciseView, error) {
+	if m.GetExerciseViewsFunc != nil {
+		return m.GetExerciseViewsFunc(ctx, lessonID, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) SubmitExercise(ctx context.Context, slug, key string, userID uint, code string, viewer study.LessonViewer) (*study.ExerciseSubmission, error) {
+	if m.SubmitExerciseFunc != nil {
+		return m.SubmitExerciseFunc(ctx, slug, key, userID, code, viewer)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) GetQuiz(ctx context.Context, slug string) (*study.Quiz, error) {
+	if m.GetQuizFunc != nil {
+		return m.GetQuizFunc(ctx, slug)
+	}
+	return nil, study.ErrQuizNotFound
+}
+
+func (m *MockStudyService) SetQuiz(ctx context.Context, slug string, req *study.SetQuizRequest, editor study.LessonEditor) (*study.Quiz, error) {
+	if m.SetQuizFunc != nil {
+		return m.SetQuizFunc(ctx, slug, req, editor)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) DeleteQuiz(ctx context.Context, slug string, editor study.LessonEditor) error {
+	if m.DeleteQuizFunc != nil {
+		return m.DeleteQuizFunc(ctx, slug, editor)
+	}
+	return nil
+}
+
+func (m *MockStudyService) GetQuizView(ctx context.Context, lessonID uint, userID *uint) (*study.QuizView, error) {
+	if m.GetQuizViewFunc != nil {
+		return m.GetQuizViewFunc(ctx, lessonID, userID)
 	}
 	return nil, nil
 }
 
-func (m *MockStudyService) ListAllLessons(ctx context.Context) ([]study.Lesson, error) {
-	if m.ListAllLessonsFunc != nil {
-		return m.ListAllLessonsFunc(ctx)
+func (m *MockStudyService) SubmitQuiz(ctx context.Context, slug string, userID uint, req *study.SubmitQuizRequest, viewer study.LessonViewer) (*study.QuizAttemptResult, error) {
+	if m.SubmitQuizFunc != nil {
+		return m.SubmitQuizFunc(ctx, slug, userID, req, viewer)
 	}
 	return nil, nil
 }
 
+func (m *MockStudyService) ListQuizAttempts(ctx context.Context, slug string, userID uint, viewer study.LessonViewer) ([]study.QuizAttempt, error) {
+	if m.ListQuizAttemptsFunc != nil {
+		return m.ListQuizAttemptsFunc(ctx, slug, userID, viewer)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) RenderLesson(ctx context.Context, lesson *study.Lesson) (*study.RenderedLesson, error) {
+	if m.RenderLessonFunc != nil {
+		return m.RenderLessonFunc(ctx, lesson)
+	}
+	return study.RenderMarkdown(lesson.Markdown)
+}
+
+func (m *MockStudyService) GetLessonPrerequisites(ctx context.Context, lessonID uint, userID *uint, includeUnpublished bool) ([]study.PrerequisiteStatus, error) {
+	if m.GetLessonPrerequisitesFunc != nil {
+		return m.GetLessonPrerequisitesFunc(ctx, lessonID, userID, includeUnpublished)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) SetLessonPrerequisites(ctx context.Context, slug string, lessonSlugs []string, editor study.LessonEditor) ([]study.PrerequisiteStatus, error) {
+	if m.SetLessonPrerequisitesFunc != nil {
+		return m.SetLessonPrerequisitesFunc(ctx, slug, lessonSlugs, editor)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) CreateLessonPreview(ctx context.Context, slug string, req *study.CreatePreviewRequest, author study.RevisionAuthor) (*study.PreviewLink, error) {
+	if m.CreateLessonPreviewFunc != nil {
+		return m.CreateLessonPreviewFunc(ctx, slug, req, author)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockStudyService) ListLessonPreviews(ctx context.Context, slug string) ([]study.LessonPreview, error) {
+	if m.ListLessonPreviewsFunc != nil {
+		return m.ListLessonPreviewsFunc(ctx, slug)
+	}
+	return []study.LessonPreview{}, nil
+}
+
+func (m *MockStudyService) RevokeLessonPreview(ctx context.Context, slug string, previewID uint) error {
+	if m.RevokeLessonPreviewFunc != nil {
+		return m.RevokeLessonPreviewFunc(ctx, slug, previewID)
+	}
+	return nil
+}
+
+func (m *MockStudyService) CheckLessonPreview(ctx context.Context, lesson *study.Lesson, token string) error {
+	if m.CheckLessonPreviewFunc != nil {
+		return m.CheckLessonPreviewFunc(ctx, lesson, token)
+	}
+	return study.ErrInvalidPreview
+}
+
+func (m *MockStudyService) SubmitLessonForReview(ctx context.Context, slug string) (*study.Lesson, error) {
+	if m.SubmitLessonForReviewFunc != nil {
+		return m.SubmitLessonForReviewFunc(ctx, slug)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockStudyService) ReviewLesson(ctx context.Context, slug string, req *study.ReviewLessonRequest, reviewer study.RevisionAuthor) (*study.Lesson, error) {
+	if m.ReviewLessonFunc != nil {
+		return m.ReviewLessonFunc(ctx, slug, req, reviewer)
+	}
+	return nil, errors.New("not implemented")
+}
+
+// filterSummaries mimics the published filter applied by study.Service
+func filterSummaries(all []study.LessonSummary, query study.ListLessonsQuery) *study.LessonPage {
+	page := &study.LessonPage{}
+	for _, l := range all {
+		if query.Published == nil || l.IsPublished == *query.Published {
+			page.Items = append(page.Items, l)
+		}
+	}
+	return page
+}
+
 func (m *MockStudyService) GetLessonBySlug(ctx context.Context, slug string) (*study.Lesson, error) {
 	if m.GetLessonBySlugFunc != nil {
 		return m.GetLessonBySlugFunc(ctx, slug)
@@ -47,16 +443,23 @@ func (m *MockStudyService) GetLessonBySlug(ctx context.Context, slug string) (*s
 	return nil, nil
 }
 
-func (m *MockStudyService) CreateLesson(ctx context.Context, lesson *study.Lesson) (*study.Lesson, error) {
+func (m *MockStudyService) LessonModifiedAt(ctx context.Context, lesson *study.Lesson, userID *uint) (time.Time, error) {
+	if m.LessonModifiedAtFunc != nil {
+		return m.LessonModifiedAtFunc(ctx, lesson, userID)
+	}
+	return lesson.UpdatedAt, nil
+}
+
+func (m *MockStudyService) CreateLesson(ctx context.Context, lesson *study.Lesson, author study.RevisionAuthor) (*study.Lesson, error) {
 	if m.CreateLessonFunc != nil {
-		return m.CreateLessonFunc(ctx, lesson)
+		return m.CreateLessonFunc(ctx, lesson, author)
 	}
 	return lesson, nil
 }
 
-func (m *MockStudyService) UpdateLessonBySlug(ctx context.Context, slug string, updates map[string]any) error {
+func (m *MockStudyService) UpdateLessonBySlug(ctx context.Context, slug string, updates map[string]any, editor study.LessonEditor, author study.RevisionAuthor) error {
 	if m.UpdateLessonBySlugFunc != nil {
-		return m.UpdateLessonBySlugFunc(ctx, slug, updates)
+		return m.UpdateLessonBySlugFunc(ctx, slug, updates, editor, author)
 	}
 	return nil
 }
@@ -68,20 +471,55 @@ func (m *MockStudyService) DeleteLessonBySlug(ctx context.Context, slug string)
 	return nil
 }
 
+func (m *MockStudyService) ListLessonRevisions(ctx context.Context, slug string) ([]study.LessonRevisionSummary, error) {
+	if m.ListLessonRevisionsFunc != nil {
+		return m.ListLessonRevisionsFunc(ctx, slug)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) GetLessonRevision(ctx context.Context, slug string, revisionID uint) (*study.LessonRevision, error) {
+	if m.GetLessonRevisionFunc != nil {
+		return m.GetLessonRevisionFunc(ctx, slug, revisionID)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) DiffLessonRevisions(ctx context.Context, slug string, fromID, toID uint) (*study.RevisionDiff, error) {
+	if m.DiffLessonRevisionsFunc != nil {
+		return m.DiffLessonRevisionsFunc(ctx, slug, fromID, toID)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) RestoreLessonRevision(ctx context.Context, slug string, revisionID uint, author study.RevisionAuthor) (*study.Lesson, error) {
+	if m.RestoreLessonRevisionFunc != nil {
+		return m.RestoreLessonRevisionFunc(ctx, slug, revisionID, author)
+	}
+	return nil, nil
+}
+
+func (m *MockStudyService) SearchLessons(ctx context.Context, query string, viewer study.LessonViewer, includeUnpublished bool, limit int) ([]study.LessonSearchResult, error) {
+	if m.SearchLessonsFunc != nil {
+		return m.SearchLessonsFunc(ctx, query, viewer, includeUnpublished, limit)
+	}
+	return nil, nil
+}
+
 // TestListLessons_AsAdmin tests that admin sees all lessons
 func TestListLessons_AsAdmin(t *testing.T) {
-	allLessons := []study.Lesson{
+	allLessons := []study.LessonSummary{
 		{Slug: "lesson-1", IsPublished: true},
 		{Slug: "lesson-2", IsPublished: false}, // unpublished
 	}
 
 	mockStudy := &MockStudyService{
-		ListAllLessonsFunc: func(ctx context.Context) ([]study.Lesson, error) {
-			return allLessons, nil
+		ListLessonSummariesFunc: func(ctx context.Context, query study.ListLessonsQuery) (*study.LessonPage, error) {
+			return filterSummaries(allLessons, query), nil
 		},
 	}
 
-	h := handlers.New(nil, mockStudy, nil, nil, nil)
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/lessons", nil)
 	// Simulate OptionalAdmin middleware setting admin context
@@ -95,7 +533,7 @@ func TestListLessons_AsAdmin(t *testing.T) {
 		t.Errorf("expected status 200, got %d", w.Code)
 	}
 
-	var lessons []study.Lesson
+	var lessons []study.LessonSummary
 	json.NewDecoder(w.Body).Decode(&lessons)
 
 	if len(lessons) != 2 {
@@ -105,19 +543,21 @@ func TestListLessons_AsAdmin(t *testing.T) {
 
 // TestListLessons_AsRegularUser tests that regular users only see published lessons
 func TestListLessons_AsRegularUser(t *testing.T) {
-	publishedLessons := []study.Lesson{
+	allLessons := []study.LessonSummary{
 		{Slug: "lesson-1", IsPublished: true},
+		{Slug: "lesson-2", IsPublished: false}, // unpublished
 	}
 
 	mockStudy := &MockStudyService{
-		ListPublishedLessonsFunc: func(ctx context.Context) ([]study.Lesson, error) {
-			return publishedLessons, nil
+		ListLessonSummariesFunc: func(ctx context.Context, query study.ListLessonsQuery) (*study.LessonPage, error) {
+			return filterSummaries(allLessons, query), nil
 		},
 	}
 
-	h := handlers.New(nil, mockStudy, nil, nil, nil)
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
 
-	req := httptest.NewRequest(http.MethodGet, "/api/lessons", nil)
+	// Regular users cannot lift the published filter
+	req := httptest.NewRequest(http.MethodGet, "/api/lessons?published=false", nil)
 	// No admin flag in context (regular user)
 	w := httptest.NewRecorder()
 
@@ -127,11 +567,11 @@ func TestListLessons_AsRegularUser(t *testing.T) {
 		t.Errorf("expected status 200, got %d", w.Code)
 	}
 
-	var lessons []study.Lesson
+	var lessons []study.LessonSummary
 	json.NewDecoder(w.Body).Decode(&lessons)
 
 	if len(lessons) != 1 {
-		t.Errorf("regular user should see only 1 published lesson, got %d", len(lessons))
+		t.Fatalf("regular user should see only 1 published lesson, got %d", len(lessons))
 	}
 
 	if lessons[0].IsPublished != true {
@@ -139,15 +579,95 @@ func TestListLessons_AsRegularUser(t *testing.T) {
 	}
 }
 
+// TestListLessons_NextCursor tests that the next page cursor is exposed in headers
+func TestListLessons_NextCursor(t *testing.T) {
+	var gotQuery study.ListLessonsQuery
+	mockStudy := &MockStudyService{
+		ListLessonSummariesFunc: func(ctx context.Context, query study.ListLessonsQuery) (*study.LessonPage, error) {
+			gotQuery = query
+			return &study.LessonPage{
+				Items:      []study.LessonSummary{{Slug: "lesson-1", IsPublished: true}},
+				NextCursor: "abc",
+			}, nil
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/lessons?sort=title&order=desc&limit=1", nil)
+	w := httptest.NewRecorder()
+
+	h.ListLessonsHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if gotQuery.Sort != "title" || gotQuery.Order != "desc" || gotQuery.Limit != 1 {
+		t.Errorf("unexpected query %+v", gotQuery)
+	}
+	if got := w.Header().Get("X-Next-Cursor"); got != "abc" {
+		t.Errorf("expected X-Next-Cursor abc, got %q", got)
+	}
+	if got := w.Header().Get("Link"); !strings.Contains(got, "cursor=abc") || !strings.Contains(got, `rel="next"`) {
+		t.Errorf("unexpected Link header %q", got)
+	}
+}
+
+// TestListLessons_PassesViewer tests that the caller is passed on so excerpts of
+// role-gated lessons can be hidden
+func TestListLessons_PassesViewer(t *testing.T) {
+	var gotQuery study.ListLessonsQuery
+	mockStudy := &MockStudyService{
+		ListLessonSummariesFunc: func(ctx context.Context, query study.ListLessonsQuery) (*study.LessonPage, error) {
+			gotQuery = query
+			return &study.LessonPage{Items: []study.LessonSummary{}}, nil
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/lessons", nil)
+	ctx := context.WithValue(req.Context(), "user_id", uint(7))
+	ctx = context.WithValue(ctx, "user_role", "user")
+	w := httptest.NewRecorder()
+	h.ListLessonsHandler(w, req.WithContext(ctx))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if v := gotQuery.Viewer; v.UserID == nil || *v.UserID != 7 || v.Role != "user" || v.IsAdmin {
+		t.Errorf("unexpected viewer %+v", v)
+	}
+}
+
+// TestListLessons_InvalidSort tests that unknown sort fields are rejected
+func TestListLessons_InvalidSort(t *testing.T) {
+	mockStudy := &MockStudyService{
+		ListLessonSummariesFunc: func(ctx context.Context, query study.ListLessonsQuery) (*study.LessonPage, error) {
+			return nil, study.ErrInvalidSort
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/lessons?sort=slug", nil)
+	w := httptest.NewRecorder()
+
+	h.ListLessonsHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
 // TestListLessons_DatabaseError tests error handling
 func TestListLessons_DatabaseError(t *testing.T) {
 	mockStudy := &MockStudyService{
-		ListPublishedLessonsFunc: func(ctx context.Context) ([]study.Lesson, error) {
+		ListLessonSummariesFunc: func(ctx context.Context, query study.ListLessonsQuery) (*study.LessonPage, error) {
 			return nil, errors.New("database connection failed")
 		},
 	}
 
-	h := handlers.New(nil, mockStudy, nil, nil, nil)
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/lessons", nil)
 	w := httptest.NewRecorder()
@@ -176,7 +696,7 @@ func TestGetLessonBySlug_Published(t *testing.T) {
 		},
 	}
 
-	h := handlers.New(nil, mockStudy, nil, nil, nil)
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/lessons/test-lesson", nil)
 
@@ -214,7 +734,7 @@ func TestGetLessonBySlug_UnpublishedAsRegularUser(t *testing.T) {
 		},
 	}
 
-	h := handlers.New(nil, mockStudy, nil, nil, nil)
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/lessons/unpublished", nil)
 
@@ -234,6 +754,193 @@ func TestGetLessonBySlug_UnpublishedAsRegularUser(t *testing.T) {
 	}
 }
 
+// TestGetLessonBySlug_ScheduledPublish tests that a due publishAt makes the lesson visible
+// before the scheduler has flipped isPublished, and a pending one keeps it hidden
+func TestGetLessonBySlug_ScheduledPublish(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+
+	for _, tc := range []struct {
+		publishAt *time.Time
+		want      int
+	}{
+		{&past, http.StatusOK},
+		{&future, http.StatusNotFound},
+	} {
+		mockStudy := &MockStudyService{
+			GetLessonBySlugFunc: func(ctx context.Context, slug string) (*study.Lesson, error) {
+				return &study.Lesson{Slug: "launch", PublishAt: tc.publishAt}, nil
+			},
+		}
+
+		h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/lessons/launch", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("slug", "launch")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		w := httptest.NewRecorder()
+
+		h.GetLessonBySlugHandler(w, req)
+
+		if w.Code != tc.want {
+			t.Errorf("publishAt %v: expected status %d, got %d", tc.publishAt, tc.want, w.Code)
+		}
+	}
+}
+
+// TestGetLessonBySlug_ConditionalRequest tests that the detail is validated by the
+// lesson's modification time and the viewer: Last-Modified is sent, If-Modified-Since
+// and a matching If-None-Match get 304 without building the response, and another
+// viewer or a newer modification gets the full lesson.
+func TestGetLessonBySlug_ConditionalRequest(t *testing.T) {
+	updated := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	modified := updated
+	rendered := 0
+	mockStudy := &MockStudyService{
+		GetLessonBySlugFunc: func(ctx context.Context, slug string) (*study.Lesson, error) {
+			return &study.Lesson{Slug: "graphs", IsPublished: true, UpdatedAt: updated}, nil
+		},
+		LessonModifiedAtFunc: func(ctx context.Context, lesson *study.Lesson, userID *uint) (time.Time, error) {
+			return modified, nil
+		},
+		RenderLessonFunc: func(ctx context.Context, lesson *study.Lesson) (*study.RenderedLesson, error) {
+			rendered++
+			return &study.RenderedLesson{}, nil
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	h.GetLessonBySlugHandler(w, slugRequest(http.MethodGet, "/api/lessons/graphs", "graphs", ""))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	etag := w.Header().Get("ETag")
+	if !strings.HasPrefix(etag, `W/"`) {
+		t.Fatalf("expected a weak ETag, got %q", etag)
+	}
+	if got := w.Header().Get("Last-Modified"); got != updated.Format(http.TimeFormat) {
+		t.Errorf("expected Last-Modified %q, got %q", updated.Format(http.TimeFormat), got)
+	}
+	rendered = 0
+
+	req := slugRequest(http.MethodGet, "/api/lessons/graphs", "graphs", "")
+	req.Header.Set("If-Modified-Since", updated.Format(http.TimeFormat))
+	w = httptest.NewRecorder()
+	h.GetLessonBySlugHandler(w, req)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("expected status 304 for If-Modified-Since, got %d", w.Code)
+	}
+
+	req = slugRequest(http.MethodGet, "/api/lessons/graphs", "graphs", "")
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	h.GetLessonBySlugHandler(w, req)
+	if w.Code != http.StatusNotModified {
+		t.Fatalf("expected status 304, got %d", w.Code)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("expected an empty body, got %q", w.Body.String())
+	}
+	if rendered != 0 {
+		t.Errorf("expected the lesson not to be rendered for a 304, rendered %d times", rendered)
+	}
+
+	req = asMentor(slugRequest(http.MethodGet, "/api/lessons/graphs", "graphs", ""), 7)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	h.GetLessonBySlugHandler(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200 for another viewer, got %d", w.Code)
+	}
+
+	modified = updated.Add(time.Minute)
+	for _, header := range []string{"If-None-Match", "If-Modified-Since"} {
+		req = slugRequest(http.MethodGet, "/api/lessons/graphs", "graphs", "")
+		if header == "If-None-Match" {
+			req.Header.Set(header, etag)
+		} else {
+			req.Header.Set(header, updated.Format(http.TimeFormat))
+		}
+		w = httptest.NewRecorder()
+		h.GetLessonBySlugHandler(w, req)
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected status 200 after a change, got %d", header, w.Code)
+		}
+	}
+}
+
+// TestListLessons_ConditionalRequest tests that the list is validated by its content,
+// so a lesson entering through its schedule (with an older updatedAt) changes the ETag
+func TestListLessons_ConditionalRequest(t *testing.T) {
+	newest := time.Date(2024, 5, 2, 8, 30, 0, 0, time.UTC)
+	items := []study.LessonSummary{{Slug: "b", IsPublished: true, UpdatedAt: newest}}
+	mockStudy := &MockStudyService{
+		ListLessonSummariesFunc: func(ctx context.Context, query study.ListLessonsQuery) (*study.LessonPage, error) {
+			return &study.LessonPage{Items: items}, nil
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	h.ListLessonsHandler(w, httptest.NewRequest(http.MethodGet, "/api/lessons", nil))
+	if got := w.Header().Get("Last-Modified"); got != "" {
+		t.Errorf("expected no Last-Modified, got %q", got)
+	}
+	etag := w.Header().Get("ETag")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/lessons", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	h.ListLessonsHandler(w, req)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("expected status 304, got %d", w.Code)
+	}
+
+	items = append(items, study.LessonSummary{Slug: "a", IsPublished: true, UpdatedAt: newest.Add(-time.Hour)})
+	req = httptest.NewRequest(http.MethodGet, "/api/lessons", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	h.ListLessonsHandler(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200 after a scheduled lesson appeared, got %d", w.Code)
+	}
+}
+
+// TestUpdateLesson_ClearSchedule tests that an explicit null clears a schedule
+func TestUpdateLesson_ClearSchedule(t *testing.T) {
+	var gotUpdates map[string]any
+	mockStudy := &MockStudyService{
+		UpdateLessonBySlugFunc: func(ctx context.Context, slug string, updates map[string]any, editor study.LessonEditor, author study.RevisionAuthor) error {
+			gotUpdates = updates
+			return nil
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/lessons/launch", strings.NewReader(`{"publishAt":null}`))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "launch")
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	req = req.WithContext(context.WithValue(ctx, middleware.IsAdminContextKey, true))
+	w := httptest.NewRecorder()
+
+	h.UpdateLessonHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	value, ok := gotUpdates["publish_at"]
+	if !ok || value.(*time.Time) != nil {
+		t.Errorf("expected publish_at to be cleared, got %v", gotUpdates)
+	}
+	if _, ok := gotUpdates["unpublish_at"]; ok {
+		t.Error("omitted unpublishAt should not be updated")
+	}
+}
+
 // TestGetLessonBySlug_UnpublishedAsAdmin tests admin can access unpublished
 func TestGetLessonBySlug_UnpublishedAsAdmin(t *testing.T) {
 	lesson := &study.Lesson{
@@ -247,7 +954,7 @@ func TestGetLessonBySlug_UnpublishedAsAdmin(t *testing.T) {
 		},
 	}
 
-	h := handlers.New(nil, mockStudy, nil, nil, nil)
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/lessons/unpublished", nil)
 
@@ -277,7 +984,7 @@ func TestGetLessonBySlug_NotFound(t *testing.T) {
 		},
 	}
 
-	h := handlers.New(nil, mockStudy, nil, nil, nil)
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/lessons/nonexistent", nil)
 
@@ -294,3 +1001,274 @@ func TestGetLessonBySlug_NotFound(t *testing.T) {
 		t.Errorf("expected status 404, got %d", w.Code)
 	}
 }
+
+// TestSearchLessons_Visibility tests that only admins search unpublished lessons
+func TestSearchLessons_Visibility(t *testing.T) {
+	var gotUnpublished bool
+	mockStudy := &MockStudyService{
+		SearchLessonsFunc: func(ctx context.Context, query string, viewer study.LessonViewer, includeUnpublished bool, limit int) ([]study.LessonSearchResult, error) {
+			gotUnpublished = includeUnpublished
+			return []study.LessonSearchResult{{Slug: "intro", Snippet: "<mark>graph</mark> basics"}}, nil
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/lessons/search?q=graph", nil)
+	w := httptest.NewRecorder()
+	h.SearchLessonsHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if gotUnpublished {
+		t.Error("regular user should not search unpublished lessons")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/lessons/search?q=graph", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "user_role", "admin"))
+	w = httptest.NewRecorder()
+	h.SearchLessonsHandler(w, req)
+
+	if !gotUnpublished {
+		t.Error("admin should search unpublished lessons")
+	}
+}
+
+// TestSearchLessons_PassesViewer tests that the caller is passed on so snippets of
+// role-gated lessons can be hidden
+func TestSearchLessons_PassesViewer(t *testing.T) {
+	var got study.LessonViewer
+	mockStudy := &MockStudyService{
+		SearchLessonsFunc: func(ctx context.Context, query string, viewer study.LessonViewer, includeUnpublished bool, limit int) ([]study.LessonSearchResult, error) {
+			got = viewer
+			return nil, nil
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/lessons/search?q=graph", nil)
+	ctx := context.WithValue(req.Context(), "user_id", uint(7))
+	ctx = context.WithValue(ctx, "user_role", "mentor")
+	w := httptest.NewRecorder()
+	h.SearchLessonsHandler(w, req.WithContext(ctx))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if got.UserID == nil || *got.UserID != 7 || got.Role != "mentor" || got.IsAdmin {
+		t.Errorf("unexpected viewer %+v", got)
+	}
+}
+
+// TestSearchLessons_EmptyQuery tests that a blank query is rejected
+func TestSearchLessons_EmptyQuery(t *testing.T) {
+	mockStudy := &MockStudyService{
+		SearchLessonsFunc: func(ctx context.Context, query string, viewer study.LessonViewer, includeUnpublished bool, limit int) ([]study.LessonSearchResult, error) {
+			return nil, study.ErrSearchQueryRequired
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/lessons/search?q=", nil)
+	w := httptest.NewRecorder()
+	h.SearchLessonsHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+// TestGetLessonBySlug_IncludesProgress tests that signed-in users get their progress with the lesson
+func TestGetLessonBySlug_IncludesProgress(t *testing.T) {
+	mockStudy := &MockStudyService{
+		GetLessonBySlugFunc: func(ctx context.Context, slug string) (*study.Lesson, error) {
+			return &study.Lesson{ID: 5, Slug: slug, IsPublished: true}, nil
+		},
+		GetLessonProgressFunc: func(ctx context.Context, userID, lessonID uint) (*study.LessonProgress, error) {
+			if userID != 9 || lessonID != 5 {
+				t.Errorf("unexpected progress lookup user=%d lesson=%d", userID, lessonID)
+			}
+			return &study.LessonProgress{LessonID: lessonID, Status: study.ProgressCompleted}, nil
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/lessons/test-lesson", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "test-lesson")
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	ctx = context.WithValue(ctx, "user_id", uint(9))
+	req = req.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	h.GetLessonBySlugHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var result struct {
+		Slug     string                `json:"slug"`
+		Progress *study.LessonProgress `json:"progress"`
+	}
+	json.NewDecoder(w.Body).Decode(&result)
+
+	if result.Slug != "test-lesson" {
+		t.Errorf("expected slug 'test-lesson', got '%s'", result.Slug)
+	}
+	if result.Progress == nil || result.Progress.Status != study.ProgressCompleted {
+		t.Errorf("expected completed progress, got %+v", result.Progress)
+	}
+}
+
+// TestGetLessonBySlug_AnonymousHasNoProgress tests that anonymous responses omit progress
+func TestGetLessonBySlug_AnonymousHasNoProgress(t *testing.T) {
+	mockStudy := &MockStudyService{
+		GetLessonBySlugFunc: func(ctx context.Context, slug string) (*study.Lesson, error) {
+			return &study.Lesson{ID: 5, Slug: slug, IsPublished: true}, nil
+		},
+		GetLessonProgressFunc: func(ctx context.Context, userID, lessonID uint) (*study.LessonProgress, error) {
+			t.Error("progress should not be looked up for anonymous users")
+			return nil, nil
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/lessons/test-lesson", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "test-lesson")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	h.GetLessonBySlugHandler(w, req)
+
+	if strings.Contains(w.Body.String(), `"progress"`) {
+		t.Errorf("anonymous response should not include progress: %s", w.Body.String())
+	}
+}
+
+// TestUpdateLessonProgress_RequiresAuth tests that progress can only be saved by signed-in users
+func TestUpdateLessonProgress_RequiresAuth(t *testing.T) {
+	h := handlers.New(nil, &MockStudyService{}, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/me/progress/test-lesson", strings.NewReader(`{"status":"completed"}`))
+	w := httptest.NewRecorder()
+
+	h.UpdateLessonProgressHandler(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", w.Code)
+	}
+}
+
+// TestUpdateLessonProgress_InvalidStatus tests validation errors map to 400
+func TestUpdateLessonProgress_InvalidStatus(t *testing.T) {
+	mockStudy := &MockStudyService{
+		UpdateLessonProgressFunc: func(ctx context.Context, userID uint, slug string, req *study.UpdateProgressRequest, viewer study.LessonViewer) (*study.LessonProgress, error) {
+			return nil, study.ErrInvalidProgressStatus
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/me/progress/test-lesson", strings.NewReader(`{"status":"skimmed"}`))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "test-lesson")
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	ctx = context.WithValue(ctx, "user_id", uint(9))
+	req = req.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	h.UpdateLessonProgressHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+// TestGetLessonBySlugHandler_RendersHTML tests that the lesson response carries sanitized HTML and a TOC
+func TestGetLessonBySlugHandler_RendersHTML(t *testing.T) {
+	mockStudy := &MockStudyService{
+		GetLessonBySlugFunc: func(ctx context.Context, slug string) (*study.Lesson, error) {
+			return &study.Lesson{ID: 1, Slug: slug, IsPublished: true, Markdown: "# Intro\n\n<script>x()</script>"}, nil
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/lessons/intro", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "intro")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+	h.GetLessonBySlugHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	var resp struct {
+		Markdown string           `json:"markdown"`
+		HTML     string           `json:"html"`
+		TOC      []study.TOCEntry `json:"toc"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Markdown == "" || !strings.Contains(resp.HTML, `id="intro"`) || strings.Contains(resp.HTML, "<script") {
+		t.Errorf("unexpected rendering: %+v", resp)
+	}
+	if len(resp.TOC) != 1 || resp.TOC[0].ID != "intro" {
+		t.Errorf("unexpected TOC %+v", resp.TOC)
+	}
+}
+
+// TestCreateLessonHandler_InvalidSlug tests that malformed slugs are reported as 400
+func TestCreateLessonHandler_InvalidSlug(t *testing.T) {
+	mockStudy := &MockStudyService{
+		CreateLessonFunc: func(ctx context.Context, lesson *study.Lesson, author study.RevisionAuthor) (*study.Lesson, error) {
+			if err := study.ValidateSlug(lesson.Slug); err != nil {
+				return nil, err
+			}
+			return lesson, nil
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	body := `{"slug":"Intro Graphs","title":"Intro","markdown":"x"}`
+	w := httptest.NewRecorder()
+	h.CreateLessonHandler(w, httptest.NewRequest(http.MethodPost, "/api/lessons", strings.NewReader(body)))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), study.ErrInvalidSlug.Error()) {
+		t.Errorf("expected the slug error, got %s", w.Body.String())
+	}
+}
+
+// TestCreateLessonHandler_InvalidExcalidraw tests that scene validation errors are reported as 400
+func TestCreateLessonHandler_InvalidExcalidraw(t *testing.T) {
+	mockStudy := &MockStudyService{
+		CreateLessonFunc: func(ctx context.Context, lesson *study.Lesson, author study.RevisionAuthor) (*study.Lesson, error) {
+			if _, err := study.NormalizeExcalidraw(lesson.Excalidraw); err != nil {
+				return nil, err
+			}
+			return lesson, nil
+		},
+	}
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	body := `{"slug":"intro","title":"Intro","markdown":"x","excalidraw":{"elements":[{"type":"rectangle","x":0,"y":0}]}}`
+	w := httptest.NewRecorder()
+	h.CreateLessonHandler(w, httptest.NewRequest(http.MethodPost, "/api/lessons", strings.NewReader(body)))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "elements[0] is missing an id") {
+		t.Errorf("expected a descriptive error, got %s", w.Body.String())
+	}
+}
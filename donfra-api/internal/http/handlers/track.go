@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/pkg/httputil"
+	"donfra-api/internal/pkg/tracing"
+)
+
+// ListTracksHandler handles GET /api/tracks. Admin users also see unpublished tracks.
+// Requires OptionalAuth middleware to set context.
+func (h *Handlers) ListTracksHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.ListTracks")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	tracks, err := h.studySvc.ListTracks(ctx, isAdminUser(ctx))
+	if err != nil {
+		tracing.RecordError(span, err)
+		httputil.WriteError(w, http.StatusInternalServerError, "failed to load tracks")
+		return
+	}
+	if tracks == nil {
+		tracks = []study.Track{}
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, tracks)
+}
+
+// GetTrackHandler handles GET /api/tracks/{slug} and returns the ordered lessons of a track
+// with previous/next navigation. Unpublished tracks and lessons are only visible to admins.
+// Requires OptionalAuth middleware to set context.
+func (h *Handlers) GetTrackHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.GetTrack")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		httputil.WriteError(w, http.StatusBadRequest, "slug is required")
+		return
+	}
+
+	track, err := h.studySvc.GetTrack(ctx, slug, isAdminUser(ctx))
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeTrackError(w, err, "failed to load track")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, track)
+}
+
+// CreateTrackHandler handles POST /api/tracks. Requires AdminOnly middleware.
+func (h *Handlers) CreateTrackHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.CreateTrack")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+
+	var req study.CreateTrackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	track, err := h.studySvc.CreateTrack(ctx, &req)
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeTrackError(w, err, "failed to create track")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusCreated, track)
+}
+
+// UpdateTrackHandler handles PATCH /api/tracks/{slug}. Requires AdminOnly middleware.
+func (h *Handlers) UpdateTrackHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.UpdateTrack")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		httputil.WriteError(w, http.StatusBadRequest, "slug is required")
+		return
+	}
+
+	var req study.UpdateTrackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	track, err := h.studySvc.UpdateTrack(ctx, slug, &req)
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeTrackError(w, err, "failed to update track")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, track)
+}
+
+// SetTrackLessonsHandler handles PUT /api/tracks/{slug}/lessons and replaces the
+// ordered lesson list of a track. Requires AdminOnly middleware.
+func (h *Handlers) SetTrackLessonsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.SetTrackLessons")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		httputil.WriteError(w, http.StatusBadRequest, "slug is required")
+		return
+	}
+
+	var req study.SetTrackLessonsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	track, err := h.studySvc.SetTrackLessons(ctx, slug, req.LessonSlugs)
+	if err != nil {
+		tracing.RecordError(span, err)
+		writeTrackError(w, err, "failed to update track lessons")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, track)
+}
+
+// DeleteTrackHandler handles DELETE /api/tracks/{slug}. Requires AdminOnly middleware.
+func (h *Handlers) DeleteTrackHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), "handler.DeleteTrack")
+	defer span.End()
+
+	if h.studySvc == nil {
+		httputil.WriteError(w, http.StatusInternalServerError, "study service unavailable")
+		return
+	}
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		httputil.WriteError(w, http.StatusBadRequest, "slug is required")
+		return
+	}
+
+	if err := h.studySvc.DeleteTrack(ctx, slug); err != nil {
+		tracing.RecordError(span, err)
+		writeTrackError(w, err, "failed to delete track")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeTrackError maps study track errors to HTTP responses
+func writeTrackError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, study.ErrTrackNotFound):
+		httputil.WriteError(w, http.StatusNotFound, "track not found")
+	case errors.Is(err, study.ErrTrackSlugTaken):
+		httputil.WriteError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, study.ErrTrackSlugRequired),
+		errors.Is(err, study.ErrTrackTitleRequired),
+		errors.Is(err, study.ErrUnknownLesson),
+		errors.Is(err, study.ErrDuplicateTrackLesson):
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		httputil.WriteError(w, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,105 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"donfra-api/internal/domain/study"
+	"donfra-api/internal/http/handlers"
+)
+
+// TestGetTrack_HiddenForRegularUser tests that unpublished tracks are not visible to regular users
+func TestGetTrack_HiddenForRegularUser(t *testing.T) {
+	var gotUnpublished bool
+	mockStudy := &MockStudyService{
+		GetTrackFunc: func(ctx context.Context, slug string, includeUnpublished bool) (*study.TrackDetail, error) {
+			gotUnpublished = includeUnpublished
+			return nil, study.ErrTrackNotFound
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/tracks/draft", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "draft")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	h.GetTrackHandler(w, req)
+
+	if gotUnpublished {
+		t.Error("regular user should not see unpublished content")
+	}
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+}
+
+// TestGetTrack_Navigation tests that ordered lessons and navigation are returned
+func TestGetTrack_Navigation(t *testing.T) {
+	mockStudy := &MockStudyService{
+		GetTrackFunc: func(ctx context.Context, slug string, includeUnpublished bool) (*study.TrackDetail, error) {
+			return &study.TrackDetail{
+				Track: study.Track{Slug: slug, Title: "Graphs", IsPublished: true},
+				Lessons: []study.TrackLessonEntry{
+					{Position: 1, Slug: "bfs", NextSlug: "dfs"},
+					{Position: 2, Slug: "dfs", PreviousSlug: "bfs"},
+				},
+			}, nil
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/tracks/graphs", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "graphs")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	h.GetTrackHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var track study.TrackDetail
+	json.NewDecoder(w.Body).Decode(&track)
+
+	if track.Slug != "graphs" || len(track.Lessons) != 2 {
+		t.Fatalf("unexpected track %+v", track)
+	}
+	if track.Lessons[0].NextSlug != "dfs" || track.Lessons[1].PreviousSlug != "bfs" {
+		t.Errorf("unexpected navigation %+v", track.Lessons)
+	}
+}
+
+// TestSetTrackLessons_UnknownLesson tests that unknown lesson slugs are rejected
+func TestSetTrackLessons_UnknownLesson(t *testing.T) {
+	mockStudy := &MockStudyService{
+		SetTrackLessonsFunc: func(ctx context.Context, slug string, lessonSlugs []string) (*study.TrackDetail, error) {
+			return nil, study.ErrUnknownLesson
+		},
+	}
+
+	h := handlers.New(nil, mockStudy, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/tracks/graphs/lessons", strings.NewReader(`{"lessons":["missing"]}`))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "graphs")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	h.SetTrackLessonsHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
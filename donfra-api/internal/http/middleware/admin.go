@@ -111,7 +111,7 @@ func RequireAdminUser(authSvc TokenValidator, userSvc UserAuthService) func(http
 			if userSvc != nil {
 				cookie, err := r.Cookie("auth_token")
 				if err == nil && cookie.Value != "" {
-					claims, err := userSvc.ValidateToken(cookie.Value)
+					claims, err := userSvc.ValidateToken(r.Context(), cookie.Value)
 					if err == nil && claims != nil && claims.Role == "admin" {
 						next.ServeHTTP(w, r)
 						return
@@ -124,3 +124,51 @@ func RequireAdminUser(authSvc TokenValidator, userSvc UserAuthService) func(http
 		})
 	}
 }
+
+// RequireLessonAuthor requires either an admin (as RequireAdminUser) or a user JWT token
+// with role=mentor via Cookie. Admin token callers are flagged with IsAdminContextKey and
+// users' info is injected into the context, so handlers can restrict what mentors change.
+func RequireLessonAuthor(authSvc TokenValidator, userSvc UserAuthService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// First, try admin token via Authorization header
+			authHeader := strings.TrimSpace(r.Header.Get("Authorization"))
+			if strings.HasPrefix(strings.ToLower(authHeader), "bearer ") {
+				token := strings.TrimSpace(authHeader[7:])
+				if token != "" && authSvc != nil {
+					claims, err := authSvc.Validate(token)
+					if err == nil && claims != nil {
+						subject, _ := claims.GetSubject()
+						if subject == "admin" {
+							ctx := context.WithValue(r.Context(), IsAdminContextKey, true)
+							next.ServeHTTP(w, r.WithContext(ctx))
+							return
+						}
+					}
+				}
+			}
+
+			// Second, try user JWT token via Cookie
+			if userSvc != nil {
+				cookie, err := r.Cookie("auth_token")
+				if err == nil && cookie.Value != "" {
+					claims, err := userSvc.ValidateToken(r.Context(), cookie.Value)
+					if err == nil && claims != nil {
+						if claims.Role != "admin" && claims.Role != "mentor" {
+							http.Error(w, "admin or mentor role required", http.StatusForbidden)
+							return
+						}
+						ctx := r.Context()
+						ctx = context.WithValue(ctx, "user_id", claims.UserID)
+						ctx = context.WithValue(ctx, "user_email", claims.Email)
+						ctx = context.WithValue(ctx, "user_role", claims.Role)
+						next.ServeHTTP(w, r.WithContext(ctx))
+						return
+					}
+				}
+			}
+
+			http.Error(w, "admin or mentor authentication required", http.StatusUnauthorized)
+		})
+	}
+}
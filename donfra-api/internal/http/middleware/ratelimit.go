@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"donfra-api/internal/pkg/httputil"
+)
+
+// ipWindow counts the requests of one client in the current window
+type ipWindow struct {
+	start time.Time
+	count int
+}
+
+// RateLimitByIP allows at most limit requests per client IP in each fixed window and
+// answers 429 Too Many Requests (with Retry-After) beyond that. Counts are kept in
+// memory, so each API instance limits on its own. X-Forwarded-For is only honored for
+// requests from trustedProxies (IPs or CIDRs); otherwise the peer address is the client.
+func RateLimitByIP(limit int, window time.Duration, trustedProxies []string) func(http.Handler) http.Handler {
+	trusted := parseTrustedProxies(trustedProxies)
+	var (
+		mu        sync.Mutex
+		clients   = map[string]*ipWindow{}
+		lastSweep = time.Now()
+	)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			now := time.Now()
+			ip := clientIP(r, trusted)
+
+			mu.Lock()
+			if now.Sub(lastSweep) >= window {
+				for key, c := range clients {
+					if now.Sub(c.start) >= window {
+						delete(clients, key)
+					}
+				}
+				lastSweep = now
+			}
+			c := clients[ip]
+			if c == nil || now.Sub(c.start) >= window {
+				c = &ipWindow{start: now}
+				clients[ip] = c
+			}
+			c.count++
+			allowed := c.count <= limit
+			retryAfter := c.start.Add(window).Sub(now)
+			mu.Unlock()
+
+			if !allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+				httputil.WriteError(w, http.StatusTooManyRequests, "too many requests, please try again later")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// parseTrustedProxies parses IPs and CIDRs, skipping (and logging) invalid entries
+func parseTrustedProxies(entries []string) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(entry); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+		} else {
+			log.Printf("[ratelimit] ignoring invalid trusted proxy %q", entry)
+		}
+	}
+	return prefixes
+}
+
+func isTrusted(ip string, trusted []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP returns the address of the client: the peer address, unless the peer is a
+// trusted proxy, in which case X-Forwarded-For is walked from the right and the first
+// address not belonging to a trusted proxy is used.
+func clientIP(r *http.Request, trusted []netip.Prefix) string {
+	peer, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		peer = r.RemoteAddr
+	}
+	if !isTrusted(peer, trusted) {
+		return peer
+	}
+	hops := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		if !isTrusted(hop, trusted) {
+			return hop
+		}
+		peer = hop
+	}
+	return peer
+}
@@ -0,0 +1,83 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"donfra-api/internal/http/middleware"
+)
+
+func TestRateLimitByIP(t *testing.T) {
+	handler := middleware.RateLimitByIP(2, time.Hour, []string{"172.18.0.0/16"})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	request := func(remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/password/forgot", nil)
+		req.RemoteAddr = remoteAddr
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	for i := 0; i < 2; i++ {
+		if w := request("10.0.0.1:1234", ""); w.Code != http.StatusNoContent {
+			t.Fatalf("request %d: expected status 204, got %d", i+1, w.Code)
+		}
+	}
+	w := request("10.0.0.1:5678", "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429 over the limit, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("expected a Retry-After header")
+	}
+
+	if w := request("10.0.0.2:1234", ""); w.Code != http.StatusNoContent {
+		t.Errorf("expected another client to be allowed, got %d", w.Code)
+	}
+
+	// Behind a trusted proxy, clients are told apart by the address the proxy appended
+	for i := 0; i < 2; i++ {
+		request("172.18.0.5:80", "198.51.100.7")
+	}
+	if w := request("172.18.0.5:80", "198.51.100.7"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429 for a forwarded client over the limit, got %d", w.Code)
+	}
+	// A client-supplied entry in front of the proxy's does not change the key
+	if w := request("172.18.0.5:80", "203.0.113.9, 198.51.100.7"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected a spoofed X-Forwarded-For behind the proxy to be ignored, got %d", w.Code)
+	}
+	if w := request("172.18.0.5:80", "198.51.100.8"); w.Code != http.StatusNoContent {
+		t.Errorf("expected a different forwarded client to be allowed, got %d", w.Code)
+	}
+}
+
+// TestRateLimitByIP_IgnoresSpoofedForwardedFor tests that clients connecting directly
+// cannot reset their bucket by sending a new X-Forwarded-For on each request
+func TestRateLimitByIP_IgnoresSpoofedForwardedFor(t *testing.T) {
+	handler := middleware.RateLimitByIP(2, time.Hour, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	for i, forwardedFor := range []string{"198.51.100.1", "198.51.100.2", "198.51.100.3"} {
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/password/forgot", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		want := http.StatusNoContent
+		if i == 2 {
+			want = http.StatusTooManyRequests
+		}
+		if w.Code != want {
+			t.Errorf("request %d with X-Forwarded-For %s: expected status %d, got %d", i+1, forwardedFor, want, w.Code)
+		}
+	}
+}
@@ -10,7 +10,7 @@ import (
 
 // UserAuthService defines the interface for user authentication.
 type UserAuthService interface {
-	ValidateToken(tokenString string) (*user.Claims, error)
+	ValidateToken(ctx context.Context, tokenString string) (*user.Claims, error)
 }
 
 // RequireAuth is a middleware that requires a valid JWT token in the cookie.
@@ -26,7 +26,7 @@ func RequireAuth(userSvc UserAuthService) func(http.Handler) http.Handler {
 			}
 
 			// Validate token
-			claims, err := userSvc.ValidateToken(cookie.Value)
+			claims, err := userSvc.ValidateToken(r.Context(), cookie.Value)
 			if err != nil {
 				httputil.WriteError(w, http.StatusUnauthorized, "invalid or expired token")
 				return
@@ -54,7 +54,7 @@ func OptionalAuth(userSvc UserAuthService) func(http.Handler) http.Handler {
 			cookie, err := r.Cookie("auth_token")
 			if err == nil {
 				// Validate token
-				claims, err := userSvc.ValidateToken(cookie.Value)
+				claims, err := userSvc.ValidateToken(r.Context(), cookie.Value)
 				if err == nil {
 					// Inject user information into context
 					ctx := r.Context()
@@ -2,6 +2,7 @@ package router
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
@@ -9,6 +10,7 @@ import (
 	"donfra-api/internal/config"
 	"donfra-api/internal/domain/auth"
 	"donfra-api/internal/domain/interview"
+	"donfra-api/internal/domain/problem"
 	"donfra-api/internal/domain/room"
 	"donfra-api/internal/domain/study"
 	"donfra-api/internal/domain/user"
@@ -16,7 +18,7 @@ import (
 	"donfra-api/internal/http/middleware"
 )
 
-func New(cfg config.Config, roomSvc *room.Service, studySvc *study.Service, authSvc *auth.AuthService, userSvc *user.Service, interviewSvc interview.Service) http.Handler {
+func New(cfg config.Config, roomSvc *room.Service, studySvc *study.Service, authSvc *auth.AuthService, userSvc *user.Service, interviewSvc interview.Service, problemSvc problem.Service) http.Handler {
 	root := chi.NewRouter()
 
 	// Tracing middleware (must be first to capture all requests)
@@ -26,7 +28,7 @@ func New(cfg config.Config, roomSvc *room.Service, studySvc *study.Service, auth
 		AllowedOrigins:   []string{"http://localhost:3000", "http://localhost:7777", "http://97.107.136.151:80"},
 		AllowedMethods:   []string{"GET", "POST", "OPTIONS"},
 		AllowedHeaders:   []string{"Accept", "Content-Type", "X-CSRF-Token", "Authorization"},
-		ExposedHeaders:   []string{"X-Request-Id"},
+		ExposedHeaders:   []string{"X-Request-Id", "X-Next-Cursor"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -37,13 +39,15 @@ func New(cfg config.Config, roomSvc *room.Service, studySvc *study.Service, auth
 		_, _ = w.Write([]byte("ok"))
 	})
 
-	h := handlers.New(roomSvc, studySvc, authSvc, userSvc, interviewSvc)
+	h := handlers.New(roomSvc, studySvc, authSvc, userSvc, interviewSvc, problemSvc)
 	v1 := chi.NewRouter()
 
 	// ===== User Authentication Routes (Public) =====
 	v1.Post("/auth/register", h.Register)
 	v1.Post("/auth/login", h.Login)
 	v1.Post("/auth/logout", h.Logout)
+	v1.With(middleware.RateLimitByIP(5, 15*time.Minute, cfg.TrustedProxies)).Post("/auth/password/forgot", h.ForgotPassword)
+	v1.Post("/auth/password/reset", h.ResetPassword)
 
 	// ===== User Routes (Protected) =====
 	v1.With(middleware.RequireAuth(userSvc)).Get("/auth/me", h.GetCurrentUser)
@@ -64,18 +68,127 @@ func New(cfg config.Config, roomSvc *room.Service, studySvc *study.Service, auth
 	// ===== Lesson Routes =====
 	// Public: list published lessons (with optional user auth)
 	v1.With(middleware.OptionalAuth(userSvc)).Get("/lessons", h.ListLessonsHandler)
+	v1.With(middleware.OptionalAuth(userSvc)).Get("/lessons/search", h.SearchLessonsHandler)
 	v1.With(middleware.OptionalAuth(userSvc)).Get("/lessons/{slug}", h.GetLessonBySlugHandler)
 
-	// Admin only: CRUD operations (supports both admin token and admin user JWT)
-	v1.With(middleware.RequireAdminUser(authSvc, userSvc)).Post("/lessons", h.CreateLessonHandler)
-	v1.With(middleware.RequireAdminUser(authSvc, userSvc)).Patch("/lessons/{slug}", h.UpdateLessonHandler)
+	// Admins and mentors: create and edit lessons. Mentors only edit their own lessons
+	// until they are published and not while they await review, and cannot publish them
+	// (see review routes)
+	v1.With(middleware.OptionalAuth(userSvc), middleware.RequireLessonAuthor(authSvc, userSvc)).Post("/lessons", h.CreateLessonHandler)
+	v1.With(middleware.OptionalAuth(userSvc), middleware.RequireLessonAuthor(authSvc, userSvc)).Patch("/lessons/{slug}", h.UpdateLessonHandler)
+	// Admin only: delete (supports both admin token and admin user JWT)
 	v1.With(middleware.RequireAdminUser(authSvc, userSvc)).Delete("/lessons/{slug}", h.DeleteLessonHandler)
 
+	// ===== Lesson Review Routes =====
+	// Authors submit their lesson for review; admins approve (publish) or request changes
+	v1.With(middleware.RequireLessonAuthor(authSvc, userSvc), h.RequireLessonOwner).Post("/lessons/{slug}/submit", h.SubmitLessonForReviewHandler)
+	v1.With(middleware.OptionalAuth(userSvc), middleware.RequireAdminUser(authSvc, userSvc)).Post("/lessons/{slug}/review", h.ReviewLessonHandler)
+
+	// Admin only: portable bundles (Markdown + front matter, .excalidraw drawings)
+	v1.With(middleware.RequireAdminUser(authSvc, userSvc)).Get("/lessons/export", h.ExportLessonsHandler)
+	v1.With(middleware.OptionalAuth(userSvc), middleware.RequireAdminUser(authSvc, userSvc)).Post("/lessons/import", h.ImportLessonsHandler)
+
+	// Admin only: revision history, diff and rollback
+	v1.With(middleware.RequireAdminUser(authSvc, userSvc)).Get("/lessons/{slug}/revisions", h.ListLessonRevisionsHandler)
+	v1.With(middleware.RequireAdminUser(authSvc, userSvc)).Get("/lessons/{slug}/revisions/diff", h.DiffLessonRevisionsHandler)
+	v1.With(middleware.RequireAdminUser(authSvc, userSvc)).Get("/lessons/{slug}/revisions/{revision_id}", h.GetLessonRevisionHandler)
+	v1.With(middleware.OptionalAuth(userSvc), middleware.RequireAdminUser(authSvc, userSvc)).Post("/lessons/{slug}/revisions/{revision_id}/restore", h.RestoreLessonRevisionHandler)
+
+	// ===== Lesson Comment Routes =====
+	// Public: read threads; authenticated users comment, authors edit/delete their own,
+	// admins and mentors moderate (hide, lock thread)
+	v1.With(middleware.OptionalAuth(userSvc)).Get("/lessons/{slug}/comments", h.ListCommentsHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Post("/lessons/{slug}/comments", h.CreateCommentHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Patch("/lessons/{slug}/comments/{id}", h.UpdateCommentHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Delete("/lessons/{slug}/comments/{id}", h.DeleteCommentHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Post("/lessons/{slug}/comments/{id}/moderate", h.ModerateCommentHandler)
+
+	// Lesson owners: prerequisites (lessons may be locked until they are completed)
+	v1.With(middleware.RequireLessonAuthor(authSvc, userSvc)).Put("/lessons/{slug}/prerequisites", h.SetPrerequisitesHandler)
+
+	// ===== Lesson Preview Routes =====
+	// Lesson owners: signed, expiring preview links for unpublished lessons (GET /lessons/{slug}?preview=)
+	v1.With(middleware.OptionalAuth(userSvc), middleware.RequireLessonAuthor(authSvc, userSvc), h.RequireLessonOwner).Post("/lessons/{slug}/previews", h.CreateLessonPreviewHandler)
+	v1.With(middleware.RequireLessonAuthor(authSvc, userSvc), h.RequireLessonOwner).Get("/lessons/{slug}/previews", h.ListLessonPreviewsHandler)
+	v1.With(middleware.RequireLessonAuthor(authSvc, userSvc), h.RequireLessonOwner).Delete("/lessons/{slug}/previews/{id}", h.RevokeLessonPreviewHandler)
+
+	// ===== Lesson Exercise Routes =====
+	// Lesson owners: manage exercises (prompt, starter code, tests)
+	v1.With(middleware.RequireLessonAuthor(authSvc, userSvc), h.RequireLessonOwner).Get("/lessons/{slug}/exercises", h.ListExercisesHandler)
+	v1.With(middleware.RequireLessonAuthor(authSvc, userSvc)).Put("/lessons/{slug}/exercises", h.SetExercisesHandler)
+	// Authenticated users: submit code to be judged; the best result is kept per user
+	v1.With(middleware.RequireAuth(userSvc)).Post("/lessons/{slug}/exercises/{key}/submit", h.SubmitExerciseHandler)
+
+	// ===== Lesson Quiz Routes =====
+	// Lesson owners: manage the quiz (answers are never sent to students)
+	v1.With(middleware.RequireLessonAuthor(authSvc, userSvc), h.RequireLessonOwner).Get("/lessons/{slug}/quiz", h.GetQuizHandler)
+	v1.With(middleware.RequireLessonAuthor(authSvc, userSvc)).Put("/lessons/{slug}/quiz", h.SetQuizHandler)
+	v1.With(middleware.RequireLessonAuthor(authSvc, userSvc)).Delete("/lessons/{slug}/quiz", h.DeleteQuizHandler)
+	// Authenticated users: submit answers for server-side grading and view attempt history
+	v1.With(middleware.RequireAuth(userSvc)).Post("/lessons/{slug}/quiz/attempts", h.SubmitQuizHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Get("/lessons/{slug}/quiz/attempts", h.ListQuizAttemptsHandler)
+
+	// ===== Lesson Asset Routes =====
+	// Public: content-addressed asset URLs referenced from lessons
+	v1.Get("/assets/{key}", h.ServeAssetHandler)
+	// Admin only: list and delete. Admins and mentors upload (the uploader is recorded)
+	v1.With(middleware.RequireAdminUser(authSvc, userSvc)).Get("/assets", h.ListAssetsHandler)
+	v1.With(middleware.RequireLessonAuthor(authSvc, userSvc)).Post("/assets", h.UploadAssetHandler)
+	v1.With(middleware.RequireAdminUser(authSvc, userSvc)).Delete("/assets/{key}", h.DeleteAssetHandler)
+
+	// ===== Track Routes =====
+	// Public: published tracks (admins also see unpublished)
+	v1.With(middleware.OptionalAuth(userSvc)).Get("/tracks", h.ListTracksHandler)
+	v1.With(middleware.OptionalAuth(userSvc)).Get("/tracks/{slug}", h.GetTrackHandler)
+
+	// Admin only: track CRUD and lesson ordering
+	v1.With(middleware.RequireAdminUser(authSvc, userSvc)).Post("/tracks", h.CreateTrackHandler)
+	v1.With(middleware.RequireAdminUser(authSvc, userSvc)).Patch("/tracks/{slug}", h.UpdateTrackHandler)
+	v1.With(middleware.RequireAdminUser(authSvc, userSvc)).Put("/tracks/{slug}/lessons", h.SetTrackLessonsHandler)
+	v1.With(middleware.RequireAdminUser(authSvc, userSvc)).Delete("/tracks/{slug}", h.DeleteTrackHandler)
+
+	// ===== Personal Study Routes =====
+	// Authenticated users: lesson progress, bookmarks and private notes
+	v1.With(middleware.RequireAuth(userSvc)).Get("/me/progress", h.GetMyProgressHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Put("/me/progress/{slug}", h.UpdateLessonProgressHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Get("/me/bookmarks", h.ListBookmarksHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Put("/me/bookmarks/{slug}", h.AddBookmarkHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Delete("/me/bookmarks/{slug}", h.RemoveBookmarkHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Get("/me/notes", h.ListNotesHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Post("/me/notes", h.CreateNoteHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Get("/me/notes/{id}", h.GetNoteHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Patch("/me/notes/{id}", h.UpdateNoteHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Delete("/me/notes/{id}", h.DeleteNoteHandler)
+
 	// ===== Interview Room Routes =====
 	// Authenticated users can create/join/close interview rooms
 	v1.With(middleware.RequireAuth(userSvc)).Post("/interview/init", h.InitInterviewRoomHandler)
-	v1.Post("/interview/join", h.JoinInterviewRoomHandler) // Public: anyone with invite token can join
+	// Public: anyone with invite token can join; limited per IP since each lobby join stores an entry
+	v1.With(middleware.RateLimitByIP(10, time.Minute, cfg.TrustedProxies)).Post("/interview/join", h.JoinInterviewRoomHandler)
+	v1.Get("/interview/join/status", h.GetJoinStatusHandler) // Public: lobby joiners poll with their lobby_ticket cookie
 	v1.With(middleware.RequireAuth(userSvc)).Post("/interview/close", h.CloseInterviewRoomHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Post("/interview/schedule", h.ScheduleInterviewRoomHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Get("/interview/scheduled", h.ListScheduledInterviewsHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Get("/interview/{room_id}/calendar.ics", h.GetInterviewCalendarHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Post("/interview/{room_id}/start", h.StartInterviewHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Post("/interview/{room_id}/end", h.EndInterviewHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Get("/interview/{room_id}/lobby", h.ListLobbyHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Post("/interview/{room_id}/lobby/{admission_id}/admit", h.AdmitLobbyEntryHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Post("/interview/{room_id}/lobby/{admission_id}/reject", h.RejectLobbyEntryHandler)
+	// Owner or participants holding room_access cookie: problem statement and starter code
+	v1.With(middleware.OptionalAuth(userSvc)).Get("/interview/{room_id}/problem", h.GetInterviewRoomProblemHandler)
+	v1.With(middleware.OptionalAuth(userSvc)).Post("/interview/{room_id}/run", h.RunInterviewCodeHandler)
+	// Owner only: scorecards and post-interview report (available after close)
+	v1.With(middleware.RequireAuth(userSvc)).Post("/interview/{room_id}/scorecard", h.SubmitScorecardHandler)
+	v1.With(middleware.RequireAuth(userSvc)).Get("/interview/{room_id}/report", h.GetInterviewReportHandler)
+
+	// ===== Problem Bank Routes =====
+	// Admin only: CRUD operations (supports both admin token and admin user JWT)
+	v1.With(middleware.RequireAdminUser(authSvc, userSvc)).Get("/problems", h.ListProblemsHandler)
+	v1.With(middleware.RequireAdminUser(authSvc, userSvc)).Get("/problems/{id}", h.GetProblemHandler)
+	v1.With(middleware.RequireAdminUser(authSvc, userSvc)).Post("/problems", h.CreateProblemHandler)
+	v1.With(middleware.RequireAdminUser(authSvc, userSvc)).Patch("/problems/{id}", h.UpdateProblemHandler)
+	v1.With(middleware.RequireAdminUser(authSvc, userSvc)).Delete("/problems/{id}", h.DeleteProblemHandler)
 
 	root.Mount("/api/v1", v1)
 	root.Mount("/api", v1)
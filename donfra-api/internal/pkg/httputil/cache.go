@@ -0,0 +1,85 @@
+package httputil
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WriteJSONCached writes v as a 200 JSON response with a weak ETag derived from a hash
+// of the body, so per-user fields and anything else that changes the response are
+// covered. No Last-Modified is sent: these bodies depend on more than a single
+// timestamp. Clients sending a matching If-None-Match get 304 Not Modified without a body.
+func WriteJSONCached(w http.ResponseWriter, r *http.Request, v any) {
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(v); err != nil {
+		WriteError(w, http.StatusInternalServerError, "failed to encode response")
+		return
+	}
+
+	sum := sha256.Sum256(body.Bytes())
+	etag := fmt.Sprintf(`W/"%s"`, hex.EncodeToString(sum[:16]))
+
+	h := w.Header()
+	h.Set("ETag", etag)
+	h.Set("Cache-Control", "private, no-cache")
+	h.Add("Vary", "Authorization, Cookie")
+
+	if notModified(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	h.Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body.Bytes())
+}
+
+// CheckNotModified sets validators for a response that changed last at lastModified
+// and whose body also depends on variant (e.g. who is asking): a weak ETag combining
+// both, Last-Modified and the same caching headers as WriteJSONCached. It answers
+// 304 Not Modified and returns true when the request's If-None-Match matches or, when
+// If-None-Match is absent, If-Modified-Since is not older than lastModified. Call it
+// before building the response; on false, write the body as usual.
+func CheckNotModified(w http.ResponseWriter, r *http.Request, lastModified time.Time, variant string) bool {
+	sum := sha256.Sum256([]byte(variant))
+	etag := fmt.Sprintf(`W/"%x-%s"`, lastModified.UnixNano(), hex.EncodeToString(sum[:8]))
+
+	h := w.Header()
+	h.Set("ETag", etag)
+	h.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	h.Set("Cache-Control", "private, no-cache")
+	h.Add("Vary", "Authorization, Cookie")
+
+	fresh := false
+	if r.Header.Get("If-None-Match") != "" {
+		fresh = notModified(r, etag)
+	} else if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil {
+		fresh = !lastModified.Truncate(time.Second).After(since)
+	}
+	if fresh {
+		w.WriteHeader(http.StatusNotModified)
+	}
+	return fresh
+}
+
+// notModified reports whether If-None-Match matches etag using weak comparison
+func notModified(r *http.Request, etag string) bool {
+	for _, candidate := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || (candidate != "" && weakETag(candidate) == weakETag(etag)) {
+			return true
+		}
+	}
+	return false
+}
+
+// weakETag strips the weak indicator for weak comparison
+func weakETag(tag string) string {
+	return strings.TrimPrefix(tag, "W/")
+}
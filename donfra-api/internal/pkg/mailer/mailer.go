@@ -0,0 +1,85 @@
+// Package mailer delivers transactional emails such as password reset links.
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+)
+
+// Message is a plain text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends emails.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// LogMailer drops emails, logging only their recipient and subject. It is used when no
+// SMTP server is configured. Bodies are never logged because they carry secrets such as
+// single-use password reset links.
+type LogMailer struct{}
+
+// NewLogMailer creates a mailer that only logs that messages were suppressed.
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+// Send logs the recipient and subject of msg without sending it.
+func (LogMailer) Send(ctx context.Context, msg Message) error {
+	log.Printf("[mailer] email suppressed (no SMTP server configured): to=%s subject=%q", msg.To, msg.Subject)
+	return nil
+}
+
+// SMTPMailer sends emails through an SMTP server using PLAIN auth when a username is set.
+type SMTPMailer struct {
+	addr     string
+	from     string
+	username string
+	password string
+}
+
+// NewSMTPMailer creates a mailer for the SMTP server at addr ("host:port").
+func NewSMTPMailer(addr, from, username, password string) *SMTPMailer {
+	return &SMTPMailer{addr: addr, from: from, username: username, password: password}
+}
+
+// Send delivers msg. The context is not used by net/smtp.
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	// from may carry a display name ("Donfra <no-reply@donfra.local>"); the SMTP
+	// envelope only takes the bare address
+	sender, err := mail.ParseAddress(m.from)
+	if err != nil {
+		return fmt.Errorf("invalid sender address %q: %w", m.from, err)
+	}
+
+	var auth smtp.Auth
+	if m.username != "" {
+		host, _, err := net.SplitHostPort(m.addr)
+		if err != nil {
+			return fmt.Errorf("invalid SMTP address %q: %w", m.addr, err)
+		}
+		auth = smtp.PlainAuth("", m.username, m.password, host)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", m.from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	if err := smtp.SendMail(m.addr, auth, sender.Address, []string{msg.To}, []byte(b.String())); err != nil {
+		return fmt.Errorf("send mail: %w", err)
+	}
+	return nil
+}
@@ -46,6 +46,7 @@ var (
 	AttrDBOperation = attribute.Key("db.operation")
 	AttrDBTable     = attribute.Key("db.table")
 	AttrDBQuery     = attribute.Key("db.query")
+	AttrCacheHit    = attribute.Key("cache.hit")
 
 	// User/Auth attributes
 	AttrUserID     = attribute.Key("user.id")
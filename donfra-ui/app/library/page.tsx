@@ -33,29 +33,40 @@ function LibraryInner() {
   const [lessons, setLessons] = useState<Lesson[]>([]);
   const [loadingList, setLoadingList] = useState(true);
   const [listError, setListError] = useState<string | null>(null);
+  // Cursor of the next page (X-Next-Cursor header); null once all lessons are loaded
+  const [nextCursor, setNextCursor] = useState<string | null>(null);
+  const [loadingMore, setLoadingMore] = useState(false);
 
   // Check if user is admin via user authentication OR admin token
   const isUserAdmin = user?.role === "admin";
   const adminToken = typeof window !== "undefined" ? localStorage.getItem("admin_token") : null;
   const isAdmin = isUserAdmin || Boolean(adminToken);
 
+  // fetchPage loads one page of lessons; the list endpoint is paginated by cursor
+  const fetchPage = async (cursor: string | null) => {
+    let token: string | null = null;
+    if (typeof window !== "undefined") {
+      token = localStorage.getItem("admin_token");
+    }
+
+    const headers: HeadersInit = {};
+    if (token) {
+      headers.Authorization = `Bearer ${token}`;
+    }
+
+    const url = cursor ? `${API_ROOT}/lessons?cursor=${encodeURIComponent(cursor)}` : `${API_ROOT}/lessons`;
+    const res = await fetch(url, { headers, credentials: 'include' });
+    const data = await res.json();
+    if (!Array.isArray(data)) throw new Error("Unexpected response");
+    return { items: data as Lesson[], next: res.headers.get("X-Next-Cursor") };
+  };
+
   useEffect(() => {
     (async () => {
       try {
-        let token: string | null = null;
-        if (typeof window !== "undefined") {
-          token = localStorage.getItem("admin_token");
-        }
-
-        const headers: HeadersInit = {};
-        if (token) {
-          headers.Authorization = `Bearer ${token}`;
-        }
-
-        const res = await fetch(`${API_ROOT}/lessons`, { headers, credentials: 'include' });
-        const data = await res.json();
-        if (!Array.isArray(data)) throw new Error("Unexpected response");
-        setLessons(data);
+        const page = await fetchPage(null);
+        setLessons(page.items);
+        setNextCursor(page.next);
       } catch (err: any) {
         setListError(err?.message || "Failed to load lessons");
       } finally {
@@ -65,6 +76,20 @@ function LibraryInner() {
     // eslint-disable-next-line react-hooks/exhaustive-deps
   }, []);
 
+  const loadMore = async () => {
+    if (!nextCursor || loadingMore) return;
+    setLoadingMore(true);
+    try {
+      const page = await fetchPage(nextCursor);
+      setLessons((prev) => [...prev, ...page.items]);
+      setNextCursor(page.next);
+    } catch (err: any) {
+      setListError(err?.message || "Failed to load lessons");
+    } finally {
+      setLoadingMore(false);
+    }
+  };
+
   return (
     <main className="admin-shell" style={{ paddingTop: 100 }}>
       <video
@@ -158,6 +183,25 @@ function LibraryInner() {
               </tbody>
             </table>
           )}
+          {!loadingList && !listError && nextCursor && (
+            <div style={{ marginTop: 16, textAlign: "center" }}>
+              <button
+                onClick={loadMore}
+                disabled={loadingMore}
+                style={{
+                  padding: "8px 16px",
+                  borderRadius: 10,
+                  border: "1px solid rgba(169,142,100,0.35)",
+                  background: "rgba(169,142,100,0.08)",
+                  color: "#f4d18c",
+                  cursor: loadingMore ? "default" : "pointer",
+                  fontWeight: 600,
+                }}
+              >
+                {loadingMore ? "Loading…" : "Load more"}
+              </button>
+            </div>
+          )}
         </section>
       </div>
     </main>
@@ -48,7 +48,7 @@ export const api = {
   },
   study: {
     list: () =>
-      getJSON<Array<{ slug: string; title: string; markdown: string; excalidraw: any; createdAt: string; updatedAt: string; isPublished: boolean }>>("/lessons"),
+      getJSON<Array<{ id: number; slug: string; title: string; excerpt: string; createdAt: string; updatedAt: string; isPublished: boolean }>>("/lessons"),
     get: (slug: string) =>
       getJSON<{ slug: string; title: string; markdown: string; excalidraw: any; createdAt: string; updatedAt: string; isPublished: boolean }>(`/lessons/${slug}`),
     create: (data: { slug: string; title: string; markdown: string; excalidraw: any; isPublished?: boolean }, token: string) =>
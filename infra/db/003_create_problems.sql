@@ -0,0 +1,27 @@
+-- Migration: Create problems table (interview question bank)
+-- Problems can be attached to interview rooms at creation time
+
+CREATE TABLE IF NOT EXISTS problems (
+    id SERIAL PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    statement TEXT NOT NULL,
+    difficulty VARCHAR(20) NOT NULL DEFAULT 'medium',
+    tags JSONB NOT NULL DEFAULT '[]',
+    starter_code JSONB NOT NULL DEFAULT '{}',
+    test_cases JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT problems_difficulty_check CHECK (difficulty IN ('easy', 'medium', 'hard'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_problems_difficulty ON problems(difficulty);
+CREATE INDEX IF NOT EXISTS idx_problems_tags ON problems USING GIN (tags);
+
+CREATE TRIGGER update_problems_updated_at BEFORE UPDATE ON problems
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Attach an optional problem to interview rooms
+ALTER TABLE interview_rooms
+    ADD COLUMN IF NOT EXISTS problem_id INTEGER REFERENCES problems(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_interview_rooms_problem_id ON interview_rooms(problem_id);
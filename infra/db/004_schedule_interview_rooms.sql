@@ -0,0 +1,10 @@
+-- Migration: Scheduled interviews
+-- Rooms can be planned for a future time slot with candidate details
+
+ALTER TABLE interview_rooms
+    ADD COLUMN IF NOT EXISTS scheduled_at TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS duration_minutes INTEGER DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS candidate_name VARCHAR(255) DEFAULT '',
+    ADD COLUMN IF NOT EXISTS candidate_email VARCHAR(255) DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_interview_rooms_scheduled_at ON interview_rooms(scheduled_at);
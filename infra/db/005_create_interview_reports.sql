@@ -0,0 +1,29 @@
+-- Migration: Interview run history and scorecards
+-- Used to assemble post-interview reports
+
+CREATE TABLE IF NOT EXISTS interview_runs (
+    id SERIAL PRIMARY KEY,
+    room_id VARCHAR(255) NOT NULL,
+    language VARCHAR(20) NOT NULL DEFAULT 'python',
+    code TEXT NOT NULL,
+    stdout TEXT DEFAULT '',
+    stderr TEXT DEFAULT '',
+    timed_out BOOLEAN NOT NULL DEFAULT false,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_interview_runs_room_id ON interview_runs(room_id);
+
+CREATE TABLE IF NOT EXISTS interview_scorecards (
+    id SERIAL PRIMARY KEY,
+    room_id VARCHAR(255) NOT NULL,
+    author_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rating INTEGER NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    recommendation VARCHAR(20) NOT NULL,
+    notes TEXT DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_scorecards_room_author ON interview_scorecards(room_id, author_id);
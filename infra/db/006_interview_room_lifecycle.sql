@@ -0,0 +1,26 @@
+-- Migration: Interview room lifecycle state machine
+-- scheduled -> waiting -> in_progress -> ended -> archived
+
+ALTER TABLE interview_rooms
+    ADD COLUMN IF NOT EXISTS state VARCHAR(20) NOT NULL DEFAULT 'waiting',
+    ADD COLUMN IF NOT EXISTS opened_at TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS started_at TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS ended_at TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ NULL;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'interview_rooms_state_check') THEN
+        ALTER TABLE interview_rooms
+            ADD CONSTRAINT interview_rooms_state_check
+            CHECK (state IN ('scheduled', 'waiting', 'in_progress', 'ended', 'archived'));
+    END IF;
+END
+$$;
+
+-- Backfill existing rooms
+UPDATE interview_rooms SET state = 'scheduled' WHERE scheduled_at IS NOT NULL AND deleted_at IS NULL;
+UPDATE interview_rooms SET opened_at = created_at WHERE scheduled_at IS NULL AND opened_at IS NULL;
+UPDATE interview_rooms SET state = 'archived', ended_at = deleted_at, archived_at = deleted_at WHERE deleted_at IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_interview_rooms_state ON interview_rooms(state);
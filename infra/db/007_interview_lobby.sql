@@ -0,0 +1,18 @@
+-- Migration: Interview lobby (waiting room) and admission control
+
+ALTER TABLE interview_rooms
+    ADD COLUMN IF NOT EXISTS lobby_enabled BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS interview_admissions (
+    id SERIAL PRIMARY KEY,
+    room_id VARCHAR(255) NOT NULL,
+    display_name VARCHAR(80) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    ticket_hash VARCHAR(64) NOT NULL UNIQUE,
+    decided_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT interview_admissions_status_check CHECK (status IN ('pending', 'admitted', 'rejected'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_interview_admissions_room_id ON interview_admissions(room_id);
@@ -0,0 +1,25 @@
+-- Migration: Lesson revision history
+-- Every create/update/restore of a lesson writes a full snapshot row
+
+CREATE TABLE IF NOT EXISTS lesson_revisions (
+    id SERIAL PRIMARY KEY,
+    lesson_id INTEGER NOT NULL REFERENCES lessons(id) ON DELETE CASCADE,
+    number INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    markdown TEXT NOT NULL,
+    excalidraw JSONB NOT NULL,
+    is_published BOOLEAN NOT NULL DEFAULT FALSE,
+    author_id INTEGER NULL REFERENCES users(id) ON DELETE SET NULL,
+    author_email VARCHAR(255) NOT NULL DEFAULT '',
+    restored_from INTEGER NULL REFERENCES lesson_revisions(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT lesson_revisions_lesson_number_unique UNIQUE (lesson_id, number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_lesson_revisions_lesson_id ON lesson_revisions(lesson_id);
+
+-- Backfill a baseline revision for existing lessons so their current content can be restored
+INSERT INTO lesson_revisions (lesson_id, number, title, markdown, excalidraw, is_published, created_at)
+SELECT l.id, 1, l.title, l.markdown, l.excalidraw, l.is_published, l.updated_at
+FROM lessons l
+WHERE NOT EXISTS (SELECT 1 FROM lesson_revisions r WHERE r.lesson_id = l.id);
@@ -0,0 +1,11 @@
+-- Migration: Full-text search over lessons
+-- Title matches (weight A) rank above markdown body matches (weight B)
+
+ALTER TABLE lessons
+    ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(markdown, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_lessons_search_vector ON lessons USING GIN (search_vector);
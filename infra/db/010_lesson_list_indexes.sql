@@ -0,0 +1,6 @@
+-- Migration: Indexes for keyset pagination of lesson lists
+-- Each sort key is paired with id as a tie-breaker to match the ORDER BY used by the API
+
+CREATE INDEX IF NOT EXISTS idx_lessons_created_at_id ON lessons (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_lessons_updated_at_id ON lessons (updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_lessons_title_id ON lessons (title, id);
@@ -0,0 +1,30 @@
+-- Migration: Lesson tags/categories and learning tracks
+
+ALTER TABLE lessons
+    ADD COLUMN IF NOT EXISTS category VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+CREATE INDEX IF NOT EXISTS idx_lessons_tags ON lessons USING GIN (tags);
+CREATE INDEX IF NOT EXISTS idx_lessons_category ON lessons(category);
+
+CREATE TABLE IF NOT EXISTS tracks (
+    id SERIAL PRIMARY KEY,
+    slug TEXT NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    is_published BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tracks_slug ON tracks(slug);
+
+CREATE TABLE IF NOT EXISTS track_lessons (
+    track_id INTEGER NOT NULL REFERENCES tracks(id) ON DELETE CASCADE,
+    lesson_id INTEGER NOT NULL REFERENCES lessons(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (track_id, lesson_id),
+    CONSTRAINT track_lessons_position_unique UNIQUE (track_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_track_lessons_lesson_id ON track_lessons(lesson_id);
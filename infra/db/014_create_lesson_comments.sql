@@ -0,0 +1,22 @@
+-- Migration: Threaded lesson comments with moderation
+
+CREATE TABLE IF NOT EXISTS lesson_comments (
+    id SERIAL PRIMARY KEY,
+    lesson_id INTEGER NOT NULL REFERENCES lessons(id) ON DELETE CASCADE,
+    parent_id INTEGER REFERENCES lesson_comments(id) ON DELETE CASCADE,
+    root_id INTEGER REFERENCES lesson_comments(id) ON DELETE CASCADE,
+    author_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    is_hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    is_locked BOOLEAN NOT NULL DEFAULT FALSE,
+    edited_at TIMESTAMPTZ,
+    removed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_lesson_comments_lesson_id ON lesson_comments(lesson_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_lesson_comments_root_id ON lesson_comments(root_id);
+CREATE INDEX IF NOT EXISTS idx_lesson_comments_parent_id ON lesson_comments(parent_id);
+-- Supports the per-user rate limit lookup
+CREATE INDEX IF NOT EXISTS idx_lesson_comments_author_created ON lesson_comments(author_id, created_at);
@@ -0,0 +1,15 @@
+-- Migration: Lesson assets (content-addressed uploads kept in a blob store)
+
+CREATE TABLE IF NOT EXISTS lesson_assets (
+    id SERIAL PRIMARY KEY,
+    hash CHAR(64) NOT NULL,
+    key VARCHAR(80) NOT NULL,
+    filename VARCHAR(255) NOT NULL DEFAULT '',
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    uploaded_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_lesson_assets_hash ON lesson_assets(hash);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_lesson_assets_key ON lesson_assets(key);
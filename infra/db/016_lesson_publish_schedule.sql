@@ -0,0 +1,8 @@
+-- Migration: Scheduled publishing and unpublishing of lessons
+
+ALTER TABLE lessons ADD COLUMN IF NOT EXISTS publish_at TIMESTAMPTZ;
+ALTER TABLE lessons ADD COLUMN IF NOT EXISTS unpublish_at TIMESTAMPTZ;
+
+-- The publish scheduler only scans lessons with a pending schedule
+CREATE INDEX IF NOT EXISTS idx_lessons_publish_at ON lessons(publish_at) WHERE publish_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_lessons_unpublish_at ON lessons(unpublish_at) WHERE unpublish_at IS NOT NULL;
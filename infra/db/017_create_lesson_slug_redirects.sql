@@ -0,0 +1,9 @@
+-- Migration: Former lesson slugs redirect to the renamed lesson
+
+CREATE TABLE IF NOT EXISTS lesson_slug_redirects (
+    old_slug TEXT PRIMARY KEY,
+    lesson_id INTEGER NOT NULL REFERENCES lessons(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_lesson_slug_redirects_lesson_id ON lesson_slug_redirects(lesson_id);
@@ -0,0 +1,32 @@
+-- Migration: Runnable lesson exercises and each user's best submission
+
+CREATE TABLE IF NOT EXISTS lesson_exercises (
+    id SERIAL PRIMARY KEY,
+    lesson_id INTEGER NOT NULL REFERENCES lessons(id) ON DELETE CASCADE,
+    key VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    title TEXT NOT NULL,
+    prompt TEXT NOT NULL DEFAULT '',
+    language VARCHAR(20) NOT NULL DEFAULT 'python',
+    starter_code TEXT NOT NULL DEFAULT '',
+    tests JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_lesson_exercises_lesson_key ON lesson_exercises(lesson_id, key);
+
+CREATE TABLE IF NOT EXISTS exercise_results (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    exercise_id INTEGER NOT NULL REFERENCES lesson_exercises(id) ON DELETE CASCADE,
+    passed BOOLEAN NOT NULL DEFAULT FALSE,
+    best_passed INTEGER NOT NULL DEFAULT 0,
+    total INTEGER NOT NULL DEFAULT 0,
+    best_code TEXT NOT NULL DEFAULT '',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_exercise_results_user_exercise ON exercise_results(user_id, exercise_id);
@@ -0,0 +1,25 @@
+-- Migration: Lesson quizzes graded server-side, with per-user attempt history
+
+CREATE TABLE IF NOT EXISTS lesson_quizzes (
+    id SERIAL PRIMARY KEY,
+    lesson_id INTEGER NOT NULL UNIQUE REFERENCES lessons(id) ON DELETE CASCADE,
+    passing_score INTEGER NOT NULL DEFAULT 70 CHECK (passing_score BETWEEN 1 AND 100),
+    completes_lesson BOOLEAN NOT NULL DEFAULT FALSE,
+    questions JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS quiz_attempts (
+    id SERIAL PRIMARY KEY,
+    quiz_id INTEGER NOT NULL REFERENCES lesson_quizzes(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    score INTEGER NOT NULL,
+    correct INTEGER NOT NULL,
+    total INTEGER NOT NULL,
+    passed BOOLEAN NOT NULL,
+    answers JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_quiz_attempts_quiz_user ON quiz_attempts(quiz_id, user_id, created_at DESC);
@@ -0,0 +1,5 @@
+-- Migration: Cache rendered lesson HTML and table of contents per revision
+
+ALTER TABLE lesson_revisions ADD COLUMN IF NOT EXISTS rendered_html TEXT;
+ALTER TABLE lesson_revisions ADD COLUMN IF NOT EXISTS rendered_toc JSONB;
+ALTER TABLE lesson_revisions ADD COLUMN IF NOT EXISTS renderer_version INTEGER NOT NULL DEFAULT 0;
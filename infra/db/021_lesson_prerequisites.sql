@@ -0,0 +1,14 @@
+-- Migration: Lesson prerequisites and gated access (required role, locked until prerequisites are completed)
+
+ALTER TABLE lessons ADD COLUMN IF NOT EXISTS required_role VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE lessons ADD COLUMN IF NOT EXISTS require_prerequisites BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS lesson_prerequisites (
+    lesson_id INTEGER NOT NULL REFERENCES lessons(id) ON DELETE CASCADE,
+    prerequisite_id INTEGER NOT NULL REFERENCES lessons(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (lesson_id, prerequisite_id),
+    CHECK (lesson_id <> prerequisite_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_lesson_prerequisites_prerequisite_id ON lesson_prerequisites(prerequisite_id);
@@ -0,0 +1,14 @@
+-- Migration: Signed, expiring preview links for unpublished lessons
+
+CREATE TABLE IF NOT EXISTS lesson_previews (
+    id SERIAL PRIMARY KEY,
+    lesson_id INTEGER NOT NULL REFERENCES lessons(id) ON DELETE CASCADE,
+    label VARCHAR(200) NOT NULL DEFAULT '',
+    created_by_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_by_email VARCHAR(255) NOT NULL DEFAULT '',
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_lesson_previews_lesson_id ON lesson_previews(lesson_id);
@@ -0,0 +1,20 @@
+-- Migration: Lesson ownership and the publishing review workflow for mentors
+
+ALTER TABLE lessons ADD COLUMN IF NOT EXISTS owner_id INTEGER REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE lessons ADD COLUMN IF NOT EXISTS review_status VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE lessons ADD COLUMN IF NOT EXISTS review_note TEXT NOT NULL DEFAULT '';
+ALTER TABLE lessons ADD COLUMN IF NOT EXISTS submitted_at TIMESTAMPTZ;
+ALTER TABLE lessons ADD COLUMN IF NOT EXISTS reviewed_by_id INTEGER REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE lessons ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMPTZ;
+
+-- Existing lessons belong to whoever wrote their first revision
+UPDATE lessons l SET owner_id = (
+    SELECT r.author_id FROM lesson_revisions r
+    WHERE r.lesson_id = l.id
+    ORDER BY r.number ASC
+    LIMIT 1
+)
+WHERE l.owner_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_lessons_owner_id ON lessons(owner_id);
+CREATE INDEX IF NOT EXISTS idx_lessons_review_status ON lessons(review_status) WHERE review_status <> '';